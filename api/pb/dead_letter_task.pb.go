@@ -0,0 +1,308 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: dead_letter_task.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// state of a background task that failed to process
+type DeadLetterTaskState int32
+
+const (
+	DeadLetterTaskState_DEAD_LETTER_TASK_STATE_UNSPECIFIED DeadLetterTaskState = 0
+	// the task exhausted its retries and is no longer processed
+	DeadLetterTaskState_DEAD_LETTER_TASK_STATE_ARCHIVED DeadLetterTaskState = 1
+	// the task failed and is waiting to be retried
+	DeadLetterTaskState_DEAD_LETTER_TASK_STATE_RETRY DeadLetterTaskState = 2
+)
+
+// Enum value maps for DeadLetterTaskState.
+var (
+	DeadLetterTaskState_name = map[int32]string{
+		0: "DEAD_LETTER_TASK_STATE_UNSPECIFIED",
+		1: "DEAD_LETTER_TASK_STATE_ARCHIVED",
+		2: "DEAD_LETTER_TASK_STATE_RETRY",
+	}
+	DeadLetterTaskState_value = map[string]int32{
+		"DEAD_LETTER_TASK_STATE_UNSPECIFIED": 0,
+		"DEAD_LETTER_TASK_STATE_ARCHIVED":    1,
+		"DEAD_LETTER_TASK_STATE_RETRY":       2,
+	}
+)
+
+func (x DeadLetterTaskState) Enum() *DeadLetterTaskState {
+	p := new(DeadLetterTaskState)
+	*p = x
+	return p
+}
+
+func (x DeadLetterTaskState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeadLetterTaskState) Descriptor() protoreflect.EnumDescriptor {
+	return file_dead_letter_task_proto_enumTypes[0].Descriptor()
+}
+
+func (DeadLetterTaskState) Type() protoreflect.EnumType {
+	return &file_dead_letter_task_proto_enumTypes[0]
+}
+
+func (x DeadLetterTaskState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeadLetterTaskState.Descriptor instead.
+func (DeadLetterTaskState) EnumDescriptor() ([]byte, []int) {
+	return file_dead_letter_task_proto_rawDescGZIP(), []int{0}
+}
+
+type DeadLetterTask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Queue string `protobuf:"bytes,2,opt,name=queue,proto3" json:"queue,omitempty"`
+	Type  string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// JSON encoded task payload
+	Payload      string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	State        DeadLetterTaskState    `protobuf:"varint,5,opt,name=state,proto3,enum=pb.DeadLetterTaskState" json:"state,omitempty"`
+	MaxRetry     int32                  `protobuf:"varint,6,opt,name=max_retry,json=maxRetry,proto3" json:"max_retry,omitempty"`
+	Retried      int32                  `protobuf:"varint,7,opt,name=retried,proto3" json:"retried,omitempty"`
+	LastError    string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastFailedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_failed_at,json=lastFailedAt,proto3" json:"last_failed_at,omitempty"`
+	// when a task waiting to be retried is processed next
+	NextProcessAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=next_process_at,json=nextProcessAt,proto3" json:"next_process_at,omitempty"`
+}
+
+func (x *DeadLetterTask) Reset() {
+	*x = DeadLetterTask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dead_letter_task_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeadLetterTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterTask) ProtoMessage() {}
+
+func (x *DeadLetterTask) ProtoReflect() protoreflect.Message {
+	mi := &file_dead_letter_task_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterTask.ProtoReflect.Descriptor instead.
+func (*DeadLetterTask) Descriptor() ([]byte, []int) {
+	return file_dead_letter_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeadLetterTask) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetterTask) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *DeadLetterTask) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DeadLetterTask) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *DeadLetterTask) GetState() DeadLetterTaskState {
+	if x != nil {
+		return x.State
+	}
+	return DeadLetterTaskState_DEAD_LETTER_TASK_STATE_UNSPECIFIED
+}
+
+func (x *DeadLetterTask) GetMaxRetry() int32 {
+	if x != nil {
+		return x.MaxRetry
+	}
+	return 0
+}
+
+func (x *DeadLetterTask) GetRetried() int32 {
+	if x != nil {
+		return x.Retried
+	}
+	return 0
+}
+
+func (x *DeadLetterTask) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetterTask) GetLastFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFailedAt
+	}
+	return nil
+}
+
+func (x *DeadLetterTask) GetNextProcessAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextProcessAt
+	}
+	return nil
+}
+
+var File_dead_letter_task_proto protoreflect.FileDescriptor
+
+var file_dead_letter_task_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x61,
+	0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xef, 0x02,
+	0x0a, 0x0e, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74,
+	0x74, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x40, 0x0a, 0x0e, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c,
+	0x61, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x41, 0x74, 0x12, 0x42, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x41, 0x74, 0x2a,
+	0x84, 0x01, 0x0a, 0x13, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x54, 0x61,
+	0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x22, 0x44, 0x45, 0x41, 0x44, 0x5f,
+	0x4c, 0x45, 0x54, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x23, 0x0a, 0x1f, 0x44, 0x45, 0x41, 0x44, 0x5f, 0x4c, 0x45, 0x54, 0x54, 0x45, 0x52, 0x5f, 0x54,
+	0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x44, 0x45, 0x41, 0x44, 0x5f, 0x4c, 0x45, 0x54,
+	0x54, 0x45, 0x52, 0x5f, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52,
+	0x45, 0x54, 0x52, 0x59, 0x10, 0x02, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x79, 0x61, 0x6d, 0x61, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x75,
+	0x73, 0x65, 0x72, 0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_dead_letter_task_proto_rawDescOnce sync.Once
+	file_dead_letter_task_proto_rawDescData = file_dead_letter_task_proto_rawDesc
+)
+
+func file_dead_letter_task_proto_rawDescGZIP() []byte {
+	file_dead_letter_task_proto_rawDescOnce.Do(func() {
+		file_dead_letter_task_proto_rawDescData = protoimpl.X.CompressGZIP(file_dead_letter_task_proto_rawDescData)
+	})
+	return file_dead_letter_task_proto_rawDescData
+}
+
+var file_dead_letter_task_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_dead_letter_task_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_dead_letter_task_proto_goTypes = []interface{}{
+	(DeadLetterTaskState)(0),      // 0: pb.DeadLetterTaskState
+	(*DeadLetterTask)(nil),        // 1: pb.DeadLetterTask
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_dead_letter_task_proto_depIdxs = []int32{
+	0, // 0: pb.DeadLetterTask.state:type_name -> pb.DeadLetterTaskState
+	2, // 1: pb.DeadLetterTask.last_failed_at:type_name -> google.protobuf.Timestamp
+	2, // 2: pb.DeadLetterTask.next_process_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_dead_letter_task_proto_init() }
+func file_dead_letter_task_proto_init() {
+	if File_dead_letter_task_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_dead_letter_task_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLetterTask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_dead_letter_task_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_dead_letter_task_proto_goTypes,
+		DependencyIndexes: file_dead_letter_task_proto_depIdxs,
+		EnumInfos:         file_dead_letter_task_proto_enumTypes,
+		MessageInfos:      file_dead_letter_task_proto_msgTypes,
+	}.Build()
+	File_dead_letter_task_proto = out.File
+	file_dead_letter_task_proto_rawDesc = nil
+	file_dead_letter_task_proto_goTypes = nil
+	file_dead_letter_task_proto_depIdxs = nil
+}
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: rpc_list_dead_letter_tasks.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListDeadLetterTasksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only list tasks of this type when set
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// defaults to archived tasks
+	State    DeadLetterTaskState `protobuf:"varint,2,opt,name=state,proto3,enum=pb.DeadLetterTaskState" json:"state,omitempty"`
+	Page     int32               `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32               `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// the queue to list tasks from, which is required because tasks are paged through one queue at a time
+	Queue string `protobuf:"bytes,5,opt,name=queue,proto3" json:"queue,omitempty"`
+}
+
+func (x *ListDeadLetterTasksRequest) Reset() {
+	*x = ListDeadLetterTasksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_dead_letter_tasks_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeadLetterTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLetterTasksRequest) ProtoMessage() {}
+
+func (x *ListDeadLetterTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_dead_letter_tasks_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLetterTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLetterTasksRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_dead_letter_tasks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListDeadLetterTasksRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListDeadLetterTasksRequest) GetState() DeadLetterTaskState {
+	if x != nil {
+		return x.State
+	}
+	return DeadLetterTaskState_DEAD_LETTER_TASK_STATE_UNSPECIFIED
+}
+
+func (x *ListDeadLetterTasksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeadLetterTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeadLetterTasksRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+type ListDeadLetterTasksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page       int32             `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize   int32             `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalTasks int32             `protobuf:"varint,3,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	Tasks      []*DeadLetterTask `protobuf:"bytes,4,rep,name=tasks,proto3" json:"tasks,omitempty"`
+}
+
+func (x *ListDeadLetterTasksResponse) Reset() {
+	*x = ListDeadLetterTasksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_dead_letter_tasks_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeadLetterTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLetterTasksResponse) ProtoMessage() {}
+
+func (x *ListDeadLetterTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_dead_letter_tasks_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLetterTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLetterTasksResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_dead_letter_tasks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListDeadLetterTasksResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeadLetterTasksResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeadLetterTasksResponse) GetTotalTasks() int32 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *ListDeadLetterTasksResponse) GetTasks() []*DeadLetterTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+var File_rpc_list_dead_letter_tasks_proto protoreflect.FileDescriptor
+
+var file_rpc_list_dead_letter_tasks_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x5f,
+	0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x16, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x65, 0x74,
+	0x74, 0x65, 0x72, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa6,
+	0x01, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65,
+	0x72, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72,
+	0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x74, 0x61, 0x73,
+	0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65,
+	0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6b, 0x79, 0x61, 0x6d, 0x61, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_dead_letter_tasks_proto_rawDescOnce sync.Once
+	file_rpc_list_dead_letter_tasks_proto_rawDescData = file_rpc_list_dead_letter_tasks_proto_rawDesc
+)
+
+func file_rpc_list_dead_letter_tasks_proto_rawDescGZIP() []byte {
+	file_rpc_list_dead_letter_tasks_proto_rawDescOnce.Do(func() {
+		file_rpc_list_dead_letter_tasks_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_dead_letter_tasks_proto_rawDescData)
+	})
+	return file_rpc_list_dead_letter_tasks_proto_rawDescData
+}
+
+var file_rpc_list_dead_letter_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_dead_letter_tasks_proto_goTypes = []interface{}{
+	(*ListDeadLetterTasksRequest)(nil),  // 0: pb.ListDeadLetterTasksRequest
+	(*ListDeadLetterTasksResponse)(nil), // 1: pb.ListDeadLetterTasksResponse
+	(DeadLetterTaskState)(0),            // 2: pb.DeadLetterTaskState
+	(*DeadLetterTask)(nil),              // 3: pb.DeadLetterTask
+}
+var file_rpc_list_dead_letter_tasks_proto_depIdxs = []int32{
+	2, // 0: pb.ListDeadLetterTasksRequest.state:type_name -> pb.DeadLetterTaskState
+	3, // 1: pb.ListDeadLetterTasksResponse.tasks:type_name -> pb.DeadLetterTask
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_dead_letter_tasks_proto_init() }
+func file_rpc_list_dead_letter_tasks_proto_init() {
+	if File_rpc_list_dead_letter_tasks_proto != nil {
+		return
+	}
+	file_dead_letter_task_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_list_dead_letter_tasks_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeadLetterTasksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_dead_letter_tasks_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeadLetterTasksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_dead_letter_tasks_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_dead_letter_tasks_proto_goTypes,
+		DependencyIndexes: file_rpc_list_dead_letter_tasks_proto_depIdxs,
+		MessageInfos:      file_rpc_list_dead_letter_tasks_proto_msgTypes,
+	}.Build()
+	File_rpc_list_dead_letter_tasks_proto = out.File
+	file_rpc_list_dead_letter_tasks_proto_rawDesc = nil
+	file_rpc_list_dead_letter_tasks_proto_goTypes = nil
+	file_rpc_list_dead_letter_tasks_proto_depIdxs = nil
+}
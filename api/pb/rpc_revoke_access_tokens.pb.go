@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: rpc_revoke_access_tokens.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RevokeAccessTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WalletAddress string `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+}
+
+func (x *RevokeAccessTokensRequest) Reset() {
+	*x = RevokeAccessTokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_revoke_access_tokens_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeAccessTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokensRequest) ProtoMessage() {}
+
+func (x *RevokeAccessTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_revoke_access_tokens_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokensRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokensRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_revoke_access_tokens_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RevokeAccessTokensRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+var File_rpc_revoke_access_tokens_proto protoreflect.FileDescriptor
+
+var file_rpc_revoke_access_tokens_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x5f, 0x61, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x22, 0x42, 0x0a, 0x19, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x77, 0x61, 0x6c, 0x6c, 0x65,
+	0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x79, 0x61, 0x6d, 0x61, 0x6c, 0x61, 0x62, 0x73,
+	0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_rpc_revoke_access_tokens_proto_rawDescOnce sync.Once
+	file_rpc_revoke_access_tokens_proto_rawDescData = file_rpc_revoke_access_tokens_proto_rawDesc
+)
+
+func file_rpc_revoke_access_tokens_proto_rawDescGZIP() []byte {
+	file_rpc_revoke_access_tokens_proto_rawDescOnce.Do(func() {
+		file_rpc_revoke_access_tokens_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_revoke_access_tokens_proto_rawDescData)
+	})
+	return file_rpc_revoke_access_tokens_proto_rawDescData
+}
+
+var file_rpc_revoke_access_tokens_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rpc_revoke_access_tokens_proto_goTypes = []interface{}{
+	(*RevokeAccessTokensRequest)(nil), // 0: pb.RevokeAccessTokensRequest
+}
+var file_rpc_revoke_access_tokens_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_revoke_access_tokens_proto_init() }
+func file_rpc_revoke_access_tokens_proto_init() {
+	if File_rpc_revoke_access_tokens_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_revoke_access_tokens_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeAccessTokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_revoke_access_tokens_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_revoke_access_tokens_proto_goTypes,
+		DependencyIndexes: file_rpc_revoke_access_tokens_proto_depIdxs,
+		MessageInfos:      file_rpc_revoke_access_tokens_proto_msgTypes,
+	}.Build()
+	File_rpc_revoke_access_tokens_proto = out.File
+	file_rpc_revoke_access_tokens_proto_rawDesc = nil
+	file_rpc_revoke_access_tokens_proto_goTypes = nil
+	file_rpc_revoke_access_tokens_proto_depIdxs = nil
+}
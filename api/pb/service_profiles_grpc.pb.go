@@ -26,6 +26,8 @@ const (
 	Profiles_ListAllProfiles_FullMethodName  = "/pb.Profiles/ListAllProfiles"
 	Profiles_UpdateProfile_FullMethodName    = "/pb.Profiles/UpdateProfile"
 	Profiles_DeleteProfile_FullMethodName    = "/pb.Profiles/DeleteProfile"
+	Profiles_RefreshEnsName_FullMethodName   = "/pb.Profiles/RefreshEnsName"
+	Profiles_WatchProfiles_FullMethodName    = "/pb.Profiles/WatchProfiles"
 )
 
 // ProfilesClient is the client API for Profiles service.
@@ -38,6 +40,9 @@ type ProfilesClient interface {
 	ListAllProfiles(ctx context.Context, in *ListAllProfilesRequest, opts ...grpc.CallOption) (*ListAllProfilesResponse, error)
 	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileResponse, error)
 	DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RefreshEnsName(ctx context.Context, in *RefreshEnsNameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// streams changes to the given profiles as they happen. Only available over gRPC.
+	WatchProfiles(ctx context.Context, in *WatchProfilesRequest, opts ...grpc.CallOption) (Profiles_WatchProfilesClient, error)
 }
 
 type profilesClient struct {
@@ -102,6 +107,47 @@ func (c *profilesClient) DeleteProfile(ctx context.Context, in *DeleteProfileReq
 	return out, nil
 }
 
+func (c *profilesClient) RefreshEnsName(ctx context.Context, in *RefreshEnsNameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Profiles_RefreshEnsName_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profilesClient) WatchProfiles(ctx context.Context, in *WatchProfilesRequest, opts ...grpc.CallOption) (Profiles_WatchProfilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Profiles_ServiceDesc.Streams[0], Profiles_WatchProfiles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &profilesWatchProfilesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Profiles_WatchProfilesClient interface {
+	Recv() (*WatchProfilesResponse, error)
+	grpc.ClientStream
+}
+
+type profilesWatchProfilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *profilesWatchProfilesClient) Recv() (*WatchProfilesResponse, error) {
+	m := new(WatchProfilesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ProfilesServer is the server API for Profiles service.
 // All implementations must embed UnimplementedProfilesServer
 // for forward compatibility
@@ -112,6 +158,9 @@ type ProfilesServer interface {
 	ListAllProfiles(context.Context, *ListAllProfilesRequest) (*ListAllProfilesResponse, error)
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileResponse, error)
 	DeleteProfile(context.Context, *DeleteProfileRequest) (*emptypb.Empty, error)
+	RefreshEnsName(context.Context, *RefreshEnsNameRequest) (*emptypb.Empty, error)
+	// streams changes to the given profiles as they happen. Only available over gRPC.
+	WatchProfiles(*WatchProfilesRequest, Profiles_WatchProfilesServer) error
 	mustEmbedUnimplementedProfilesServer()
 }
 
@@ -137,6 +186,12 @@ func (UnimplementedProfilesServer) UpdateProfile(context.Context, *UpdateProfile
 func (UnimplementedProfilesServer) DeleteProfile(context.Context, *DeleteProfileRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteProfile not implemented")
 }
+func (UnimplementedProfilesServer) RefreshEnsName(context.Context, *RefreshEnsNameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshEnsName not implemented")
+}
+func (UnimplementedProfilesServer) WatchProfiles(*WatchProfilesRequest, Profiles_WatchProfilesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProfiles not implemented")
+}
 func (UnimplementedProfilesServer) mustEmbedUnimplementedProfilesServer() {}
 
 // UnsafeProfilesServer may be embedded to opt out of forward compatibility for this service.
@@ -258,6 +313,45 @@ func _Profiles_DeleteProfile_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Profiles_RefreshEnsName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshEnsNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfilesServer).RefreshEnsName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Profiles_RefreshEnsName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfilesServer).RefreshEnsName(ctx, req.(*RefreshEnsNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profiles_WatchProfiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProfilesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfilesServer).WatchProfiles(m, &profilesWatchProfilesServer{stream})
+}
+
+type Profiles_WatchProfilesServer interface {
+	Send(*WatchProfilesResponse) error
+	grpc.ServerStream
+}
+
+type profilesWatchProfilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *profilesWatchProfilesServer) Send(m *WatchProfilesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Profiles_ServiceDesc is the grpc.ServiceDesc for Profiles service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,7 +383,17 @@ var Profiles_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteProfile",
 			Handler:    _Profiles_DeleteProfile_Handler,
 		},
+		{
+			MethodName: "RefreshEnsName",
+			Handler:    _Profiles_RefreshEnsName_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchProfiles",
+			Handler:       _Profiles_WatchProfiles_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service_profiles.proto",
 }
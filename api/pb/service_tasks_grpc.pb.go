@@ -0,0 +1,260 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: service_tasks.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Tasks_ListDeadLetterTasks_FullMethodName  = "/pb.Tasks/ListDeadLetterTasks"
+	Tasks_GetDeadLetterTask_FullMethodName    = "/pb.Tasks/GetDeadLetterTask"
+	Tasks_ReplayDeadLetterTask_FullMethodName = "/pb.Tasks/ReplayDeadLetterTask"
+	Tasks_DeleteDeadLetterTask_FullMethodName = "/pb.Tasks/DeleteDeadLetterTask"
+	Tasks_RevokeAccessTokens_FullMethodName   = "/pb.Tasks/RevokeAccessTokens"
+)
+
+// TasksClient is the client API for Tasks service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TasksClient interface {
+	ListDeadLetterTasks(ctx context.Context, in *ListDeadLetterTasksRequest, opts ...grpc.CallOption) (*ListDeadLetterTasksResponse, error)
+	GetDeadLetterTask(ctx context.Context, in *GetDeadLetterTaskRequest, opts ...grpc.CallOption) (*GetDeadLetterTaskResponse, error)
+	ReplayDeadLetterTask(ctx context.Context, in *ReplayDeadLetterTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteDeadLetterTask(ctx context.Context, in *DeleteDeadLetterTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// stops access tokens issued to the wallet address so far from being accepted. Admin only.
+	RevokeAccessTokens(ctx context.Context, in *RevokeAccessTokensRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type tasksClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTasksClient(cc grpc.ClientConnInterface) TasksClient {
+	return &tasksClient{cc}
+}
+
+func (c *tasksClient) ListDeadLetterTasks(ctx context.Context, in *ListDeadLetterTasksRequest, opts ...grpc.CallOption) (*ListDeadLetterTasksResponse, error) {
+	out := new(ListDeadLetterTasksResponse)
+	err := c.cc.Invoke(ctx, Tasks_ListDeadLetterTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) GetDeadLetterTask(ctx context.Context, in *GetDeadLetterTaskRequest, opts ...grpc.CallOption) (*GetDeadLetterTaskResponse, error) {
+	out := new(GetDeadLetterTaskResponse)
+	err := c.cc.Invoke(ctx, Tasks_GetDeadLetterTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) ReplayDeadLetterTask(ctx context.Context, in *ReplayDeadLetterTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Tasks_ReplayDeadLetterTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) DeleteDeadLetterTask(ctx context.Context, in *DeleteDeadLetterTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Tasks_DeleteDeadLetterTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) RevokeAccessTokens(ctx context.Context, in *RevokeAccessTokensRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Tasks_RevokeAccessTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TasksServer is the server API for Tasks service.
+// All implementations must embed UnimplementedTasksServer
+// for forward compatibility
+type TasksServer interface {
+	ListDeadLetterTasks(context.Context, *ListDeadLetterTasksRequest) (*ListDeadLetterTasksResponse, error)
+	GetDeadLetterTask(context.Context, *GetDeadLetterTaskRequest) (*GetDeadLetterTaskResponse, error)
+	ReplayDeadLetterTask(context.Context, *ReplayDeadLetterTaskRequest) (*emptypb.Empty, error)
+	DeleteDeadLetterTask(context.Context, *DeleteDeadLetterTaskRequest) (*emptypb.Empty, error)
+	// stops access tokens issued to the wallet address so far from being accepted. Admin only.
+	RevokeAccessTokens(context.Context, *RevokeAccessTokensRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedTasksServer()
+}
+
+// UnimplementedTasksServer must be embedded to have forward compatible implementations.
+type UnimplementedTasksServer struct {
+}
+
+func (UnimplementedTasksServer) ListDeadLetterTasks(context.Context, *ListDeadLetterTasksRequest) (*ListDeadLetterTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetterTasks not implemented")
+}
+func (UnimplementedTasksServer) GetDeadLetterTask(context.Context, *GetDeadLetterTaskRequest) (*GetDeadLetterTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetterTask not implemented")
+}
+func (UnimplementedTasksServer) ReplayDeadLetterTask(context.Context, *ReplayDeadLetterTaskRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetterTask not implemented")
+}
+func (UnimplementedTasksServer) DeleteDeadLetterTask(context.Context, *DeleteDeadLetterTaskRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeadLetterTask not implemented")
+}
+func (UnimplementedTasksServer) RevokeAccessTokens(context.Context, *RevokeAccessTokensRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAccessTokens not implemented")
+}
+func (UnimplementedTasksServer) mustEmbedUnimplementedTasksServer() {}
+
+// UnsafeTasksServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TasksServer will
+// result in compilation errors.
+type UnsafeTasksServer interface {
+	mustEmbedUnimplementedTasksServer()
+}
+
+func RegisterTasksServer(s grpc.ServiceRegistrar, srv TasksServer) {
+	s.RegisterService(&Tasks_ServiceDesc, srv)
+}
+
+func _Tasks_ListDeadLetterTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLetterTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).ListDeadLetterTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_ListDeadLetterTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).ListDeadLetterTasks(ctx, req.(*ListDeadLetterTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_GetDeadLetterTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeadLetterTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).GetDeadLetterTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_GetDeadLetterTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).GetDeadLetterTask(ctx, req.(*GetDeadLetterTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_ReplayDeadLetterTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLetterTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).ReplayDeadLetterTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_ReplayDeadLetterTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).ReplayDeadLetterTask(ctx, req.(*ReplayDeadLetterTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_DeleteDeadLetterTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeadLetterTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).DeleteDeadLetterTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_DeleteDeadLetterTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).DeleteDeadLetterTask(ctx, req.(*DeleteDeadLetterTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_RevokeAccessTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAccessTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).RevokeAccessTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tasks_RevokeAccessTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).RevokeAccessTokens(ctx, req.(*RevokeAccessTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Tasks_ServiceDesc is the grpc.ServiceDesc for Tasks service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tasks_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Tasks",
+	HandlerType: (*TasksServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeadLetterTasks",
+			Handler:    _Tasks_ListDeadLetterTasks_Handler,
+		},
+		{
+			MethodName: "GetDeadLetterTask",
+			Handler:    _Tasks_GetDeadLetterTask_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetterTask",
+			Handler:    _Tasks_ReplayDeadLetterTask_Handler,
+		},
+		{
+			MethodName: "DeleteDeadLetterTask",
+			Handler:    _Tasks_DeleteDeadLetterTask_Handler,
+		},
+		{
+			MethodName: "RevokeAccessTokens",
+			Handler:    _Tasks_RevokeAccessTokens_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service_tasks.proto",
+}
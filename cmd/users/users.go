@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/hibiken/asynq"
@@ -13,6 +19,13 @@ import (
 	authMiddleware "github.com/kyamalabs/auth/pkg/middleware"
 	"github.com/kyamalabs/users/internal/api/middleware"
 	"github.com/kyamalabs/users/internal/cache"
+	"github.com/kyamalabs/users/internal/event"
+	"github.com/kyamalabs/users/internal/health"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/kyamalabs/users/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/kyamalabs/users/internal/constants"
 
@@ -21,6 +34,8 @@ import (
 	"github.com/kyamalabs/users/internal/api/server"
 	"github.com/rakyll/statik/fs"
 	"google.golang.org/grpc"
+	grpcHealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 
@@ -29,13 +44,25 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgxpool"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/services"
 	"github.com/kyamalabs/users/internal/util"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
 
 	_ "github.com/kyamalabs/users/docs/statik"
 )
 
+const (
+	defaultShutdownTimeout     = 30 * time.Second
+	defaultHealthCheckInterval = 10 * time.Second
+)
+
+var interruptSignals = []os.Signal{
+	os.Interrupt,
+	syscall.SIGTERM,
+}
+
 func main() {
 	config, err := util.LoadConfig(".")
 	if err != nil {
@@ -44,29 +71,143 @@ func main() {
 
 	setupLogger(config)
 
-	runDBMigration(config.DBMigrationURL, config.DBSource)
+	shutdownTracing, err := tracing.Setup(context.Background(), config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot set up tracing")
+	}
+
+	migration := runDBMigration(config.DBMigrationURL, config.DBSource)
+
+	poolConfig, err := pgxpool.ParseConfig(config.DBSource)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot parse db source")
+	}
+	poolConfig.ConnConfig.Tracer = tracing.NewPgxTracer()
 
-	connPool, err := pgxpool.New(context.Background(), config.DBSource)
+	connPool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot connect to db")
 	}
 	store := db.NewStore(connPool)
 
+	prometheus.MustRegister(metrics.NewPgxPoolCollector(connPool))
+
 	redisOpt, err := asynq.ParseRedisURI(config.RedisConnURL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("could not parse redis connection URL")
 	}
 
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
+	inspector := worker.NewRedisDeadLetterInspector(redisOpt)
+
+	authService, err := services.NewAuthServiceGrpcClient(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create auth service gRPC client")
+	}
+
+	appCache, err := cache.NewCache(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create cache")
+	}
+
+	streamEventPublisher, err := event.NewRedisStreamEventPublisher(config.RedisConnURL, config.EventStream, config.EventStreamMaxLen)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create event publisher")
+	}
+
+	eventPublisher := event.NewMultiEventPublisher(
+		streamEventPublisher,
+		worker.NewWebhookEventPublisher(config, store, taskDistributor),
+	)
+
+	profileWatcher, err := event.NewRedisProfileWatcher(config.RedisConnURL, config.ProfileWatchersMax)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create profile watcher")
+	}
+
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = defaultShutdownTimeout
+	}
+
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = defaultHealthCheckInterval
+	}
+
+	healthChecker := health.NewChecker(config.HealthCheckTimeout)
+	healthChecker.AddCheck("postgres", connPool.Ping)
+	healthChecker.AddCheck("migrations", checkMigrations(migration))
+	if pinger, ok := appCache.(health.Pinger); ok {
+		healthChecker.AddCheck("cache", pinger.Ping)
+	}
+	if pinger, ok := authService.(health.Pinger); ok {
+		healthChecker.AddCheck("auth_service", pinger.Ping)
+	}
+
+	// the gRPC and HTTP gateway servers share the handlers
+	s, err := server.NewServer(config, appCache, store, taskDistributor, profileWatcher, inspector, authService)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create server")
+	}
+	healthChecker.AddCheck("rate_limiter_store", s.RateLimiter.Ping)
+
+	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
+	defer stop()
+
+	waitGroup, ctx := errgroup.WithContext(ctx)
+
+	runTaskProcessor(ctx, waitGroup, config, redisOpt, appCache, store, taskDistributor, profileWatcher)
+	runTaskScheduler(ctx, waitGroup, config, redisOpt)
+	runOutboxRelay(ctx, waitGroup, config, store, taskDistributor, eventPublisher)
+	serversCtx := drainServers(ctx, waitGroup, config, healthChecker)
+	runMetricsServer(serversCtx, waitGroup, config)
+	runGatewayServer(ctx, serversCtx, waitGroup, config, healthChecker, s, appCache)
+	runGrpcServer(ctx, serversCtx, waitGroup, config, healthChecker, s, appCache)
+
+	err = waitGroup.Wait()
+
+	// everything that could still use these connections has stopped by now
+	closeResource("task distributor", taskDistributor)
+	closeResource("dead letter inspector", inspector)
+	closeResource("auth service client", authService)
+	closeResource("event publisher", streamEventPublisher)
+	closeResource("profile watcher", profileWatcher)
+	closeResource("cache", appCache)
+	closeResource("rate limiter store", s.RateLimiter)
+	sourceErr, databaseErr := migration.Close()
+	if err := errors.Join(sourceErr, databaseErr); err != nil {
+		log.Error().Err(err).Msg("could not close migration instance")
+	}
+	connPool.Close()
+	shutdownTracingProvider(shutdownTracing, config.ShutdownTimeout)
 
-	redisCache, err := cache.NewRedisCache(config.RedisConnURL)
 	if err != nil {
-		log.Fatal().Err(err).Msg("cannot create redis cache")
+		log.Fatal().Err(err).Msg("error from wait group")
 	}
 
-	go runTaskProcessor(config, redisOpt, redisCache)
-	go runGatewayServer(config, store, redisCache, taskDistributor)
-	runGrpcServer(config, store, redisCache, taskDistributor)
+	log.Info().Msg("shut down gracefully")
+}
+
+// shutdownTracingProvider exports the spans of the requests and tasks that finished last.
+func shutdownTracingProvider(shutdown tracing.ShutdownFunc, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("could not shut down tracing")
+	}
+}
+
+// closeResource closes dependencies backed by a connection. Backends that hold no connection,
+// such as the in-memory cache, are skipped.
+func closeResource(name string, resource any) {
+	closer, ok := resource.(io.Closer)
+	if !ok {
+		return
+	}
+
+	if err := closer.Close(); err != nil {
+		log.Error().Err(err).Msgf("could not close %s", name)
+	}
 }
 
 func setupLogger(config util.Config) {
@@ -78,10 +219,13 @@ func setupLogger(config util.Config) {
 
 	logger = logger.With().Str("service", constants.ServiceName).Logger()
 	log.Logger = logger
+
+	// log.Ctx falls back to the global logger outside of requests and tasks
+	zerolog.DefaultContextLogger = &log.Logger
 }
 
-func runTaskProcessor(config util.Config, redisOpt asynq.RedisConnOpt, redisCache cache.Cache) {
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, config, redisCache)
+func runTaskProcessor(ctx context.Context, waitGroup *errgroup.Group, config util.Config, redisOpt asynq.RedisConnOpt, cache cache.Cache, store db.Store, taskDistributor worker.TaskDistributor, profileWatcher event.ProfileWatcher) {
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, config, cache, store, taskDistributor, profileWatcher)
 
 	err := taskProcessor.Start()
 	if err != nil {
@@ -89,9 +233,78 @@ func runTaskProcessor(config util.Config, redisOpt asynq.RedisConnOpt, redisCach
 	}
 
 	log.Info().Msg("started task processor")
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("shutting down task processor")
+
+		taskProcessor.Shutdown()
+		log.Info().Msg("task processor stopped")
+
+		return nil
+	})
 }
 
-func runDBMigration(migrationURL string, dbSource string) {
+func runTaskScheduler(ctx context.Context, waitGroup *errgroup.Group, config util.Config, redisOpt asynq.RedisConnOpt) {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt, config)
+
+	err := taskScheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+
+	log.Info().Msg("started task scheduler")
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("shutting down task scheduler")
+
+		taskScheduler.Shutdown()
+		log.Info().Msg("task scheduler stopped")
+
+		return nil
+	})
+}
+
+func runOutboxRelay(ctx context.Context, waitGroup *errgroup.Group, config util.Config, store db.Store, taskDistributor worker.TaskDistributor, eventPublisher event.EventPublisher) {
+	outboxRelay := worker.NewOutboxRelay(config, store, taskDistributor)
+	outboxRelay.RegisterSink(event.OutboxSinkEvents, event.NewOutboxSink(eventPublisher))
+	outboxRelay.Start()
+
+	log.Info().Msg("started outbox relay")
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("shutting down outbox relay")
+
+		outboxRelay.Shutdown()
+		log.Info().Msg("outbox relay stopped")
+
+		return nil
+	})
+}
+
+// drainServers fails readiness as soon as shutdown starts and returns a context that is done once
+// the drain delay has passed, giving load balancers time to stop routing requests to this
+// instance before the servers stop accepting them.
+func drainServers(ctx context.Context, waitGroup *errgroup.Group, config util.Config, healthChecker *health.Checker) context.Context {
+	serversCtx, stopServers := context.WithCancel(context.Background())
+
+	waitGroup.Go(func() error {
+		defer stopServers()
+
+		<-ctx.Done()
+		healthChecker.Drain()
+
+		time.Sleep(config.ShutdownDrainDelay)
+
+		return nil
+	})
+
+	return serversCtx
+}
+
+func runDBMigration(migrationURL string, dbSource string) *migrate.Migrate {
 	migration, err := migrate.New(migrationURL, dbSource)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create new migration instance")
@@ -103,47 +316,115 @@ func runDBMigration(migrationURL string, dbSource string) {
 	}
 
 	log.Info().Msg("db migrated successfully")
+
+	return migration
 }
 
-func runGrpcServer(config util.Config, store db.Store, cache cache.Cache, taskDistributor worker.TaskDistributor) {
-	s, err := server.NewServer(config, cache, store, taskDistributor)
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot create server")
+// checkMigrations fails readiness when the schema was never migrated or a migration failed
+// halfway, which leaves the database dirty until it is fixed by hand.
+func checkMigrations(migration *migrate.Migrate) health.CheckFunc {
+	return func(_ context.Context) error {
+		version, dirty, err := migration.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			return errors.New("no migrations have been applied")
+		}
+		if err != nil {
+			return fmt.Errorf("could not get migration version: %w", err)
+		}
+
+		if dirty {
+			return fmt.Errorf("database is dirty at migration version %d", version)
+		}
+
+		return nil
+	}
+}
+
+func runGrpcServer(ctx context.Context, serversCtx context.Context, waitGroup *errgroup.Group, config util.Config, healthChecker *health.Checker, s *server.Server, cache cache.Cache) {
+	authenticateServiceConfig := &authMiddleware.AuthenticateServiceConfig{
+		Cache:                 cache,
+		ServiceAuthPublicKeys: config.ServiceAuthPublicKeys,
 	}
 
 	grpcInterceptor := grpc.ChainUnaryInterceptor(
+		middleware.GrpcMetrics,
 		middleware.GrpcExtractMetadata,
-		(&authMiddleware.AuthenticateServiceConfig{
-			Cache:                 cache,
-			ServiceAuthPublicKeys: config.ServiceAuthPublicKeys,
-		}).AuthenticateServiceGrpc,
-		middleware.GrpcRateLimiter,
-		middleware.GrpcLogger,
+		middleware.GrpcSkipHealthCheck(authenticateServiceConfig.AuthenticateServiceGrpc),
+		middleware.GrpcSkipHealthCheck(s.RateLimiter.GrpcRateLimiter),
+		middleware.GrpcSkipHealthCheck(middleware.GrpcLogger),
 	)
 
-	grpcServer := grpc.NewServer(grpcInterceptor)
+	grpcStreamInterceptor := grpc.ChainStreamInterceptor(
+		middleware.GrpcStreamMetrics,
+		middleware.GrpcStreamExtractMetadata,
+		middleware.GrpcStreamSkipHealthCheck(middleware.GrpcStreamAuthenticateService(authenticateServiceConfig)),
+		middleware.GrpcStreamSkipHealthCheck(s.RateLimiter.GrpcStreamRateLimiter),
+		middleware.GrpcStreamSkipHealthCheck(middleware.GrpcStreamLogger),
+	)
+
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpcInterceptor,
+		grpcStreamInterceptor,
+	)
 	pb.RegisterProfilesServer(grpcServer, &s.ProfileHandler)
 	pb.RegisterReferralsServer(grpcServer, &s.ReferralHandler)
+	pb.RegisterWebhooksServer(grpcServer, &s.WebhookHandler)
+	pb.RegisterTasksServer(grpcServer, &s.TaskHandler)
 	reflection.Register(grpcServer)
 
+	healthServer := grpcHealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
 	listener, err := net.Listen("tcp", config.GRPCServerAddress)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create grpc server listener")
 	}
 
-	log.Info().Msgf("started gRPC server at %s", listener.Addr().String())
-	err = grpcServer.Serve(listener)
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot start gRPC server")
-	}
-}
+	waitGroup.Go(func() error {
+		log.Info().Msgf("started gRPC server at %s", listener.Addr().String())
 
-func runGatewayServer(config util.Config, store db.Store, cache cache.Cache, taskDistributor worker.TaskDistributor) {
-	s, err := server.NewServer(config, cache, store, taskDistributor)
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot create server")
-	}
+		err := grpcServer.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Error().Err(err).Msg("gRPC server failed to serve")
+			return err
+		}
+
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		healthChecker.WatchGrpc(ctx, healthServer, config.HealthCheckInterval)
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-serversCtx.Done()
+		log.Info().Msg("shutting down gRPC server")
+
+		s.StopStreams()
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		// the remaining RPCs are cancelled once the drain timeout passes
+		select {
+		case <-stopped:
+		case <-time.After(config.ShutdownTimeout):
+			log.Warn().Msg("gRPC server did not drain in time, cancelling remaining RPCs")
+			grpcServer.Stop()
+		}
 
+		log.Info().Msg("gRPC server stopped")
+
+		return nil
+	})
+}
+
+func runGatewayServer(ctx context.Context, serversCtx context.Context, waitGroup *errgroup.Group, config util.Config, healthChecker *health.Checker, s *server.Server, cache cache.Cache) {
 	opt := runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 		MarshalOptions: protojson.MarshalOptions{
 			EmitDefaultValues: true,
@@ -154,11 +435,13 @@ func runGatewayServer(config util.Config, store db.Store, cache cache.Cache, tas
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(opt)
-
-	ctx, cancel := context.WithCancel(context.Background())
+	grpcMux := runtime.NewServeMux(
+		opt,
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithMetadata(middleware.AnnotateHTTPRoute),
+	)
 
-	err = pb.RegisterProfilesHandlerServer(ctx, grpcMux, &s.ProfileHandler)
+	err := pb.RegisterProfilesHandlerServer(ctx, grpcMux, &s.ProfileHandler)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot register profiles handler server")
 	}
@@ -168,6 +451,16 @@ func runGatewayServer(config util.Config, store db.Store, cache cache.Cache, tas
 		log.Fatal().Err(err).Msg("cannot register referrals handler server")
 	}
 
+	err = pb.RegisterWebhooksHandlerServer(ctx, grpcMux, &s.WebhookHandler)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot register webhooks handler server")
+	}
+
+	err = pb.RegisterTasksHandlerServer(ctx, grpcMux, &s.TaskHandler)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot register tasks handler server")
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
 
@@ -180,15 +473,23 @@ func runGatewayServer(config util.Config, store db.Store, cache cache.Cache, tas
 	mux.Handle("/swagger/", swaggerHandler)
 
 	handler := middleware.HTTPLogger(mux)
-	handler = middleware.HTTPRateLimiter(handler)
+	handler = s.RateLimiter.HTTPRateLimiter(handler)
 	handler = authMiddleware.AuthenticateServiceHTTP(handler, &authMiddleware.AuthenticateServiceConfig{
 		Cache:                 cache,
 		ServiceAuthPublicKeys: config.ServiceAuthPublicKeys,
 	})
 	handler = middleware.HTTPExtractMetadata(handler)
+	handler = middleware.HTTPMetrics(handler)
+	handler = otelhttp.NewHandler(handler, "gateway")
+
+	// probes are served outside of the middlewares so the orchestrator needs no service credentials
+	rootMux := http.NewServeMux()
+	rootMux.Handle("/healthz", healthChecker.LivenessHandler())
+	rootMux.Handle("/readyz", healthChecker.ReadinessHandler())
+	rootMux.Handle("/", handler)
 
 	srv := &http.Server{
-		Handler:      handler,
+		Handler:      rootMux,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  120 * time.Second,
@@ -199,12 +500,94 @@ func runGatewayServer(config util.Config, store db.Store, cache cache.Cache, tas
 		log.Fatal().Err(err).Msg("cannot create http gateway server listener")
 	}
 
-	log.Info().Msgf("started HTTP gateway server at %s", listener.Addr().String())
+	waitGroup.Go(func() error {
+		log.Info().Msgf("started HTTP gateway server at %s", listener.Addr().String())
+
+		err := srv.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("HTTP gateway server failed to serve")
+			return err
+		}
+
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-serversCtx.Done()
+		log.Info().Msg("shutting down HTTP gateway server")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+
+		err := srv.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Warn().Err(err).Msg("HTTP gateway server did not drain in time, closing remaining connections")
+			_ = srv.Close()
+		}
+
+		log.Info().Msg("HTTP gateway server stopped")
+
+		return nil
+	})
+}
+
+// runMetricsServer serves /metrics on its own listener so it is never exposed next to the
+// public gateway. Metrics are not served when no address is configured.
+func runMetricsServer(serversCtx context.Context, waitGroup *errgroup.Group, config util.Config) {
+	if config.MetricsServerAddress == "" {
+		log.Info().Msg("metrics server address is not set, not serving metrics")
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	srv := &http.Server{
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
 
-	err = srv.Serve(listener)
+	listener, err := net.Listen("tcp", config.MetricsServerAddress)
 	if err != nil {
-		log.Fatal().Err(err).Msg("cannot start HTTP gateway server")
+		log.Fatal().Err(err).Msg("cannot create metrics server listener")
+	}
+
+	waitGroup.Go(func() error {
+		log.Info().Msgf("started metrics server at %s", listener.Addr().String())
+
+		err := srv.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("metrics server failed to serve")
+			return err
+		}
+
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-serversCtx.Done()
+		log.Info().Msg("shutting down metrics server")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			_ = srv.Close()
+		}
+
+		log.Info().Msg("metrics server stopped")
+
+		return nil
+	})
+}
+
+// gatewayHeaderMatcher forwards the wallet address header admins identify themselves with
+// alongside the headers the gateway forwards by default.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, constants.XWalletAddressHeader) {
+		return constants.XWalletAddressHeader, true
 	}
 
-	cancel()
+	return runtime.DefaultHeaderMatcher(key)
 }
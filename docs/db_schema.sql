@@ -5,7 +5,8 @@
 CREATE TABLE "profiles" (
   "wallet_address" varchar PRIMARY KEY,
   "gamer_tag" varchar UNIQUE NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
 );
 
 CREATE TABLE "referrals" (
@@ -15,4 +16,62 @@ CREATE TABLE "referrals" (
   "referred_at" timestamptz NOT NULL DEFAULT (now())
 );
 
+CREATE TABLE "ens_name_expiries" (
+  "wallet_address" varchar PRIMARY KEY,
+  "expires_at" timestamptz NOT NULL,
+  "resolved_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "outbox" (
+  "id" bigserial PRIMARY KEY,
+  "sink" varchar NOT NULL,
+  "topic" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "headers" jsonb NOT NULL DEFAULT '{}',
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar,
+  "available_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "published_at" timestamptz,
+  "failed_at" timestamptz
+);
+
+CREATE TABLE "webhooks" (
+  "id" UUID PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" UUID PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "webhook_id" UUID NOT NULL,
+  "event_id" varchar NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_status" int,
+  "last_error" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "delivered_at" timestamptz
+);
+
+CREATE INDEX ON "profiles" ("updated_at");
+
+CREATE INDEX ON "ens_name_expiries" ("expires_at");
+
+CREATE INDEX ON "outbox" ("available_at");
+
+CREATE INDEX ON "outbox" ("published_at");
+
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("webhook_id", "event_id");
+
+CREATE INDEX ON "webhook_deliveries" ("webhook_id", "created_at");
+
 ALTER TABLE "referrals" ADD FOREIGN KEY ("referrer") REFERENCES "profiles" ("wallet_address");
+
+ALTER TABLE "ens_name_expiries" ADD FOREIGN KEY ("wallet_address") REFERENCES "profiles" ("wallet_address") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("webhook_id") REFERENCES "webhooks" ("id") ON DELETE CASCADE;
@@ -2,9 +2,11 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/kyamalabs/users/internal/api/middleware"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -15,8 +17,24 @@ import (
 const (
 	InternalServerError     string = "An unexpected error occurred while processing your request."
 	UnauthorizedAccessError string = "Authorization failed. Please verify your credentials and try again."
+	AuthUnavailableError    string = "Authorization is temporarily unavailable. Please try again later."
 )
 
+// AuthorizationError is the status returned when a caller could not be authorized. Callers that
+// are authorized but have exhausted their rate limit are told to slow down instead, and callers
+// whose credentials could not be checked because the auth service is unavailable to retry later.
+func AuthorizationError(err error) error {
+	if errors.Is(err, middleware.ErrRateLimitExceeded) {
+		return status.Error(codes.ResourceExhausted, middleware.RateLimitExceededError)
+	}
+
+	if status.Code(err) == codes.Unavailable {
+		return status.Error(codes.Unavailable, AuthUnavailableError)
+	}
+
+	return status.Error(codes.Unauthenticated, UnauthorizedAccessError)
+}
+
 func FieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
 	return &errdetails.BadRequest_FieldViolation{
 		Field:       field,
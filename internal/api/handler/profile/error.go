@@ -1,7 +1,10 @@
 package profile
 
 const (
-	AlreadyExists        string = "User profile already exists"
-	DoesNotExist         string = "User profile does not exist"
-	GamerTagAlreadyInUse string = "Gamer tag already in use"
+	AlreadyExists             string = "User profile already exists"
+	DoesNotExist              string = "User profile does not exist"
+	GamerTagAlreadyInUse      string = "Gamer tag already in use"
+	EnsNameRefreshThrottled   string = "ENS name refresh requested too recently"
+	ProfileChangesUnavailable string = "Profile changes are temporarily unavailable"
+	TooManyProfileWatchers    string = "Too many profile watchers, try again later"
 )
@@ -1,12 +1,16 @@
 package profile
 
 import (
+	"sync"
+
 	"github.com/kyamalabs/users/api/pb"
 	"github.com/kyamalabs/users/internal/cache"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	"github.com/kyamalabs/users/internal/services"
 	"github.com/kyamalabs/users/internal/util"
 	"github.com/kyamalabs/users/internal/worker"
+	"golang.org/x/sync/singleflight"
 )
 
 type Handler struct {
@@ -16,14 +20,39 @@ type Handler struct {
 	store           db.Store
 	taskDistributor worker.TaskDistributor
 	authService     services.AuthGrpcService
+	profileWatcher  event.ProfileWatcher
+	loads           *singleflight.Group
+	stopWatches     *stopSignal
+}
+
+// stopSignal is closed once to end the streams a handler serves.
+type stopSignal struct {
+	once    sync.Once
+	stopped chan struct{}
 }
 
-func NewHandler(config util.Config, cache cache.Cache, store db.Store, taskDistributor worker.TaskDistributor, authService services.AuthGrpcService) Handler {
+func (signal *stopSignal) stop() {
+	signal.once.Do(func() {
+		close(signal.stopped)
+	})
+}
+
+func NewHandler(config util.Config, cache cache.Cache, store db.Store, taskDistributor worker.TaskDistributor, authService services.AuthGrpcService, profileWatcher event.ProfileWatcher) Handler {
 	return Handler{
 		config:          config,
 		cache:           cache,
 		store:           store,
 		taskDistributor: taskDistributor,
 		authService:     authService,
+		profileWatcher:  profileWatcher,
+		loads:           &singleflight.Group{},
+		stopWatches:     &stopSignal{stopped: make(chan struct{})},
 	}
 }
+
+// StopWatches ends the WatchProfiles streams being served and the ones started afterwards, so
+// the server can stop gracefully without waiting on watchers that never disconnect. Watchers
+// are told to reconnect, which gets them served by another replica.
+func (h *Handler) StopWatches() {
+	h.stopWatches.stop()
+}
@@ -0,0 +1,138 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyamalabs/users/internal/cache"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	profileCacheKeyPrefix      = "profile"
+	profileCacheExpiration     = 10 * time.Minute
+	profilePageCacheKeyPrefix  = "profiles:page"
+	profilePageCacheExpiration = time.Minute
+	profilePagesVersionKey     = "profiles:pages:version"
+	maxCachedProfilePage       = 3
+	sharedLoadTimeout          = 5 * time.Second
+)
+
+type cachedProfilePage struct {
+	Profiles      []db.Profile `json:"profiles"`
+	TotalProfiles int64        `json:"total_profiles"`
+}
+
+func getProfileCacheKey(walletAddress string) string {
+	return fmt.Sprintf("%s:%s", profileCacheKeyPrefix, walletAddress)
+}
+
+// getProfile reads a profile record through the cache. Concurrent misses for the same
+// wallet address share a single query.
+func (h *Handler) getProfile(ctx context.Context, walletAddress string) (db.Profile, error) {
+	key := getProfileCacheKey(walletAddress)
+	profiles := cache.NewTyped[db.Profile](h.cache, cache.JSONCodec[db.Profile]{})
+
+	res, err := h.sharedLoad(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return profiles.GetOrLoad(ctx, key, profileCacheExpiration, func(ctx context.Context) (db.Profile, error) {
+			return h.store.GetProfile(ctx, walletAddress)
+		})
+	})
+	if err != nil {
+		return db.Profile{}, err
+	}
+
+	return res.(db.Profile), nil
+}
+
+// listProfiles reads a page of profiles along with the total profile count. Only the first
+// few pages are cached since they are the ones polled by the game lobby.
+func (h *Handler) listProfiles(ctx context.Context, params db.ListProfilesParams) (cachedProfilePage, error) {
+	load := func(ctx context.Context) (cachedProfilePage, error) {
+		profiles, err := h.store.ListProfiles(ctx, params)
+		if err != nil {
+			return cachedProfilePage{}, fmt.Errorf("could not list user profiles: %w", err)
+		}
+
+		totalProfiles, err := h.store.GetProfilesCount(ctx)
+		if err != nil {
+			return cachedProfilePage{}, fmt.Errorf("could not get total profiles count: %w", err)
+		}
+
+		return cachedProfilePage{
+			Profiles:      profiles,
+			TotalProfiles: totalProfiles,
+		}, nil
+	}
+
+	if params.Offset >= maxCachedProfilePage*params.Limit {
+		return load(ctx)
+	}
+
+	key := fmt.Sprintf("%s:%s:%d:%d", profilePageCacheKeyPrefix, h.getProfilePagesVersion(ctx), params.Limit, params.Offset)
+	pages := cache.NewTyped[cachedProfilePage](h.cache, cache.JSONCodec[cachedProfilePage]{})
+
+	res, err := h.sharedLoad(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return pages.GetOrLoad(ctx, key, profilePageCacheExpiration, load)
+	})
+	if err != nil {
+		return cachedProfilePage{}, err
+	}
+
+	return res.(cachedProfilePage), nil
+}
+
+// sharedLoad runs load once for all concurrent callers with the same key. The load is detached
+// from the cancellation of the caller that started it, so that caller going away does not fail
+// the others, and bounded by its own timeout instead. Each caller still returns as soon as its
+// own context is done.
+func (h *Handler) sharedLoad(ctx context.Context, key string, load func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	loadCtx := context.WithoutCancel(ctx)
+
+	results := h.loads.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(loadCtx, sharedLoadTimeout)
+		defer cancel()
+
+		return load(ctx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-results:
+		return res.Val, res.Err
+	}
+}
+
+// getProfilePagesVersion returns the token that namespaces cached profile pages. Replacing it
+// invalidates every cached page at once.
+func (h *Handler) getProfilePagesVersion(ctx context.Context) string {
+	res, err := h.cache.Get(ctx, profilePagesVersionKey)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not get cached profile pages version")
+	}
+
+	version, ok := res.(string)
+	if !ok {
+		return "0"
+	}
+
+	return version
+}
+
+// invalidateCachedProfile evicts a profile record and every cached profile page after a write.
+// Failures are logged rather than returned since cached entries expire on their own.
+func (h *Handler) invalidateCachedProfile(ctx context.Context, walletAddress string) {
+	err := h.cache.Del(ctx, getProfileCacheKey(walletAddress))
+	if err != nil && err != cache.Nil {
+		log.Ctx(ctx).Error().Err(err).Str("wallet_address", walletAddress).Msg("could not evict cached user profile")
+	}
+
+	err = h.cache.Set(ctx, profilePagesVersionKey, uuid.NewString(), 0)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("could not invalidate cached profile pages")
+	}
+}
@@ -0,0 +1,62 @@
+package profile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyamalabs/users/internal/cache"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProfile_SharedLoadOutlivesCaller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	walletAddress := "0x0000000000000000000000000000000000000001"
+	profile := db.Profile{WalletAddress: walletAddress, GamerTag: "some-gamer-tag"}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	store.EXPECT().
+		GetProfile(gomock.Any(), walletAddress).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, _ string) (db.Profile, error) {
+			close(started)
+			<-release
+			return profile, ctx.Err()
+		})
+
+	h := newTestHandler(store, cache.NewMemoryCache(0), nil, nil)
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := h.getProfile(firstCtx, walletAddress)
+		firstErr <- err
+	}()
+	<-started
+
+	secondRes := make(chan db.Profile, 1)
+	go func() {
+		res, err := h.getProfile(context.Background(), walletAddress)
+		require.NoError(t, err)
+		secondRes <- res
+	}()
+
+	// the caller that started the load goes away without failing the one sharing it
+	cancelFirst()
+	require.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+
+	select {
+	case res := <-secondRes:
+		require.Equal(t, profile, res)
+	case <-time.After(time.Second):
+		require.Fail(t, "shared load did not complete")
+	}
+}
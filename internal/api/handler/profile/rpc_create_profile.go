@@ -7,12 +7,15 @@ import (
 	"github.com/kyamalabs/users/internal/api/handler/referral"
 
 	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/internal/requestid"
+	"github.com/kyamalabs/users/internal/tracing"
 	"github.com/kyamalabs/users/internal/worker"
 
 	"github.com/kyamalabs/users/api/pb"
 	"github.com/kyamalabs/users/internal/api/handler"
 	"github.com/kyamalabs/users/internal/api/middleware"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	"github.com/kyamalabs/users/internal/validator"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -22,7 +25,7 @@ import (
 )
 
 func (h *Handler) CreateProfile(ctx context.Context, req *pb.CreateProfileRequest) (*pb.CreateProfileResponse, error) {
-	logger := log.With().Str("wallet_address", req.GetWalletAddress()).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
 
 	violations := validateCreateProfileRequest(req)
 	if violations != nil {
@@ -32,7 +35,37 @@ func (h *Handler) CreateProfile(ctx context.Context, req *pb.CreateProfileReques
 	_, err := middleware.AuthorizeUser(ctx, req.GetWalletAddress(), h.authService)
 	if err != nil {
 		logger.Error().Err(err).Msg("could not authorize user")
-		return nil, status.Error(codes.Unauthenticated, handler.UnauthorizedAccessError)
+		return nil, handler.AuthorizationError(err)
+	}
+
+	// the ens name resolution is recorded in the same transaction as the profile so that it is
+	// enqueued exactly when the profile is committed
+	cacheENSNameMessage, err := worker.CacheEnsNameTask.NewOutboxMessage(
+		&worker.PayloadCacheEnsName{
+			WalletAddress: req.GetWalletAddress(),
+		},
+		worker.OutboxTaskHeaders{
+			Queue:        worker.QueueDefault,
+			MaxRetry:     10,
+			ProcessIn:    10 * time.Second,
+			RequestID:    requestid.FromContext(ctx),
+			TraceContext: tracing.InjectContext(ctx),
+		},
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not build ens name resolution outbox message")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	events := []*pb.Event{event.NewProfileCreated(req.GetWalletAddress(), req.GetGamerTag())}
+	if req.GetReferrer() != "" {
+		events = append(events, event.NewReferralRecorded(req.GetReferrer(), req.GetWalletAddress()))
+	}
+
+	eventMessages, err := event.NewOutboxMessages(events...)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not build event outbox messages")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
 
 	params := db.CreateProfileTxParams{
@@ -40,10 +73,8 @@ func (h *Handler) CreateProfile(ctx context.Context, req *pb.CreateProfileReques
 			WalletAddress: req.GetWalletAddress(),
 			GamerTag:      req.GetGamerTag(),
 		},
-		Referrer: req.GetReferrer(),
-		AfterCreate: func() error {
-			return cacheENSName(ctx, req.GetWalletAddress(), h.taskDistributor)
-		},
+		Referrer:       req.GetReferrer(),
+		OutboxMessages: append([]db.CreateOutboxMessageParams{cacheENSNameMessage}, eventMessages...),
 	}
 
 	txResult, err := h.store.CreateProfileTx(ctx, params)
@@ -52,11 +83,14 @@ func (h *Handler) CreateProfile(ctx context.Context, req *pb.CreateProfileReques
 		return nil, handleCreateProfileTxError(err)
 	}
 
+	h.invalidateCachedProfile(ctx, req.GetWalletAddress())
+
 	response := &pb.CreateProfileResponse{
 		Profile: &pb.Profile{
 			WalletAddress: txResult.Profile.WalletAddress,
 			GamerTag:      txResult.Profile.GamerTag,
 			CreatedAt:     timestamppb.New(txResult.Profile.CreatedAt),
+			EnsStatus:     pb.EnsStatus_ENS_STATUS_RESOLVING,
 		},
 		Referral: &pb.Referral{
 			Referrer:   txResult.Referral.Referrer,
@@ -98,7 +132,7 @@ func cacheENSName(ctx context.Context, walletAddress string, taskDistributor wor
 		asynq.Queue(worker.QueueDefault),
 	}
 
-	return taskDistributor.DistributeTaskCacheEnsName(ctx, taskPayload, opts...)
+	return worker.CacheEnsNameTask.Enqueue(ctx, taskDistributor, taskPayload, opts...)
 }
 
 func validateCreateProfileRequest(req *pb.CreateProfileRequest) (violations []*errdetails.BadRequest_FieldViolation) {
@@ -15,7 +15,9 @@ import (
 	mockcache "github.com/kyamalabs/users/internal/cache/mock"
 	mockdb "github.com/kyamalabs/users/internal/db/mock"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/kyamalabs/users/internal/worker"
 	mockwk "github.com/kyamalabs/users/internal/worker/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -56,7 +58,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -69,16 +71,27 @@ func TestCreateProfileAPI(t *testing.T) {
 				store.EXPECT().
 					CreateProfileTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.CreateProfileTxResult{
-						Profile: db.Profile{
-							WalletAddress: createProfileReqParams.GetWalletAddress(),
-							GamerTag:      createProfileReqParams.GetGamerTag(),
-						},
-						Referral: db.Referral{
-							Referrer: createProfileReqParams.GetReferrer(),
-							Referee:  createProfileReqParams.GetWalletAddress(),
-						},
-					}, nil)
+					DoAndReturn(func(ctx context.Context, params db.CreateProfileTxParams) (db.CreateProfileTxResult, error) {
+						require.Len(t, params.OutboxMessages, 3)
+						require.Equal(t, worker.OutboxSinkTasks, params.OutboxMessages[0].Sink)
+						require.Equal(t, worker.TaskCacheENSName, params.OutboxMessages[0].Topic)
+						require.Contains(t, string(params.OutboxMessages[0].Payload), createProfileReqParams.GetWalletAddress())
+						require.Equal(t, event.TypeProfileCreated, params.OutboxMessages[1].Topic)
+						require.Equal(t, event.TypeReferralRecorded, params.OutboxMessages[2].Topic)
+
+						return db.CreateProfileTxResult{
+							Profile: db.Profile{
+								WalletAddress: createProfileReqParams.GetWalletAddress(),
+								GamerTag:      createProfileReqParams.GetGamerTag(),
+							},
+							Referral: db.Referral{
+								Referrer: createProfileReqParams.GetReferrer(),
+								Referee:  createProfileReqParams.GetWalletAddress(),
+							},
+						}, nil
+					})
+
+				expectCachedProfileInvalidation(cache, createProfileReqParams.GetWalletAddress())
 			},
 			checkResponse: func(t *testing.T, res *pb.CreateProfileResponse, err error) {
 				require.NoError(t, err)
@@ -99,7 +112,7 @@ func TestCreateProfileAPI(t *testing.T) {
 				Referrer:      createProfileReqParams.GetReferrer()[:len(createProfileReqParams.GetReferrer())-1],
 			},
 			buildContext: func(t *testing.T) context.Context {
-				return nil
+				return context.Background()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 			},
@@ -119,7 +132,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("some verify access token error"))
 			},
@@ -138,7 +151,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -168,7 +181,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -198,7 +211,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -228,7 +241,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -258,7 +271,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -288,7 +301,7 @@ func TestCreateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
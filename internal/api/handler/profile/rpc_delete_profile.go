@@ -6,6 +6,8 @@ import (
 	"github.com/kyamalabs/users/api/pb"
 	"github.com/kyamalabs/users/internal/api/handler"
 	"github.com/kyamalabs/users/internal/api/middleware"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	"github.com/kyamalabs/users/internal/validator"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -15,7 +17,7 @@ import (
 )
 
 func (h *Handler) DeleteProfile(ctx context.Context, req *pb.DeleteProfileRequest) (*emptypb.Empty, error) {
-	logger := log.With().Str("wallet_address", req.GetWalletAddress()).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
 
 	violations := validateDeleteProfileRequest(req)
 	if violations != nil {
@@ -25,15 +27,36 @@ func (h *Handler) DeleteProfile(ctx context.Context, req *pb.DeleteProfileReques
 	_, err := middleware.AuthorizeUser(ctx, req.GetWalletAddress(), h.authService)
 	if err != nil {
 		logger.Error().Err(err).Msg("could not authorize user")
-		return nil, status.Error(codes.Unauthenticated, handler.UnauthorizedAccessError)
+		return nil, handler.AuthorizationError(err)
 	}
 
-	err = h.store.DeleteProfile(ctx, req.GetWalletAddress())
+	eventMessages, err := event.NewOutboxMessages(event.NewProfileDeleted(req.GetWalletAddress()))
 	if err != nil {
-		logger.Error().Err(err).Msg("could not create user profile")
+		logger.Error().Err(err).Msg("could not build event outbox messages")
 		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
 
+	err = h.store.DeleteProfileTx(ctx, db.DeleteProfileTxParams{
+		WalletAddress:  req.GetWalletAddress(),
+		OutboxMessages: eventMessages,
+	})
+	if err != nil {
+		if err == db.RecordNotFoundError {
+			logger.Error().Err(err).Msg("user profile does not exist")
+			return nil, status.Error(codes.NotFound, DoesNotExist)
+		}
+
+		logger.Error().Err(err).Msg("could not delete user profile")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	h.invalidateCachedProfile(ctx, req.GetWalletAddress())
+
+	h.notifyProfileChange(ctx, &pb.ProfileChange{
+		Type:          pb.ProfileChangeType_PROFILE_CHANGE_TYPE_DELETED,
+		WalletAddress: req.GetWalletAddress(),
+	})
+
 	logger.Info().Msg("user profile deleted successfully")
 
 	return &emptypb.Empty{}, nil
@@ -11,10 +11,14 @@ import (
 	"github.com/kyamalabs/users/internal/api/handler"
 	mockcache "github.com/kyamalabs/users/internal/cache/mock"
 	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	mockservices "github.com/kyamalabs/users/internal/services/mock"
 	mockwk "github.com/kyamalabs/users/internal/worker/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -47,7 +51,7 @@ func TestDeleteProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -58,9 +62,17 @@ func TestDeleteProfileAPI(t *testing.T) {
 					}, nil)
 
 				store.EXPECT().
-					DeleteProfile(gomock.Any(), gomock.Any()).
+					DeleteProfileTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil)
+					DoAndReturn(func(ctx context.Context, params db.DeleteProfileTxParams) error {
+						require.Equal(t, deleteProfileReqParams.GetWalletAddress(), params.WalletAddress)
+						require.Len(t, params.OutboxMessages, 1)
+						require.Equal(t, event.TypeProfileDeleted, params.OutboxMessages[0].Topic)
+
+						return nil
+					})
+
+				expectCachedProfileInvalidation(cache, deleteProfileReqParams.GetWalletAddress())
 			},
 			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
 				require.NoError(t, err)
@@ -73,7 +85,7 @@ func TestDeleteProfileAPI(t *testing.T) {
 				WalletAddress: deleteProfileReqParams.GetWalletAddress()[:len(deleteProfileReqParams.GetWalletAddress())-1],
 			},
 			buildContext: func(t *testing.T) context.Context {
-				return nil
+				return context.Background()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 			},
@@ -93,7 +105,7 @@ func TestDeleteProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("some verify access token error"))
 			},
@@ -104,6 +116,26 @@ func TestDeleteProfileAPI(t *testing.T) {
 				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
 			},
 		},
+		{
+			name: "auth service unavailable",
+			req:  deleteProfileReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authService.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, status.Error(codes.Unavailable, "auth service is unavailable"))
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.Equal(t, codes.Unavailable, status.Code(err))
+				require.ErrorContains(t, err, handler.AuthUnavailableError)
+			},
+		},
 		{
 			name: "db error",
 			req:  deleteProfileReqParams,
@@ -112,7 +144,7 @@ func TestDeleteProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -123,7 +155,7 @@ func TestDeleteProfileAPI(t *testing.T) {
 					}, nil)
 
 				store.EXPECT().
-					DeleteProfile(gomock.Any(), gomock.Any()).
+					DeleteProfileTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(errors.New("some db error"))
 			},
@@ -134,6 +166,36 @@ func TestDeleteProfileAPI(t *testing.T) {
 				require.ErrorContains(t, err, handler.InternalServerError)
 			},
 		},
+		{
+			name: "profile does not exist",
+			req:  deleteProfileReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authService.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&authPb.VerifyAccessTokenResponse{
+						Payload: &authPb.AccessTokenPayload{
+							Id:            "some-id",
+							WalletAddress: deleteProfileReqParams.WalletAddress,
+							Role:          authPb.AccessTokenPayload_GAMER,
+						},
+					}, nil)
+
+				store.EXPECT().
+					DeleteProfileTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordNotFoundError)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, DoesNotExist)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -18,7 +18,7 @@ import (
 )
 
 func (h *Handler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
-	logger := log.With().Str("wallet_address", req.GetWalletAddress()).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
 
 	violations := validateGetProfileRequest(req)
 	if violations != nil {
@@ -28,17 +28,10 @@ func (h *Handler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*p
 	_, err := middleware.AuthorizeUser(ctx, req.GetWalletAddress(), h.authService)
 	if err != nil {
 		logger.Error().Err(err).Msg("could not authorize user")
-		return nil, status.Error(codes.Unauthenticated, handler.UnauthorizedAccessError)
+		return nil, handler.AuthorizationError(err)
 	}
 
-	params := db.GetProfileTxParams{
-		WalletAddress: req.GetWalletAddress(),
-		AfterCreate: func() (string, error) {
-			return getCachedENSName(ctx, req.GetWalletAddress(), h.cache, h.taskDistributor)
-		},
-	}
-
-	txResult, err := h.store.GetProfileTx(ctx, params)
+	profile, err := h.getProfile(ctx, req.GetWalletAddress())
 	if err != nil {
 		if err == db.RecordNotFoundError {
 			logger.Error().Err(err).Msg("user profile does not exist")
@@ -49,12 +42,19 @@ func (h *Handler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*p
 		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
 
+	ensName, ensStatus, err := getCachedENSName(ctx, req.GetWalletAddress(), h.cache, h.taskDistributor)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not get cached ens name")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
 	response := &pb.GetProfileResponse{
 		Profile: &pb.Profile{
-			WalletAddress: txResult.Profile.WalletAddress,
-			EnsName:       txResult.EnsName,
-			GamerTag:      txResult.Profile.GamerTag,
-			CreatedAt:     timestamppb.New(txResult.Profile.CreatedAt),
+			WalletAddress: profile.WalletAddress,
+			EnsName:       ensName,
+			GamerTag:      profile.GamerTag,
+			CreatedAt:     timestamppb.New(profile.CreatedAt),
+			EnsStatus:     ensStatus,
 		},
 	}
 
@@ -63,14 +63,30 @@ func (h *Handler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*p
 	return response, nil
 }
 
-func getCachedENSName(ctx context.Context, walletAddress string, c cache.Cache, taskDistributor worker.TaskDistributor) (string, error) {
-	ensName, err := worker.GetCachedENSName(ctx, c, walletAddress)
+func getCachedENSName(ctx context.Context, walletAddress string, c cache.Cache, taskDistributor worker.TaskDistributor) (string, pb.EnsStatus, error) {
+	cachedENSName, err := worker.GetCachedENSName(ctx, c, walletAddress)
 	if err == cache.Nil {
 		err = cacheENSName(ctx, walletAddress, taskDistributor)
-		return "", err
+		return "", pb.EnsStatus_ENS_STATUS_RESOLVING, err
+	}
+	if err != nil {
+		return "", pb.EnsStatus_ENS_STATUS_UNSPECIFIED, err
+	}
+
+	return cachedENSName.Name, toPbEnsStatus(cachedENSName.Status), nil
+}
+
+func toPbEnsStatus(status worker.EnsNameStatus) pb.EnsStatus {
+	switch status {
+	case worker.EnsNameStatusResolved:
+		return pb.EnsStatus_ENS_STATUS_RESOLVED
+	case worker.EnsNameStatusNone:
+		return pb.EnsStatus_ENS_STATUS_NONE
+	case worker.EnsNameStatusError:
+		return pb.EnsStatus_ENS_STATUS_ERROR
 	}
 
-	return ensName, err
+	return pb.EnsStatus_ENS_STATUS_UNSPECIFIED
 }
 
 func validateGetProfileRequest(req *pb.GetProfileRequest) (violations []*errdetails.BadRequest_FieldViolation) {
@@ -47,7 +47,7 @@ func TestGetProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -57,16 +57,28 @@ func TestGetProfileAPI(t *testing.T) {
 						},
 					}, nil)
 
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(nil, nil)
+
 				store.EXPECT().
-					GetProfileTx(gomock.Any(), gomock.Any()).
+					GetProfile(gomock.Any(), getProfileReqParams.GetWalletAddress()).
 					Times(1).
-					Return(db.GetProfileTxResult{
-						EnsName: "mamabear",
-						Profile: db.Profile{
-							WalletAddress: getProfileReqParams.GetWalletAddress(),
-							GamerTag:      "mamabear",
-						},
+					Return(db.Profile{
+						WalletAddress: getProfileReqParams.GetWalletAddress(),
+						GamerTag:      "mamabear",
 					}, nil)
+
+				cache.EXPECT().
+					Set(gomock.Any(), getProfileCacheKey(getProfileReqParams.GetWalletAddress()), gomock.Any(), profileCacheExpiration).
+					Times(1).
+					Return(nil)
+
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("mamabear", nil)
 			},
 			checkResponse: func(t *testing.T, res *pb.GetProfileResponse, err error) {
 				require.NoError(t, err)
@@ -84,7 +96,7 @@ func TestGetProfileAPI(t *testing.T) {
 				WalletAddress: getProfileReqParams.GetWalletAddress()[:len(getProfileReqParams.GetWalletAddress())-1],
 			},
 			buildContext: func(t *testing.T) context.Context {
-				return nil
+				return context.Background()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 			},
@@ -104,7 +116,7 @@ func TestGetProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("some verify access token error"))
 			},
@@ -123,7 +135,7 @@ func TestGetProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -133,10 +145,15 @@ func TestGetProfileAPI(t *testing.T) {
 						},
 					}, nil)
 
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(nil, nil)
+
 				store.EXPECT().
-					GetProfileTx(gomock.Any(), gomock.Any()).
+					GetProfile(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.GetProfileTxResult{}, db.RecordNotFoundError)
+					Return(db.Profile{}, db.RecordNotFoundError)
 			},
 			checkResponse: func(t *testing.T, res *pb.GetProfileResponse, err error) {
 				require.Error(t, err)
@@ -150,7 +167,7 @@ func TestGetProfileAPI(t *testing.T) {
 			req:  getProfileReqParams,
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -160,10 +177,15 @@ func TestGetProfileAPI(t *testing.T) {
 						},
 					}, nil)
 
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(nil, nil)
+
 				store.EXPECT().
-					GetProfileTx(gomock.Any(), gomock.Any()).
+					GetProfile(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.GetProfileTxResult{}, errors.New("some db error"))
+					Return(db.Profile{}, errors.New("some db error"))
 			},
 			buildContext: func(t *testing.T) context.Context {
 				return handler.NewContextWithBearerToken()
@@ -13,21 +13,14 @@ import (
 )
 
 func (h *Handler) GetPublicProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetPublicProfileResponse, error) {
-	logger := log.With().Str("wallet_address", req.GetWalletAddress()).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
 
 	violations := validateGetProfileRequest(req)
 	if violations != nil {
 		return nil, handler.InvalidArgumentError(violations)
 	}
 
-	params := db.GetProfileTxParams{
-		WalletAddress: req.GetWalletAddress(),
-		AfterCreate: func() (string, error) {
-			return getCachedENSName(ctx, req.GetWalletAddress(), h.cache, h.taskDistributor)
-		},
-	}
-
-	txResult, err := h.store.GetProfileTx(ctx, params)
+	profile, err := h.getProfile(ctx, req.GetWalletAddress())
 	if err != nil {
 		if err == db.RecordNotFoundError {
 			logger.Error().Err(err).Msg("user profile does not exist")
@@ -38,12 +31,19 @@ func (h *Handler) GetPublicProfile(ctx context.Context, req *pb.GetProfileReques
 		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
 
+	ensName, ensStatus, err := getCachedENSName(ctx, req.GetWalletAddress(), h.cache, h.taskDistributor)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not get cached ens name")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
 	response := &pb.GetPublicProfileResponse{
 		Profile: &pb.PublicProfile{
-			WalletAddress: txResult.Profile.WalletAddress,
-			EnsName:       txResult.EnsName,
-			GamerTag:      txResult.Profile.GamerTag,
-			CreatedAt:     timestamppb.New(txResult.Profile.CreatedAt),
+			WalletAddress: profile.WalletAddress,
+			EnsName:       ensName,
+			GamerTag:      profile.GamerTag,
+			CreatedAt:     timestamppb.New(profile.CreatedAt),
+			EnsStatus:     ensStatus,
 		},
 	}
 
@@ -45,16 +45,30 @@ func TestGetPublicProfileAPI(t *testing.T) {
 				return handler.NewContextWithBearerToken()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				profile := db.Profile{
+					WalletAddress: getPublicProfileReqParams.GetWalletAddress(),
+					GamerTag:      "mamabear",
+				}
+
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getPublicProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(nil, nil)
+
 				store.EXPECT().
-					GetProfileTx(gomock.Any(), gomock.Any()).
+					GetProfile(gomock.Any(), getPublicProfileReqParams.GetWalletAddress()).
 					Times(1).
-					Return(db.GetProfileTxResult{
-						EnsName: "mamabear",
-						Profile: db.Profile{
-							WalletAddress: getPublicProfileReqParams.GetWalletAddress(),
-							GamerTag:      "mamabear",
-						},
-					}, nil)
+					Return(profile, nil)
+
+				cache.EXPECT().
+					Set(gomock.Any(), getProfileCacheKey(getPublicProfileReqParams.GetWalletAddress()), profile, profileCacheExpiration).
+					Times(1).
+					Return(nil)
+
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("mamabear", nil)
 			},
 			checkResponse: func(t *testing.T, res *pb.GetPublicProfileResponse, err error) {
 				require.NoError(t, err)
@@ -62,17 +76,52 @@ func TestGetPublicProfileAPI(t *testing.T) {
 
 				require.Equal(t, getPublicProfileReqParams.GetWalletAddress(), res.GetProfile().GetWalletAddress())
 				require.Equal(t, "mamabear", res.GetProfile().GetEnsName())
+				require.Equal(t, pb.EnsStatus_ENS_STATUS_RESOLVED, res.GetProfile().GetEnsStatus())
 				require.NotEmpty(t, res.GetProfile().GetGamerTag())
 				require.NotZero(t, res.GetProfile().GetCreatedAt())
 			},
 		},
+		{
+			name: "cached profile",
+			req:  getPublicProfileReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getPublicProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(map[string]interface{}{
+						"wallet_address": getPublicProfileReqParams.GetWalletAddress(),
+						"gamer_tag":      "mamabear",
+						"created_at":     "2024-02-12T06:51:34Z",
+					}, nil)
+
+				store.EXPECT().
+					GetProfile(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("mamabear", nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetPublicProfileResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res)
+
+				require.Equal(t, getPublicProfileReqParams.GetWalletAddress(), res.GetProfile().GetWalletAddress())
+				require.Equal(t, "mamabear", res.GetProfile().GetGamerTag())
+				require.Equal(t, int64(1707720694), res.GetProfile().GetCreatedAt().GetSeconds())
+			},
+		},
 		{
 			name: "invalid request parameters",
 			req: &pb.GetProfileRequest{
 				WalletAddress: getPublicProfileReqParams.GetWalletAddress()[:len(getPublicProfileReqParams.GetWalletAddress())-1],
 			},
 			buildContext: func(t *testing.T) context.Context {
-				return nil
+				return context.Background()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 			},
@@ -91,10 +140,19 @@ func TestGetPublicProfileAPI(t *testing.T) {
 				return handler.NewContextWithBearerToken()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getPublicProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(nil, nil)
+
 				store.EXPECT().
-					GetProfileTx(gomock.Any(), gomock.Any()).
+					GetProfile(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.GetProfileTxResult{}, db.RecordNotFoundError)
+					Return(db.Profile{}, db.RecordNotFoundError)
+
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, res *pb.GetPublicProfileResponse, err error) {
 				require.Error(t, err)
@@ -110,10 +168,42 @@ func TestGetPublicProfileAPI(t *testing.T) {
 				return handler.NewContextWithBearerToken()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getPublicProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(nil, nil)
+
 				store.EXPECT().
-					GetProfileTx(gomock.Any(), gomock.Any()).
+					GetProfile(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Profile{}, errors.New("some db error"))
+			},
+			checkResponse: func(t *testing.T, res *pb.GetPublicProfileResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+		{
+			name: "ens name cache error",
+			req:  getPublicProfileReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				cache.EXPECT().
+					Get(gomock.Any(), getProfileCacheKey(getPublicProfileReqParams.GetWalletAddress())).
+					Times(1).
+					Return(map[string]interface{}{
+						"wallet_address": getPublicProfileReqParams.GetWalletAddress(),
+						"gamer_tag":      "mamabear",
+					}, nil)
+
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.GetProfileTxResult{}, errors.New("some db error"))
+					Return(nil, errors.New("some cache error"))
 			},
 			checkResponse: func(t *testing.T, res *pb.GetPublicProfileResponse, err error) {
 				require.Error(t, err)
@@ -7,6 +7,7 @@ import (
 	"github.com/kyamalabs/users/internal/api/handler"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
 	"github.com/kyamalabs/users/internal/validator"
+	"github.com/kyamalabs/users/internal/worker"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -41,23 +42,36 @@ func (h *Handler) ListAllProfiles(ctx context.Context, req *pb.ListAllProfilesRe
 		Offset: offset,
 	}
 
-	profiles, err := h.store.ListProfiles(ctx, params)
+	profilePage, err := h.listProfiles(ctx, params)
 	if err != nil {
-		log.Error().Err(err).Msg("could not list user profiles")
+		log.Ctx(ctx).Error().Err(err).Msg("could not list user profiles")
 		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
+	profiles := profilePage.Profiles
 
-	totalProfilesCount, err := h.store.GetProfilesCount(ctx)
+	walletAddresses := make([]string, len(profiles))
+	for i, profile := range profiles {
+		walletAddresses[i] = profile.WalletAddress
+	}
+
+	cachedENSNames, err := worker.GetCachedENSNames(ctx, h.cache, walletAddresses)
 	if err != nil {
-		log.Error().Err(err).Msg("could not get total profiles count")
-		return nil, status.Error(codes.Internal, handler.InternalServerError)
+		log.Ctx(ctx).Error().Err(err).Msg("could not get cached ens names")
 	}
 
 	var publicProfiles []*pb.PublicProfile
 	for _, profile := range profiles {
-		ensName, err := getCachedENSName(ctx, profile.WalletAddress, h.cache, h.taskDistributor)
-		if err != nil {
-			log.Error().Err(err).Msg("could not get cached ens name")
+		ensName, ensStatus := "", pb.EnsStatus_ENS_STATUS_UNSPECIFIED
+
+		cachedENSName, ok := cachedENSNames[profile.WalletAddress]
+		switch {
+		case ok:
+			ensName, ensStatus = cachedENSName.Name, toPbEnsStatus(cachedENSName.Status)
+		case err == nil:
+			ensStatus = pb.EnsStatus_ENS_STATUS_RESOLVING
+			if enqueueErr := cacheENSName(ctx, profile.WalletAddress, h.taskDistributor); enqueueErr != nil {
+				log.Ctx(ctx).Error().Err(enqueueErr).Str("wallet_address", profile.WalletAddress).Msg("could not enqueue ens name resolution")
+			}
 		}
 
 		publicProfiles = append(publicProfiles, &pb.PublicProfile{
@@ -65,13 +79,14 @@ func (h *Handler) ListAllProfiles(ctx context.Context, req *pb.ListAllProfilesRe
 			GamerTag:      profile.GamerTag,
 			EnsName:       ensName,
 			CreatedAt:     timestamppb.New(profile.CreatedAt),
+			EnsStatus:     ensStatus,
 		})
 	}
 
 	response := &pb.ListAllProfilesResponse{
 		Page:          page,
 		PageSize:      limit,
-		TotalProfiles: int32(totalProfilesCount),
+		TotalProfiles: int32(profilePage.TotalProfiles),
 		Profiles:      publicProfiles,
 	}
 
@@ -3,6 +3,7 @@ package profile
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v6"
@@ -35,6 +36,25 @@ func generateProfiles(t *testing.T, num int) []db.Profile {
 	return profiles
 }
 
+func expectProfilePageCacheMiss(c *mockcache.MockCache, storesPage bool) {
+	c.EXPECT().
+		Get(gomock.Any(), profilePagesVersionKey).
+		Times(1).
+		Return(nil, nil)
+
+	c.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil, nil)
+
+	if storesPage {
+		c.EXPECT().
+			Set(gomock.Any(), gomock.Any(), gomock.Any(), profilePageCacheExpiration).
+			Times(1).
+			Return(nil)
+	}
+}
+
 func TestListAllProfilesAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -46,6 +66,8 @@ func TestListAllProfilesAPI(t *testing.T) {
 			name: "success",
 			req:  &pb.ListAllProfilesRequest{},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectProfilePageCacheMiss(cache, true)
+
 				store.EXPECT().
 					ListProfiles(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -57,9 +79,9 @@ func TestListAllProfilesAPI(t *testing.T) {
 					Return(int64(2), nil)
 
 				cache.EXPECT().
-					Get(gomock.Any(), gomock.Any()).
-					Times(2).
-					Return(gofakeit.Gamertag(), nil)
+					MGet(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]interface{}{gofakeit.Gamertag(), gofakeit.Gamertag()}, nil)
 			},
 			checkResponse: func(t *testing.T, res *pb.ListAllProfilesResponse, err error) {
 				require.NoError(t, err)
@@ -69,6 +91,144 @@ func TestListAllProfilesAPI(t *testing.T) {
 				require.Equal(t, int32(2), res.GetTotalProfiles())
 				require.Equal(t, int32(1), res.GetPage())
 				require.Equal(t, int32(30), res.GetPageSize())
+
+				for _, profile := range res.GetProfiles() {
+					require.Equal(t, pb.EnsStatus_ENS_STATUS_RESOLVED, profile.GetEnsStatus())
+				}
+			},
+		},
+		{
+			name: "ens names not yet resolved",
+			req:  &pb.ListAllProfilesRequest{},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectProfilePageCacheMiss(cache, true)
+
+				store.EXPECT().
+					ListProfiles(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(generateProfiles(t, 2), nil)
+
+				store.EXPECT().
+					GetProfilesCount(gomock.Any()).
+					Times(1).
+					Return(int64(2), nil)
+
+				cache.EXPECT().
+					MGet(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]interface{}{nil, nil}, nil)
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAllProfilesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetProfiles(), 2)
+
+				for _, profile := range res.GetProfiles() {
+					require.Empty(t, profile.GetEnsName())
+					require.Equal(t, pb.EnsStatus_ENS_STATUS_RESOLVING, profile.GetEnsStatus())
+				}
+			},
+		},
+		{
+			name: "cache error",
+			req:  &pb.ListAllProfilesRequest{},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectProfilePageCacheMiss(cache, true)
+
+				store.EXPECT().
+					ListProfiles(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(generateProfiles(t, 2), nil)
+
+				store.EXPECT().
+					GetProfilesCount(gomock.Any()).
+					Times(1).
+					Return(int64(2), nil)
+
+				cache.EXPECT().
+					MGet(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some cache error"))
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAllProfilesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetProfiles(), 2)
+
+				for _, profile := range res.GetProfiles() {
+					require.Empty(t, profile.GetEnsName())
+					require.Equal(t, pb.EnsStatus_ENS_STATUS_UNSPECIFIED, profile.GetEnsStatus())
+				}
+			},
+		},
+		{
+			name: "cached page",
+			req:  &pb.ListAllProfilesRequest{},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				cache.EXPECT().
+					Get(gomock.Any(), profilePagesVersionKey).
+					Times(1).
+					Return("some-version", nil)
+
+				cache.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf("%s:some-version:%d:%d", profilePageCacheKeyPrefix, defaultPageSize, 0)).
+					Times(1).
+					Return(map[string]interface{}{
+						"profiles": []interface{}{
+							map[string]interface{}{"wallet_address": "0xc0ffee254729296a45a3885639AC7E10F9d54979", "gamer_tag": "bulba"},
+						},
+						"total_profiles": float64(1),
+					}, nil)
+
+				store.EXPECT().
+					ListProfiles(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				cache.EXPECT().
+					MGet(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]interface{}{"bulba.eth"}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAllProfilesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetProfiles(), 1)
+				require.Equal(t, int32(1), res.GetTotalProfiles())
+				require.Equal(t, "bulba", res.GetProfiles()[0].GetGamerTag())
+				require.Equal(t, "bulba.eth", res.GetProfiles()[0].GetEnsName())
+			},
+		},
+		{
+			name: "page beyond cached pages",
+			req: &pb.ListAllProfilesRequest{
+				Page: maxCachedProfilePage + 1,
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					ListProfiles(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, nil)
+
+				store.EXPECT().
+					GetProfilesCount(gomock.Any()).
+					Times(1).
+					Return(int64(90), nil)
+
+				cache.EXPECT().
+					MGet(gomock.Any()).
+					Times(1).
+					Return([]interface{}{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAllProfilesResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetProfiles())
+				require.Equal(t, int32(90), res.GetTotalProfiles())
 			},
 		},
 		{
@@ -91,6 +251,8 @@ func TestListAllProfilesAPI(t *testing.T) {
 			name: "ListProfiles db error",
 			req:  &pb.ListAllProfilesRequest{},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectProfilePageCacheMiss(cache, false)
+
 				store.EXPECT().
 					ListProfiles(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -107,6 +269,8 @@ func TestListAllProfilesAPI(t *testing.T) {
 			name: "GetProfilesCount db error",
 			req:  &pb.ListAllProfilesRequest{},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectProfilePageCacheMiss(cache, false)
+
 				store.EXPECT().
 					ListProfiles(gomock.Any(), gomock.Any()).
 					Times(1).
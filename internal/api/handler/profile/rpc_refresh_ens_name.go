@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	"github.com/kyamalabs/users/internal/cache"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/kyamalabs/users/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	ensNameRefreshThrottleKeyPrefix = "ens-name-refresh"
+	ensNameRefreshThrottlePeriod    = 5 * time.Minute
+)
+
+func (h *Handler) RefreshEnsName(ctx context.Context, req *pb.RefreshEnsNameRequest) (*emptypb.Empty, error) {
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
+
+	violations := validateRefreshEnsNameRequest(req)
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeUser(ctx, req.GetWalletAddress(), h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize user")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	throttled, err := isEnsNameRefreshThrottled(ctx, req.GetWalletAddress(), h.cache)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not check ens name refresh throttle")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+	if throttled {
+		logger.Info().Msg("ens name refresh throttled")
+		return nil, status.Error(codes.ResourceExhausted, EnsNameRefreshThrottled)
+	}
+
+	err = worker.DeleteCachedENSName(ctx, h.cache, req.GetWalletAddress())
+	if err != nil {
+		logger.Error().Err(err).Msg("could not evict cached ens name")
+		releaseEnsNameRefreshThrottle(ctx, req.GetWalletAddress(), h.cache)
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	err = refreshENSName(ctx, req.GetWalletAddress(), h.taskDistributor)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not enqueue ens name refresh")
+		releaseEnsNameRefreshThrottle(ctx, req.GetWalletAddress(), h.cache)
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	logger.Info().Msg("ens name refresh scheduled successfully")
+
+	return &emptypb.Empty{}, nil
+}
+
+func getEnsNameRefreshThrottleKey(walletAddress string) string {
+	return fmt.Sprintf("%s:%s", ensNameRefreshThrottleKeyPrefix, walletAddress)
+}
+
+// isEnsNameRefreshThrottled claims the refresh window of the wallet address atomically, so
+// concurrent requests cannot both get past the throttle.
+func isEnsNameRefreshThrottled(ctx context.Context, walletAddress string, c cache.Cache) (bool, error) {
+	key := getEnsNameRefreshThrottleKey(walletAddress)
+
+	claimed, err := c.SetNX(ctx, key, time.Now().Unix(), ensNameRefreshThrottlePeriod)
+	if err != nil {
+		return false, err
+	}
+
+	return !claimed, nil
+}
+
+// releaseEnsNameRefreshThrottle lets the wallet address request a refresh again right away after
+// its claimed refresh could not be scheduled.
+func releaseEnsNameRefreshThrottle(ctx context.Context, walletAddress string, c cache.Cache) {
+	err := c.Del(ctx, getEnsNameRefreshThrottleKey(walletAddress))
+	if err != nil && err != cache.Nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not release ens name refresh throttle")
+	}
+}
+
+func refreshENSName(ctx context.Context, walletAddress string, taskDistributor worker.TaskDistributor) error {
+	taskPayload := &worker.PayloadCacheEnsName{
+		WalletAddress: walletAddress,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	return worker.CacheEnsNameTask.Enqueue(ctx, taskDistributor, taskPayload, opts...)
+}
+
+func validateRefreshEnsNameRequest(req *pb.RefreshEnsNameRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateWalletAddress(req.GetWalletAddress()); err != nil {
+		violations = append(violations, handler.FieldViolation("wallet_address", err))
+	}
+
+	return violations
+}
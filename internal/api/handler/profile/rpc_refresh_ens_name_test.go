@@ -0,0 +1,264 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyamalabs/auth/pkg/util"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockcache "github.com/kyamalabs/users/internal/cache/mock"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/kyamalabs/users/internal/worker"
+	mockwk "github.com/kyamalabs/users/internal/worker/mock"
+	"github.com/kyamalabs/users/internal/worker/workertest"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func generateRefreshEnsNameReqParams(t *testing.T) *pb.RefreshEnsNameRequest {
+	wallet, err := util.NewEthereumWallet()
+	require.NoError(t, err)
+	require.NotEmpty(t, wallet)
+
+	return &pb.RefreshEnsNameRequest{
+		WalletAddress: wallet.Address,
+	}
+}
+
+func TestRefreshEnsNameAPI(t *testing.T) {
+	refreshEnsNameReqParams := generateRefreshEnsNameReqParams(t)
+	require.NotEmpty(t, refreshEnsNameReqParams)
+
+	authorizeUser := func(authService *mockservices.MockAuthGrpcService) {
+		authService.EXPECT().
+			VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(&authPb.VerifyAccessTokenResponse{
+				Payload: &authPb.AccessTokenPayload{
+					Id:            "some-id",
+					WalletAddress: refreshEnsNameReqParams.WalletAddress,
+					Role:          authPb.AccessTokenPayload_GAMER,
+				},
+			}, nil)
+	}
+
+	throttleKey := getEnsNameRefreshThrottleKey(refreshEnsNameReqParams.GetWalletAddress())
+
+	testCases := []struct {
+		name          string
+		req           *pb.RefreshEnsNameRequest
+		buildContext  func(t *testing.T) context.Context
+		buildStubs    func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *emptypb.Empty, err error)
+	}{
+		{
+			name: "success",
+			req:  refreshEnsNameReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authorizeUser(authService)
+
+				cache.EXPECT().
+					SetNX(gomock.Any(), throttleKey, gomock.Any(), ensNameRefreshThrottlePeriod).
+					Times(1).
+					Return(true, nil)
+
+				cache.EXPECT().
+					Del(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), workertest.EnqueuedTask(worker.CacheEnsNameTask, &worker.PayloadCacheEnsName{WalletAddress: refreshEnsNameReqParams.GetWalletAddress()})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &emptypb.Empty{}, res)
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req: &pb.RefreshEnsNameRequest{
+				WalletAddress: refreshEnsNameReqParams.GetWalletAddress()[:len(refreshEnsNameReqParams.GetWalletAddress())-1],
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return context.Background()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"wallet_address"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "unauthorized user",
+			req:  refreshEnsNameReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authService.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some verify access token error"))
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "throttled",
+			req:  refreshEnsNameReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authorizeUser(authService)
+
+				cache.EXPECT().
+					SetNX(gomock.Any(), throttleKey, gomock.Any(), ensNameRefreshThrottlePeriod).
+					Times(1).
+					Return(false, nil)
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, EnsNameRefreshThrottled)
+			},
+		},
+		{
+			name: "cache error",
+			req:  refreshEnsNameReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authorizeUser(authService)
+
+				cache.EXPECT().
+					SetNX(gomock.Any(), throttleKey, gomock.Any(), ensNameRefreshThrottlePeriod).
+					Times(1).
+					Return(false, errors.New("some cache error"))
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+		{
+			name: "evict cached ens name error",
+			req:  refreshEnsNameReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authorizeUser(authService)
+
+				cache.EXPECT().
+					SetNX(gomock.Any(), throttleKey, gomock.Any(), ensNameRefreshThrottlePeriod).
+					Times(1).
+					Return(true, nil)
+
+				cache.EXPECT().
+					Del(gomock.Any(), gomock.Not(throttleKey)).
+					Times(1).
+					Return(errors.New("some cache error"))
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				cache.EXPECT().
+					Del(gomock.Any(), throttleKey).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+		{
+			name: "task distributor error",
+			req:  refreshEnsNameReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authorizeUser(authService)
+
+				cache.EXPECT().
+					SetNX(gomock.Any(), throttleKey, gomock.Any(), ensNameRefreshThrottlePeriod).
+					Times(1).
+					Return(true, nil)
+
+				cache.EXPECT().
+					Del(gomock.Any(), gomock.Not(throttleKey)).
+					Times(1).
+					Return(nil)
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some task distributor error"))
+
+				// the refresh was not scheduled, so it may be requested again right away
+				cache.EXPECT().
+					Del(gomock.Any(), throttleKey).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			cache := mockcache.NewMockCache(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+
+			tc.buildStubs(store, cache, authService, taskDistributor)
+
+			h := newTestHandler(store, cache, authService, taskDistributor)
+
+			ctx := tc.buildContext(t)
+			res, err := h.RefreshEnsName(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
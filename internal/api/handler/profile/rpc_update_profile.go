@@ -3,10 +3,12 @@ package profile
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kyamalabs/users/api/pb"
 	"github.com/kyamalabs/users/internal/api/handler"
 	"github.com/kyamalabs/users/internal/api/middleware"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	"github.com/kyamalabs/users/internal/validator"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -16,7 +18,7 @@ import (
 )
 
 func (h *Handler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
-	logger := log.With().Str("wallet_address", req.GetWalletAddress()).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
 
 	violations := validateUpdateProfileRequest(req)
 	if violations != nil {
@@ -26,29 +28,65 @@ func (h *Handler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileReques
 	_, err := middleware.AuthorizeUser(ctx, req.GetWalletAddress(), h.authService)
 	if err != nil {
 		logger.Error().Err(err).Msg("could not authorize user")
-		return nil, status.Error(codes.Unauthenticated, handler.UnauthorizedAccessError)
+		return nil, handler.AuthorizationError(err)
+	}
+
+	var events []*pb.Event
+	if req.GetGamerTag() != "" {
+		events = append(events, event.NewGamerTagChanged(req.GetWalletAddress(), req.GetGamerTag()))
+	}
+
+	eventMessages, err := event.NewOutboxMessages(events...)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not build event outbox messages")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
 
 	params := db.UpdateProfileTxParams{
-		WalletAddress: req.GetWalletAddress(),
-		GamerTag:      req.GetGamerTag(),
-		AfterCreate: func() (string, error) {
-			return getCachedENSName(ctx, req.GetWalletAddress(), h.cache, h.taskDistributor)
+		UpdateProfileParams: db.UpdateProfileParams{
+			WalletAddress: req.GetWalletAddress(),
+			GamerTag: pgtype.Text{
+				String: req.GetGamerTag(),
+				Valid:  req.GetGamerTag() != "",
+			},
 		},
+		OutboxMessages: eventMessages,
 	}
 
 	txResult, err := h.store.UpdateProfileTx(ctx, params)
 	if err != nil {
+		if err == db.RecordNotFoundError {
+			logger.Error().Err(err).Msg("user profile does not exist")
+			return nil, status.Error(codes.NotFound, DoesNotExist)
+		}
+
 		logger.Error().Err(err).Msg("could not update user profile")
 		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
 
+	h.invalidateCachedProfile(ctx, req.GetWalletAddress())
+
+	// the update is already committed, so a cache failure only costs the response its ens name
+	ensName, ensStatus, err := getCachedENSName(ctx, req.GetWalletAddress(), h.cache, h.taskDistributor)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not get cached ens name")
+	}
+
+	if txResult.Changed {
+		h.notifyProfileChange(ctx, &pb.ProfileChange{
+			Type:          pb.ProfileChangeType_PROFILE_CHANGE_TYPE_GAMER_TAG_CHANGED,
+			WalletAddress: txResult.Profile.WalletAddress,
+			GamerTag:      txResult.Profile.GamerTag,
+		})
+	}
+
 	response := &pb.UpdateProfileResponse{
 		Profile: &pb.Profile{
 			WalletAddress: txResult.Profile.WalletAddress,
 			GamerTag:      txResult.Profile.GamerTag,
-			EnsName:       txResult.EnsName,
+			EnsName:       ensName,
 			CreatedAt:     timestamppb.New(txResult.Profile.CreatedAt),
+			EnsStatus:     ensStatus,
 		},
 	}
 
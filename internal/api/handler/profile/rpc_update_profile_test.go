@@ -13,6 +13,7 @@ import (
 	mockcache "github.com/kyamalabs/users/internal/cache/mock"
 	mockdb "github.com/kyamalabs/users/internal/db/mock"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	mockservices "github.com/kyamalabs/users/internal/services/mock"
 	mockwk "github.com/kyamalabs/users/internal/worker/mock"
 	"github.com/stretchr/testify/require"
@@ -49,7 +50,7 @@ func TestUpdateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -62,13 +63,26 @@ func TestUpdateProfileAPI(t *testing.T) {
 				store.EXPECT().
 					UpdateProfileTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.UpdateProfileTxResult{
-						EnsName: "mamabear",
-						Profile: db.Profile{
-							WalletAddress: updateProfileReqParams.GetWalletAddress(),
-							GamerTag:      updateProfileReqParams.GetGamerTag(),
-						},
-					}, nil)
+					DoAndReturn(func(ctx context.Context, params db.UpdateProfileTxParams) (db.UpdateProfileTxResult, error) {
+						require.Len(t, params.OutboxMessages, 1)
+						require.Equal(t, event.OutboxSinkEvents, params.OutboxMessages[0].Sink)
+						require.Equal(t, event.TypeGamerTagChanged, params.OutboxMessages[0].Topic)
+
+						return db.UpdateProfileTxResult{
+							Profile: db.Profile{
+								WalletAddress: updateProfileReqParams.GetWalletAddress(),
+								GamerTag:      updateProfileReqParams.GetGamerTag(),
+							},
+							Changed: true,
+						}, nil
+					})
+
+				expectCachedProfileInvalidation(cache, updateProfileReqParams.GetWalletAddress())
+
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("mamabear", nil)
 			},
 			checkResponse: func(t *testing.T, res *pb.UpdateProfileResponse, err error) {
 				require.NoError(t, err)
@@ -80,6 +94,48 @@ func TestUpdateProfileAPI(t *testing.T) {
 				require.NotZero(t, res.GetProfile().GetCreatedAt())
 			},
 		},
+		{
+			name: "ens name cache error after commit",
+			req:  updateProfileReqParams,
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				authService.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&authPb.VerifyAccessTokenResponse{
+						Payload: &authPb.AccessTokenPayload{
+							Id:            "some-id",
+							WalletAddress: updateProfileReqParams.WalletAddress,
+							Role:          authPb.AccessTokenPayload_GAMER,
+						},
+					}, nil)
+
+				store.EXPECT().
+					UpdateProfileTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdateProfileTxResult{
+						Profile: db.Profile{
+							WalletAddress: updateProfileReqParams.GetWalletAddress(),
+							GamerTag:      updateProfileReqParams.GetGamerTag(),
+						},
+					}, nil)
+
+				expectCachedProfileInvalidation(cache, updateProfileReqParams.GetWalletAddress())
+
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some cache error"))
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateProfileResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, updateProfileReqParams.GetGamerTag(), res.GetProfile().GetGamerTag())
+				require.Empty(t, res.GetProfile().GetEnsName())
+				require.Equal(t, pb.EnsStatus_ENS_STATUS_UNSPECIFIED, res.GetProfile().GetEnsStatus())
+			},
+		},
 		{
 			name: "invalid request parameters",
 			req: &pb.UpdateProfileRequest{
@@ -87,7 +143,7 @@ func TestUpdateProfileAPI(t *testing.T) {
 				GamerTag:      updateProfileReqParams.GetGamerTag()[:2],
 			},
 			buildContext: func(t *testing.T) context.Context {
-				return nil
+				return context.Background()
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 			},
@@ -107,7 +163,7 @@ func TestUpdateProfileAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("some verify access token error"))
 			},
@@ -128,7 +184,7 @@ func TestUpdateProfileAPI(t *testing.T) {
 					Return(db.UpdateProfileTxResult{}, errors.New("some db error"))
 
 				authService.EXPECT().
-					VerifyAccessToken(gomock.Any(), gomock.Any()).
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&authPb.VerifyAccessTokenResponse{
 						Payload: &authPb.AccessTokenPayload{
@@ -148,6 +204,36 @@ func TestUpdateProfileAPI(t *testing.T) {
 				require.ErrorContains(t, err, handler.InternalServerError)
 			},
 		},
+		{
+			name: "profile does not exist",
+			req:  updateProfileReqParams,
+			buildStubs: func(store *mockdb.MockStore, cache *mockcache.MockCache, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					UpdateProfileTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdateProfileTxResult{}, db.RecordNotFoundError)
+
+				authService.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&authPb.VerifyAccessTokenResponse{
+						Payload: &authPb.AccessTokenPayload{
+							Id:            "some-id",
+							WalletAddress: updateProfileReqParams.WalletAddress,
+							Role:          authPb.AccessTokenPayload_GAMER,
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T) context.Context {
+				return handler.NewContextWithBearerToken()
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateProfileResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, DoesNotExist)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
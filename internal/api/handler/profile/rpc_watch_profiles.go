@@ -0,0 +1,94 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/event"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) WatchProfiles(req *pb.WatchProfilesRequest, stream pb.Profiles_WatchProfilesServer) error {
+	ctx := stream.Context()
+	logger := log.Ctx(ctx).With().Int("watched_profiles", len(req.GetWalletAddresses())).Logger()
+
+	violations := validateWatchProfilesRequest(req)
+	if violations != nil {
+		return handler.InvalidArgumentError(violations)
+	}
+
+	changes, err := h.profileWatcher.Watch(ctx, req.GetWalletAddresses())
+	if err != nil {
+		logger.Error().Err(err).Msg("could not watch user profiles")
+
+		switch {
+		case errors.Is(err, event.ErrTooManyProfileWatchers):
+			return status.Error(codes.ResourceExhausted, TooManyProfileWatchers)
+		case errors.Is(err, event.ErrProfileWatcherClosed):
+			return status.Error(codes.Unavailable, ProfileChangesUnavailable)
+		}
+
+		return status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-h.stopWatches.stopped:
+			logger.Info().Msg("stopped watching user profiles for shutdown")
+			return status.Error(codes.Unavailable, ProfileChangesUnavailable)
+		case change, ok := <-changes:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				logger.Error().Msg("lost profile changes subscription")
+				return status.Error(codes.Unavailable, ProfileChangesUnavailable)
+			}
+
+			err = stream.Send(&pb.WatchProfilesResponse{Change: change})
+			if err != nil {
+				logger.Error().Err(err).Msg("could not send profile change")
+				return err
+			}
+		}
+	}
+}
+
+// notifyProfileChange pushes a change to the streams watching the profile. Failures are logged
+// rather than returned since the change is already committed.
+func (h *Handler) notifyProfileChange(ctx context.Context, change *pb.ProfileChange) {
+	change.ChangedAt = timestamppb.Now()
+
+	err := h.profileWatcher.Notify(ctx, change)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Str("wallet_address", change.GetWalletAddress()).
+			Str("type", change.GetType().String()).
+			Msg("could not notify profile change")
+	}
+}
+
+func validateWatchProfilesRequest(req *pb.WatchProfilesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateWatchedProfilesCount(len(req.GetWalletAddresses())); err != nil {
+		violations = append(violations, handler.FieldViolation("wallet_addresses", err))
+	}
+
+	for i, walletAddress := range req.GetWalletAddresses() {
+		if err := validator.ValidateWalletAddress(walletAddress); err != nil {
+			violations = append(violations, handler.FieldViolation(fmt.Sprintf("wallet_addresses[%d]", i), err))
+		}
+	}
+
+	return violations
+}
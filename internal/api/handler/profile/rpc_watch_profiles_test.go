@@ -0,0 +1,241 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyamalabs/auth/pkg/util"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/event"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeWatchProfilesStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.WatchProfilesResponse
+	err  error
+}
+
+func (s *fakeWatchProfilesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchProfilesStream) Send(res *pb.WatchProfilesResponse) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.sent <- res
+	return nil
+}
+
+func generateWatchProfilesReqParams(t *testing.T) *pb.WatchProfilesRequest {
+	wallet, err := util.NewEthereumWallet()
+	require.NoError(t, err)
+	require.NotEmpty(t, wallet)
+
+	return &pb.WatchProfilesRequest{
+		WalletAddresses: []string{wallet.Address},
+	}
+}
+
+func TestWatchProfilesAPI(t *testing.T) {
+	watchProfilesReqParams := generateWatchProfilesReqParams(t)
+	require.NotEmpty(t, watchProfilesReqParams)
+
+	unwatchedWallet, err := util.NewEthereumWallet()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *pb.WatchProfilesRequest
+		sendErr       error
+		checkResponse func(t *testing.T, sent []*pb.WatchProfilesResponse, err error)
+	}{
+		{
+			name: "success",
+			req:  watchProfilesReqParams,
+			checkResponse: func(t *testing.T, sent []*pb.WatchProfilesResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, sent)
+
+				for _, res := range sent {
+					require.Equal(t, watchProfilesReqParams.GetWalletAddresses()[0], res.GetChange().GetWalletAddress())
+					require.Equal(t, pb.ProfileChangeType_PROFILE_CHANGE_TYPE_GAMER_TAG_CHANGED, res.GetChange().GetType())
+					require.Equal(t, "mamabear", res.GetChange().GetGamerTag())
+				}
+			},
+		},
+		{
+			name:    "send error",
+			req:     watchProfilesReqParams,
+			sendErr: errors.New("some stream error"),
+			checkResponse: func(t *testing.T, sent []*pb.WatchProfilesResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, sent)
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req: &pb.WatchProfilesRequest{
+				WalletAddresses: []string{"0x0000000000000000000000000000000000000000"},
+			},
+			checkResponse: func(t *testing.T, sent []*pb.WatchProfilesResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, sent)
+
+				expectedFieldViolations := []string{"wallet_addresses[0]"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "no wallet addresses",
+			req:  &pb.WatchProfilesRequest{},
+			checkResponse: func(t *testing.T, sent []*pb.WatchProfilesResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, sent)
+
+				expectedFieldViolations := []string{"wallet_addresses"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			watcher := event.NewMemoryProfileWatcher()
+
+			h := newTestHandler(nil, nil, nil, nil)
+			h.profileWatcher = watcher
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream := &fakeWatchProfilesStream{
+				ctx:  ctx,
+				sent: make(chan *pb.WatchProfilesResponse, 100),
+				err:  tc.sendErr,
+			}
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- h.WatchProfiles(tc.req, stream)
+			}()
+
+			// keep notifying until the stream has subscribed or returned
+			var err error
+			require.Eventually(t, func() bool {
+				for _, walletAddress := range []string{unwatchedWallet.Address, watchProfilesReqParams.GetWalletAddresses()[0]} {
+					_ = watcher.Notify(ctx, &pb.ProfileChange{
+						Type:          pb.ProfileChangeType_PROFILE_CHANGE_TYPE_GAMER_TAG_CHANGED,
+						WalletAddress: walletAddress,
+						GamerTag:      "mamabear",
+					})
+				}
+
+				select {
+				case err = <-errCh:
+					return true
+				default:
+					return len(stream.sent) > 0
+				}
+			}, time.Second, 10*time.Millisecond)
+
+			if len(stream.sent) > 0 {
+				cancel()
+				err = <-errCh
+			}
+
+			close(stream.sent)
+			var sent []*pb.WatchProfilesResponse
+			for res := range stream.sent {
+				sent = append(sent, res)
+			}
+
+			tc.checkResponse(t, sent, err)
+		})
+	}
+}
+
+func TestWatchProfilesAPI_StopWatches(t *testing.T) {
+	watchProfilesReqParams := generateWatchProfilesReqParams(t)
+
+	h := newTestHandler(nil, nil, nil, nil)
+
+	stream := &fakeWatchProfilesStream{
+		ctx:  context.Background(),
+		sent: make(chan *pb.WatchProfilesResponse, 1),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.WatchProfiles(watchProfilesReqParams, stream)
+	}()
+
+	h.StopWatches()
+
+	select {
+	case err := <-errCh:
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		require.ErrorContains(t, err, ProfileChangesUnavailable)
+	case <-time.After(time.Second):
+		require.Fail(t, "stream was not stopped")
+	}
+}
+
+func TestWatchProfilesAPI_WatcherErrors(t *testing.T) {
+	watchProfilesReqParams := generateWatchProfilesReqParams(t)
+
+	testCases := []struct {
+		name         string
+		watchErr     error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "too many watchers",
+			watchErr:     event.ErrTooManyProfileWatchers,
+			expectedCode: codes.ResourceExhausted,
+		},
+		{
+			name:         "watcher closed",
+			watchErr:     event.ErrProfileWatcherClosed,
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name:         "subscription error",
+			watchErr:     errors.New("some subscription error"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newTestHandler(nil, nil, nil, nil)
+			h.profileWatcher = failingProfileWatcher{err: tc.watchErr}
+
+			stream := &fakeWatchProfilesStream{
+				ctx:  context.Background(),
+				sent: make(chan *pb.WatchProfilesResponse, 1),
+			}
+
+			err := h.WatchProfiles(watchProfilesReqParams, stream)
+			require.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+type failingProfileWatcher struct {
+	event.ProfileWatcher
+	err error
+}
+
+func (w failingProfileWatcher) Watch(_ context.Context, _ []string) (<-chan *pb.ProfileChange, error) {
+	return nil, w.err
+}
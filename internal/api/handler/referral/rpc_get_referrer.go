@@ -15,7 +15,7 @@ import (
 )
 
 func (h *Handler) GetReferrer(ctx context.Context, req *pb.GetReferrerRequest) (*pb.GetReferrerResponse, error) {
-	logger := log.With().Str("wallet_address", req.GetWalletAddress()).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
 
 	violations := validateGetReferrerRequest(req)
 	if violations != nil {
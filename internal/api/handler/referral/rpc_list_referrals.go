@@ -19,7 +19,7 @@ const (
 )
 
 func (h *Handler) ListReferrals(ctx context.Context, req *pb.ListReferralsRequest) (*pb.ListReferralsResponse, error) {
-	logger := log.With().Str("wallet_address", req.GetWalletAddress()).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
 
 	violations := validateGetReferralsRequest(req)
 	if violations != nil {
@@ -52,7 +52,7 @@ func (h *Handler) ListReferrals(ctx context.Context, req *pb.ListReferralsReques
 
 	totalReferralsCount, err := h.store.GetReferralsCount(ctx, req.GetWalletAddress())
 	if err != nil {
-		log.Error().Err(err).Msg("could not get total referrals count")
+		log.Ctx(ctx).Error().Err(err).Msg("could not get total referrals count")
 		return nil, status.Error(codes.Internal, handler.InternalServerError)
 	}
 
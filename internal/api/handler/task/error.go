@@ -0,0 +1,6 @@
+package task
+
+const (
+	TaskDoesNotExist                 string = "Task does not exist or has not failed"
+	AccessTokenRevocationUnsupported string = "Access tokens cannot be revoked with the configured access token verification"
+)
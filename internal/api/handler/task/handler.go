@@ -0,0 +1,23 @@
+package task
+
+import (
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/services"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/kyamalabs/users/internal/worker"
+)
+
+type Handler struct {
+	pb.UnimplementedTasksServer
+	config      util.Config
+	inspector   worker.DeadLetterInspector
+	authService services.AuthGrpcService
+}
+
+func NewHandler(config util.Config, inspector worker.DeadLetterInspector, authService services.AuthGrpcService) Handler {
+	return Handler{
+		config:      config,
+		inspector:   inspector,
+		authService: authService,
+	}
+}
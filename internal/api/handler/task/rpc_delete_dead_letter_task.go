@@ -0,0 +1,44 @@
+package task
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	"github.com/kyamalabs/users/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *Handler) DeleteDeadLetterTask(ctx context.Context, req *pb.DeleteDeadLetterTaskRequest) (*emptypb.Empty, error) {
+	logger := log.Ctx(ctx).With().Str("queue", req.GetQueue()).Str("task_id", req.GetId()).Logger()
+
+	violations := validateTaskLocator(req.GetQueue(), req.GetId())
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	err = h.inspector.DeleteDeadLetterTask(req.GetQueue(), req.GetId())
+	if err != nil {
+		if errors.Is(err, worker.ErrDeadLetterTaskNotFound) {
+			return nil, status.Error(codes.NotFound, TaskDoesNotExist)
+		}
+
+		logger.Error().Err(err).Msg("could not delete dead letter task")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	logger.Info().Msg("dead letter task deleted successfully")
+
+	return &emptypb.Empty{}, nil
+}
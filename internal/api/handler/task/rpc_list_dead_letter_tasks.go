@@ -0,0 +1,87 @@
+package task
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize int32 = 30
+)
+
+func (h *Handler) ListDeadLetterTasks(ctx context.Context, req *pb.ListDeadLetterTasksRequest) (*pb.ListDeadLetterTasksResponse, error) {
+	state := deadLetterTaskStateFromPb(req.GetState())
+	logger := log.Ctx(ctx).With().Str("queue", req.GetQueue()).Str("type", req.GetType()).Str("state", state.String()).Logger()
+
+	violations := validateListDeadLetterTasksRequest(req)
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	page := req.GetPage()
+	if page <= 0 {
+		page = 1
+	}
+
+	limit := req.GetPageSize()
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	tasks, totalTasksCount, err := h.inspector.ListDeadLetterTasks(req.GetQueue(), req.GetType(), state, int(page), int(limit))
+	if err != nil {
+		logger.Error().Err(err).Msg("could not list dead letter tasks")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	var pbTasks []*pb.DeadLetterTask
+	for _, task := range tasks {
+		pbTasks = append(pbTasks, deadLetterTaskToPb(task))
+	}
+
+	response := &pb.ListDeadLetterTasksResponse{
+		Page:       page,
+		PageSize:   limit,
+		TotalTasks: int32(totalTasksCount),
+		Tasks:      pbTasks,
+	}
+
+	return response, nil
+}
+
+func validateListDeadLetterTasksRequest(req *pb.ListDeadLetterTasksRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateQueue(req.GetQueue()); err != nil {
+		violations = append(violations, handler.FieldViolation("queue", err))
+	}
+
+	if req.GetType() != "" {
+		if err := validateTaskType(req.GetType()); err != nil {
+			violations = append(violations, handler.FieldViolation("type", err))
+		}
+	}
+
+	if _, ok := pb.DeadLetterTaskState_name[int32(req.GetState())]; !ok {
+		violations = append(violations, handler.FieldViolation("state", errors.New("not a valid task state")))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, handler.FieldViolation("page_size", err))
+	}
+
+	return violations
+}
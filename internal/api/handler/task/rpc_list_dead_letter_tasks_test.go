@@ -0,0 +1,172 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/kyamalabs/users/internal/worker"
+	mockwk "github.com/kyamalabs/users/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomDeadLetterTask(state asynq.TaskState) *asynq.TaskInfo {
+	return &asynq.TaskInfo{
+		ID:            "some-task-id",
+		Queue:         worker.QueueDefault,
+		Type:          worker.TaskCacheENSName,
+		Payload:       []byte(`{"wallet_address":"0xc0ffee254729296a45a3885639AC7E10F9d54979"}`),
+		State:         state,
+		MaxRetry:      10,
+		Retried:       10,
+		LastErr:       "some rpc error",
+		LastFailedAt:  time.Now().Add(-time.Minute),
+		NextProcessAt: time.Now().Add(time.Minute),
+	}
+}
+
+func TestListDeadLetterTasksAPI(t *testing.T) {
+	archivedTask := randomDeadLetterTask(asynq.TaskStateArchived)
+	retryTask := randomDeadLetterTask(asynq.TaskStateRetry)
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListDeadLetterTasksRequest
+		buildStubs    func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService)
+		checkResponse func(t *testing.T, res *pb.ListDeadLetterTasksResponse, err error)
+	}{
+		{
+			name: "success",
+			req:  &pb.ListDeadLetterTasksRequest{Queue: worker.QueueDefault, Type: worker.TaskCacheENSName},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				inspector.EXPECT().
+					ListDeadLetterTasks(worker.QueueDefault, worker.TaskCacheENSName, asynq.TaskStateArchived, 1, int(defaultPageSize)).
+					Times(1).
+					Return([]*asynq.TaskInfo{archivedTask}, 1, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListDeadLetterTasksResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(1), res.GetPage())
+				require.Equal(t, defaultPageSize, res.GetPageSize())
+				require.Equal(t, int32(1), res.GetTotalTasks())
+				require.Len(t, res.GetTasks(), 1)
+
+				task := res.GetTasks()[0]
+				require.Equal(t, archivedTask.ID, task.GetId())
+				require.Equal(t, archivedTask.Queue, task.GetQueue())
+				require.Equal(t, archivedTask.Type, task.GetType())
+				require.Equal(t, string(archivedTask.Payload), task.GetPayload())
+				require.Equal(t, pb.DeadLetterTaskState_DEAD_LETTER_TASK_STATE_ARCHIVED, task.GetState())
+				require.Equal(t, archivedTask.LastErr, task.GetLastError())
+				require.WithinDuration(t, archivedTask.LastFailedAt, task.GetLastFailedAt().AsTime(), time.Second)
+				require.Nil(t, task.GetNextProcessAt())
+			},
+		},
+		{
+			name: "success - tasks waiting to be retried",
+			req: &pb.ListDeadLetterTasksRequest{
+				Queue:    worker.QueueCritical,
+				State:    pb.DeadLetterTaskState_DEAD_LETTER_TASK_STATE_RETRY,
+				Page:     2,
+				PageSize: 10,
+			},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				inspector.EXPECT().
+					ListDeadLetterTasks(worker.QueueCritical, "", asynq.TaskStateRetry, 2, 10).
+					Times(1).
+					Return([]*asynq.TaskInfo{retryTask}, 11, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListDeadLetterTasksResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(2), res.GetPage())
+				require.Equal(t, int32(10), res.GetPageSize())
+				require.Equal(t, int32(11), res.GetTotalTasks())
+				require.Len(t, res.GetTasks(), 1)
+				require.Equal(t, pb.DeadLetterTaskState_DEAD_LETTER_TASK_STATE_RETRY, res.GetTasks()[0].GetState())
+				require.NotNil(t, res.GetTasks()[0].GetNextProcessAt())
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req: &pb.ListDeadLetterTasksRequest{
+				Queue:    "unknown",
+				Type:     "task:unknown",
+				State:    pb.DeadLetterTaskState(42),
+				PageSize: 1000,
+			},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				inspector.EXPECT().
+					ListDeadLetterTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListDeadLetterTasksResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"queue", "type", "state", "page_size"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "not an admin",
+			req:  &pb.ListDeadLetterTasksRequest{Queue: worker.QueueDefault},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+
+				inspector.EXPECT().
+					ListDeadLetterTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListDeadLetterTasksResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "inspector error",
+			req:  &pb.ListDeadLetterTasksRequest{Queue: worker.QueueDefault},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				inspector.EXPECT().
+					ListDeadLetterTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, 0, errors.New("some redis error"))
+			},
+			checkResponse: func(t *testing.T, res *pb.ListDeadLetterTasksResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inspector := mockwk.NewMockDeadLetterInspector(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			tc.buildStubs(inspector, authService)
+
+			h := newTestHandler(inspector, authService)
+			res, err := h.ListDeadLetterTasks(newAdminContext(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
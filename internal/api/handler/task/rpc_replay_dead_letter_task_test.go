@@ -0,0 +1,133 @@
+package task
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/kyamalabs/users/internal/worker"
+	mockwk "github.com/kyamalabs/users/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestReplayDeadLetterTaskAPI(t *testing.T) {
+	task := randomDeadLetterTask(asynq.TaskStateArchived)
+
+	testCases := []struct {
+		name          string
+		req           *pb.ReplayDeadLetterTaskRequest
+		buildStubs    func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService)
+		checkResponse func(t *testing.T, res *emptypb.Empty, err error)
+	}{
+		{
+			name: "success",
+			req:  &pb.ReplayDeadLetterTaskRequest{Queue: task.Queue, Id: task.ID},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				inspector.EXPECT().
+					ReplayDeadLetterTask(task.Queue, task.ID).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &emptypb.Empty{}, res)
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req:  &pb.ReplayDeadLetterTaskRequest{Queue: "unknown"},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				inspector.EXPECT().
+					ReplayDeadLetterTask(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"queue", "id"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "not an admin",
+			req:  &pb.ReplayDeadLetterTaskRequest{Queue: task.Queue, Id: task.ID},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+
+				inspector.EXPECT().
+					ReplayDeadLetterTask(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "task not found",
+			req:  &pb.ReplayDeadLetterTaskRequest{Queue: task.Queue, Id: task.ID},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				inspector.EXPECT().
+					ReplayDeadLetterTask(task.Queue, task.ID).
+					Times(1).
+					Return(worker.ErrDeadLetterTaskNotFound)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.Equal(t, codes.NotFound, status.Code(err))
+				require.ErrorContains(t, err, TaskDoesNotExist)
+			},
+		},
+		{
+			name: "inspector error",
+			req:  &pb.ReplayDeadLetterTaskRequest{Queue: task.Queue, Id: task.ID},
+			buildStubs: func(inspector *mockwk.MockDeadLetterInspector, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				inspector.EXPECT().
+					ReplayDeadLetterTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some redis error"))
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inspector := mockwk.NewMockDeadLetterInspector(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			tc.buildStubs(inspector, authService)
+
+			h := newTestHandler(inspector, authService)
+			res, err := h.ReplayDeadLetterTask(newAdminContext(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package task
+
+import (
+	"context"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	"github.com/kyamalabs/users/internal/services"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RevokeAccessTokens is called when the auth service revokes the access tokens of a wallet address,
+// so their verifications stop being accepted right away. Verifiers that cannot revoke tokens, such
+// as the auth service itself, make the call fail rather than pretend the tokens were revoked.
+func (h *Handler) RevokeAccessTokens(ctx context.Context, req *pb.RevokeAccessTokensRequest) (*emptypb.Empty, error) {
+	logger := log.Ctx(ctx).With().Str("wallet_address", req.GetWalletAddress()).Logger()
+
+	violations := validateRevokeAccessTokensRequest(req)
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	revoker, ok := h.authService.(services.AccessTokenRevoker)
+	if !ok {
+		logger.Error().Msg("access token verifier cannot revoke access tokens")
+		return nil, status.Error(codes.FailedPrecondition, AccessTokenRevocationUnsupported)
+	}
+
+	err = revoker.RevokeAccessTokens(ctx, req.GetWalletAddress())
+	if err != nil {
+		logger.Error().Err(err).Msg("could not revoke access tokens")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	logger.Info().Msg("access tokens revoked successfully")
+
+	return &emptypb.Empty{}, nil
+}
+
+func validateRevokeAccessTokensRequest(req *pb.RevokeAccessTokensRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateWalletAddress(req.GetWalletAddress()); err != nil {
+		violations = append(violations, handler.FieldViolation("wallet_address", err))
+	}
+
+	return violations
+}
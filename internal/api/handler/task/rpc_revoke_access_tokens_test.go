@@ -0,0 +1,143 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/services"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// revokingAuthService stands in for an access token verifier that can revoke tokens.
+type revokingAuthService struct {
+	services.AuthGrpcService
+	revoked []string
+	err     error
+}
+
+func (s *revokingAuthService) RevokeAccessTokens(_ context.Context, walletAddress string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.revoked = append(s.revoked, walletAddress)
+
+	return nil
+}
+
+func TestRevokeAccessTokensAPI(t *testing.T) {
+	walletAddress := "0x0000000000000000000000000000000000000001"
+
+	testCases := []struct {
+		name          string
+		req           *pb.RevokeAccessTokensRequest
+		revocable     bool
+		revokeErr     error
+		buildStubs    func(authService *mockservices.MockAuthGrpcService)
+		checkResponse func(t *testing.T, res *emptypb.Empty, revoked []string, err error)
+	}{
+		{
+			name:      "success",
+			req:       &pb.RevokeAccessTokensRequest{WalletAddress: walletAddress},
+			revocable: true,
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, revoked []string, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, []string{walletAddress}, revoked)
+			},
+		},
+		{
+			name: "verifier cannot revoke",
+			req:  &pb.RevokeAccessTokensRequest{WalletAddress: walletAddress},
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, revoked []string, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+				require.ErrorContains(t, err, AccessTokenRevocationUnsupported)
+			},
+		},
+		{
+			name:      "invalid request arguments",
+			req:       &pb.RevokeAccessTokensRequest{WalletAddress: "invalid-wallet-address"},
+			revocable: true,
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				authService.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, revoked []string, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+				require.Empty(t, revoked)
+
+				handler.CheckInvalidRequestParams(t, err, []string{"wallet_address"})
+			},
+		},
+		{
+			name:      "not an admin",
+			req:       &pb.RevokeAccessTokensRequest{WalletAddress: walletAddress},
+			revocable: true,
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, revoked []string, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+				require.Empty(t, revoked)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name:      "revocation error",
+			req:       &pb.RevokeAccessTokensRequest{WalletAddress: walletAddress},
+			revocable: true,
+			revokeErr: errors.New("some cache error"),
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, revoked []string, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+			tc.buildStubs(authService)
+
+			h := newTestHandler(nil, authService)
+
+			revoker := &revokingAuthService{AuthGrpcService: authService, err: tc.revokeErr}
+			if tc.revocable {
+				h = newTestHandler(nil, revoker)
+			}
+
+			res, err := h.RevokeAccessTokens(newAdminContext(), tc.req)
+			tc.checkResponse(t, res, revoker.revoked, err)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"errors"
+
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func deadLetterTaskToPb(task *asynq.TaskInfo) *pb.DeadLetterTask {
+	pbTask := &pb.DeadLetterTask{
+		Id:        task.ID,
+		Queue:     task.Queue,
+		Type:      task.Type,
+		Payload:   string(task.Payload),
+		State:     deadLetterTaskStateToPb(task.State),
+		MaxRetry:  int32(task.MaxRetry),
+		Retried:   int32(task.Retried),
+		LastError: task.LastErr,
+	}
+
+	if !task.LastFailedAt.IsZero() {
+		pbTask.LastFailedAt = timestamppb.New(task.LastFailedAt)
+	}
+
+	// archived tasks are not processed again unless replayed
+	if task.State == asynq.TaskStateRetry && !task.NextProcessAt.IsZero() {
+		pbTask.NextProcessAt = timestamppb.New(task.NextProcessAt)
+	}
+
+	return pbTask
+}
+
+func deadLetterTaskStateToPb(state asynq.TaskState) pb.DeadLetterTaskState {
+	switch state {
+	case asynq.TaskStateArchived:
+		return pb.DeadLetterTaskState_DEAD_LETTER_TASK_STATE_ARCHIVED
+	case asynq.TaskStateRetry:
+		return pb.DeadLetterTaskState_DEAD_LETTER_TASK_STATE_RETRY
+	default:
+		return pb.DeadLetterTaskState_DEAD_LETTER_TASK_STATE_UNSPECIFIED
+	}
+}
+
+func deadLetterTaskStateFromPb(state pb.DeadLetterTaskState) asynq.TaskState {
+	if state == pb.DeadLetterTaskState_DEAD_LETTER_TASK_STATE_RETRY {
+		return asynq.TaskStateRetry
+	}
+
+	return asynq.TaskStateArchived
+}
+
+func validateTaskType(taskType string) error {
+	if !worker.IsRegisteredTaskType(taskType) {
+		return errors.New("not a registered task type")
+	}
+
+	return nil
+}
+
+func validateQueue(queue string) error {
+	if !worker.IsKnownQueue(queue) {
+		return errors.New("not a known queue")
+	}
+
+	return nil
+}
+
+func validateTaskID(id string) error {
+	if id == "" {
+		return errors.New("must not be empty")
+	}
+
+	return nil
+}
+
+// validateTaskLocator checks the queue and id every single task request carries.
+func validateTaskLocator(queue string, id string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateQueue(queue); err != nil {
+		violations = append(violations, handler.FieldViolation("queue", err))
+	}
+
+	if err := validateTaskID(id); err != nil {
+		violations = append(violations, handler.FieldViolation("id", err))
+	}
+
+	return violations
+}
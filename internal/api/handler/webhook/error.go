@@ -0,0 +1,5 @@
+package webhook
+
+const (
+	DeliveryDoesNotExist string = "Webhook delivery does not exist"
+)
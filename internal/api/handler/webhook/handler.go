@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"github.com/kyamalabs/users/api/pb"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/services"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/kyamalabs/users/internal/worker"
+)
+
+type Handler struct {
+	pb.UnimplementedWebhooksServer
+	config          util.Config
+	store           db.Store
+	taskDistributor worker.TaskDistributor
+	authService     services.AuthGrpcService
+}
+
+func NewHandler(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, authService services.AuthGrpcService) Handler {
+	return Handler{
+		config:          config,
+		store:           store,
+		taskDistributor: taskDistributor,
+		authService:     authService,
+	}
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/internal/constants"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/services"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/kyamalabs/users/internal/worker"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+)
+
+const adminWalletAddress = "0xc0ffee254729296a45a3885639AC7E10F9d54979"
+
+func newTestHandler(store db.Store, authService services.AuthGrpcService, taskDistributor worker.TaskDistributor) Handler {
+	config := util.Config{
+		WebhookMaxRetry: 8,
+	}
+
+	return NewHandler(config, store, taskDistributor, authService)
+}
+
+func newAdminContext() context.Context {
+	md := metadata.MD{
+		constants.AuthorizationHeader: []string{
+			fmt.Sprintf("%s %s", constants.AuthorizationBearer, "some-token"),
+		},
+		constants.XWalletAddressHeader: []string{adminWalletAddress},
+	}
+
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func expectAdminAuthorization(authService *mockservices.MockAuthGrpcService, role authPb.AccessTokenPayload_Role) {
+	authService.EXPECT().
+		VerifyAccessToken(gomock.Any(), &authPb.VerifyAccessTokenRequest{WalletAddress: adminWalletAddress}, "some-token").
+		Times(1).
+		Return(&authPb.VerifyAccessTokenResponse{
+			Payload: &authPb.AccessTokenPayload{
+				Id:            "some-id",
+				WalletAddress: adminWalletAddress,
+				Role:          role,
+			},
+		}, nil)
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.CreateWebhookResponse, error) {
+	logger := log.Ctx(ctx).With().Str("url", req.GetUrl()).Logger()
+
+	violations := validateCreateWebhookRequest(req)
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	// the host is only resolved for admins, so that anyone else cannot make the service look up
+	// arbitrary hosts
+	if err = validateWebhookHost(ctx, req.GetUrl()); err != nil {
+		logger.Error().Err(err).Msg("webhook url points to a disallowed host")
+		return nil, handler.InvalidArgumentError([]*errdetails.BadRequest_FieldViolation{handler.FieldViolation("url", err)})
+	}
+
+	webhook, err := h.store.CreateWebhook(ctx, db.CreateWebhookParams{
+		Url:        req.GetUrl(),
+		Secret:     req.GetSecret(),
+		EventTypes: req.GetEventTypes(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("could not create webhook")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	logger.Info().Str("webhook_id", webhook.ID.String()).Msg("webhook created successfully")
+
+	return &pb.CreateWebhookResponse{Webhook: webhookToPb(webhook)}, nil
+}
+
+func validateCreateWebhookRequest(req *pb.CreateWebhookRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateWebhookURL(req.GetUrl()); err != nil {
+		violations = append(violations, handler.FieldViolation("url", err))
+	}
+
+	if err := validator.ValidateWebhookSecret(req.GetSecret()); err != nil {
+		violations = append(violations, handler.FieldViolation("secret", err))
+	}
+
+	if len(req.GetEventTypes()) == 0 {
+		violations = append(violations, handler.FieldViolation("event_types", errors.New("must not be empty")))
+	}
+
+	for i, eventType := range req.GetEventTypes() {
+		if !event.IsKnownType(eventType) {
+			violations = append(violations, handler.FieldViolation(fmt.Sprintf("event_types[%d]", i), fmt.Errorf("unknown event type: %s", eventType)))
+		}
+	}
+
+	return violations
+}
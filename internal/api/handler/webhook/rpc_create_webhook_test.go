@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookAPI(t *testing.T) {
+	req := &pb.CreateWebhookRequest{
+		Url:        "https://93.184.216.34/hooks/users",
+		Secret:     "some-webhook-secret",
+		EventTypes: []string{event.TypeReferralRecorded},
+	}
+
+	webhook := db.Webhook{
+		ID:         uuid.New(),
+		Url:        req.GetUrl(),
+		Secret:     req.GetSecret(),
+		EventTypes: req.GetEventTypes(),
+		CreatedAt:  time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateWebhookRequest
+		buildStubs    func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService)
+		checkResponse func(t *testing.T, res *pb.CreateWebhookResponse, err error)
+	}{
+		{
+			name: "success",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					CreateWebhook(gomock.Any(), db.CreateWebhookParams{
+						Url:        req.GetUrl(),
+						Secret:     req.GetSecret(),
+						EventTypes: req.GetEventTypes(),
+					}).
+					Times(1).
+					Return(webhook, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, webhook.ID.String(), res.GetWebhook().GetId())
+				require.Equal(t, webhook.Url, res.GetWebhook().GetUrl())
+				require.Equal(t, webhook.EventTypes, res.GetWebhook().GetEventTypes())
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req: &pb.CreateWebhookRequest{
+				Url:        "ftp://tournaments.example.com",
+				Secret:     "too-short",
+				EventTypes: []string{event.TypeProfileCreated, "profile.unknown"},
+			},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"url", "secret", "event_types[1]"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "no event types",
+			req: &pb.CreateWebhookRequest{
+				Url:    req.GetUrl(),
+				Secret: req.GetSecret(),
+			},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"event_types"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "not an admin",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+
+				store.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "reserved host address",
+			req: &pb.CreateWebhookRequest{
+				Url:        "https://100.64.0.1/hooks/users",
+				Secret:     req.GetSecret(),
+				EventTypes: req.GetEventTypes(),
+			},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				handler.CheckInvalidRequestParams(t, err, []string{"url"})
+			},
+		},
+		{
+			name: "unresolvable host",
+			req: &pb.CreateWebhookRequest{
+				Url:        "https://hooks.invalid/users",
+				Secret:     req.GetSecret(),
+				EventTypes: req.GetEventTypes(),
+			},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				handler.CheckInvalidRequestParams(t, err, []string{"url"})
+			},
+		},
+		{
+			name: "db error",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Webhook{}, errors.New("some db error"))
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			tc.buildStubs(store, authService)
+
+			h := newTestHandler(store, authService, nil)
+			res, err := h.CreateWebhook(newAdminContext(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
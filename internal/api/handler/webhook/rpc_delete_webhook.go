@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *Handler) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	logger := log.Ctx(ctx).With().Str("webhook_id", req.GetId()).Logger()
+
+	violations := validateDeleteWebhookRequest(req)
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	err = h.store.DeleteWebhook(ctx, uuid.MustParse(req.GetId()))
+	if err != nil {
+		logger.Error().Err(err).Msg("could not delete webhook")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	logger.Info().Msg("webhook deleted successfully")
+
+	return &emptypb.Empty{}, nil
+}
+
+func validateDeleteWebhookRequest(req *pb.DeleteWebhookRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUUID(req.GetId()); err != nil {
+		violations = append(violations, handler.FieldViolation("id", err))
+	}
+
+	return violations
+}
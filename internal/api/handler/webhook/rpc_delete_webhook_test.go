@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestDeleteWebhookAPI(t *testing.T) {
+	webhookID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		req           *pb.DeleteWebhookRequest
+		buildStubs    func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService)
+		checkResponse func(t *testing.T, res *emptypb.Empty, err error)
+	}{
+		{
+			name: "success",
+			req:  &pb.DeleteWebhookRequest{Id: webhookID.String()},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					DeleteWebhook(gomock.Any(), webhookID).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &emptypb.Empty{}, res)
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req:  &pb.DeleteWebhookRequest{Id: "not-a-uuid"},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"id"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "not an admin",
+			req:  &pb.DeleteWebhookRequest{Id: webhookID.String()},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+
+				store.EXPECT().
+					DeleteWebhook(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "db error",
+			req:  &pb.DeleteWebhookRequest{Id: webhookID.String()},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					DeleteWebhook(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some db error"))
+			},
+			checkResponse: func(t *testing.T, res *emptypb.Empty, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			tc.buildStubs(store, authService)
+
+			h := newTestHandler(store, authService, nil)
+			res, err := h.DeleteWebhook(newAdminContext(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize int32 = 30
+)
+
+func (h *Handler) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	logger := log.Ctx(ctx).With().Str("webhook_id", req.GetWebhookId()).Logger()
+
+	violations := validateListWebhookDeliveriesRequest(req)
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	page := req.GetPage()
+	if page <= 0 {
+		page = 1
+	}
+
+	limit := req.GetPageSize()
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	offset := (page - 1) * limit
+	webhookID := uuid.MustParse(req.GetWebhookId())
+
+	deliveries, err := h.store.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		WebhookID: webhookID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("could not list webhook deliveries")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	totalDeliveriesCount, err := h.store.GetWebhookDeliveriesCount(ctx, webhookID)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not get total webhook deliveries count")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	var pbDeliveries []*pb.WebhookDelivery
+	for _, delivery := range deliveries {
+		pbDeliveries = append(pbDeliveries, webhookDeliveryToPb(delivery))
+	}
+
+	response := &pb.ListWebhookDeliveriesResponse{
+		Page:            page,
+		PageSize:        limit,
+		TotalDeliveries: int32(totalDeliveriesCount),
+		Deliveries:      pbDeliveries,
+	}
+
+	return response, nil
+}
+
+func validateListWebhookDeliveriesRequest(req *pb.ListWebhookDeliveriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUUID(req.GetWebhookId()); err != nil {
+		violations = append(violations, handler.FieldViolation("webhook_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, handler.FieldViolation("page_size", err))
+	}
+
+	return violations
+}
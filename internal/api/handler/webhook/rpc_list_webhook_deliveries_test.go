@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/kyamalabs/users/internal/worker"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhookDeliveriesAPI(t *testing.T) {
+	webhookID := uuid.New()
+
+	deliveries := []db.WebhookDelivery{
+		{
+			ID:             uuid.New(),
+			WebhookID:      webhookID,
+			EventID:        uuid.NewString(),
+			EventType:      event.TypeReferralRecorded,
+			Status:         worker.WebhookDeliveryStatusSucceeded,
+			Attempts:       1,
+			ResponseStatus: pgtype.Int4{Int32: 200, Valid: true},
+			CreatedAt:      time.Now(),
+			DeliveredAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		},
+		{
+			ID:             uuid.New(),
+			WebhookID:      webhookID,
+			EventID:        uuid.NewString(),
+			EventType:      event.TypeReferralRecorded,
+			Status:         worker.WebhookDeliveryStatusFailed,
+			Attempts:       9,
+			ResponseStatus: pgtype.Int4{Int32: 503, Valid: true},
+			LastError:      pgtype.Text{String: "webhook endpoint responded with status 503", Valid: true},
+			CreatedAt:      time.Now(),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListWebhookDeliveriesRequest
+		buildStubs    func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService)
+		checkResponse func(t *testing.T, res *pb.ListWebhookDeliveriesResponse, err error)
+	}{
+		{
+			name: "success",
+			req:  &pb.ListWebhookDeliveriesRequest{WebhookId: webhookID.String()},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					ListWebhookDeliveries(gomock.Any(), db.ListWebhookDeliveriesParams{
+						WebhookID: webhookID,
+						Limit:     defaultPageSize,
+						Offset:    0,
+					}).
+					Times(1).
+					Return(deliveries, nil)
+
+				store.EXPECT().
+					GetWebhookDeliveriesCount(gomock.Any(), webhookID).
+					Times(1).
+					Return(int64(len(deliveries)), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListWebhookDeliveriesResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(1), res.GetPage())
+				require.Equal(t, defaultPageSize, res.GetPageSize())
+				require.Equal(t, int32(len(deliveries)), res.GetTotalDeliveries())
+				require.Len(t, res.GetDeliveries(), len(deliveries))
+
+				succeeded := res.GetDeliveries()[0]
+				require.Equal(t, pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED, succeeded.GetStatus())
+				require.Equal(t, int32(200), succeeded.GetResponseStatus())
+				require.NotNil(t, succeeded.GetDeliveredAt())
+
+				failed := res.GetDeliveries()[1]
+				require.Equal(t, pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED, failed.GetStatus())
+				require.Equal(t, deliveries[1].LastError.String, failed.GetLastError())
+				require.Nil(t, failed.GetDeliveredAt())
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req: &pb.ListWebhookDeliveriesRequest{
+				WebhookId: "not-a-uuid",
+				PageSize:  51,
+			},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+			},
+			checkResponse: func(t *testing.T, res *pb.ListWebhookDeliveriesResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"webhook_id", "page_size"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "not an admin",
+			req:  &pb.ListWebhookDeliveriesRequest{WebhookId: webhookID.String()},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+
+				store.EXPECT().
+					ListWebhookDeliveries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListWebhookDeliveriesResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "db error",
+			req:  &pb.ListWebhookDeliveriesRequest{WebhookId: webhookID.String()},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					ListWebhookDeliveries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some db error"))
+			},
+			checkResponse: func(t *testing.T, res *pb.ListWebhookDeliveriesResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			tc.buildStubs(store, authService)
+
+			h := newTestHandler(store, authService, nil)
+			res, err := h.ListWebhookDeliveries(newAdminContext(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
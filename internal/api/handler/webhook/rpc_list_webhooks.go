@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListWebhooks(ctx context.Context, _ *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	webhooks, err := h.store.ListWebhooks(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("could not list webhooks")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	var pbWebhooks []*pb.Webhook
+	for _, webhook := range webhooks {
+		pbWebhooks = append(pbWebhooks, webhookToPb(webhook))
+	}
+
+	return &pb.ListWebhooksResponse{Webhooks: pbWebhooks}, nil
+}
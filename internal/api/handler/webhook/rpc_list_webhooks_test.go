@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhooksAPI(t *testing.T) {
+	webhooks := []db.Webhook{
+		{
+			ID:         uuid.New(),
+			Url:        "https://93.184.216.34/hooks/users",
+			Secret:     "some-webhook-secret",
+			EventTypes: []string{event.TypeReferralRecorded},
+			CreatedAt:  time.Now(),
+		},
+		{
+			ID:         uuid.New(),
+			Url:        "https://93.184.216.35/hooks/users",
+			Secret:     "another-webhook-secret",
+			EventTypes: []string{event.TypeProfileCreated, event.TypeGamerTagChanged},
+			CreatedAt:  time.Now(),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService)
+		checkResponse func(t *testing.T, res *pb.ListWebhooksResponse, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					ListWebhooks(gomock.Any()).
+					Times(1).
+					Return(webhooks, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListWebhooksResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetWebhooks(), len(webhooks))
+
+				for i, webhook := range res.GetWebhooks() {
+					require.Equal(t, webhooks[i].ID.String(), webhook.GetId())
+					require.Equal(t, webhooks[i].Url, webhook.GetUrl())
+					require.Equal(t, webhooks[i].EventTypes, webhook.GetEventTypes())
+				}
+			},
+		},
+		{
+			name: "not an admin",
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+
+				store.EXPECT().
+					ListWebhooks(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListWebhooksResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "db error",
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					ListWebhooks(gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some db error"))
+			},
+			checkResponse: func(t *testing.T, res *pb.ListWebhooksResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			tc.buildStubs(store, authService)
+
+			h := newTestHandler(store, authService, nil)
+			res, err := h.ListWebhooks(newAdminContext(), &pb.ListWebhooksRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
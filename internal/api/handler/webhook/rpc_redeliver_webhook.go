@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	"github.com/kyamalabs/users/internal/api/middleware"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/kyamalabs/users/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.RedeliverWebhookResponse, error) {
+	logger := log.Ctx(ctx).With().Str("delivery_id", req.GetDeliveryId()).Logger()
+
+	violations := validateRedeliverWebhookRequest(req)
+	if violations != nil {
+		return nil, handler.InvalidArgumentError(violations)
+	}
+
+	_, err := middleware.AuthorizeAdmin(ctx, h.authService)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not authorize admin")
+		return nil, handler.AuthorizationError(err)
+	}
+
+	delivery, err := h.store.ResetWebhookDelivery(ctx, uuid.MustParse(req.GetDeliveryId()))
+	if err != nil {
+		if errors.Is(err, db.RecordNotFoundError) {
+			return nil, status.Error(codes.NotFound, DeliveryDoesNotExist)
+		}
+
+		logger.Error().Err(err).Msg("could not reset webhook delivery")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	// redeliveries are not given a task id since the original delivery task may still be retained
+	err = worker.DeliverWebhookTask.Enqueue(
+		ctx,
+		h.taskDistributor,
+		&worker.PayloadDeliverWebhook{DeliveryID: delivery.ID},
+		asynq.Queue(worker.QueueCritical),
+		asynq.MaxRetry(h.config.WebhookMaxRetry),
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not enqueue webhook redelivery")
+		return nil, status.Error(codes.Internal, handler.InternalServerError)
+	}
+
+	logger.Info().Msg("webhook redelivery enqueued successfully")
+
+	return &pb.RedeliverWebhookResponse{Delivery: webhookDeliveryToPb(delivery)}, nil
+}
+
+func validateRedeliverWebhookRequest(req *pb.RedeliverWebhookRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUUID(req.GetDeliveryId()); err != nil {
+		violations = append(violations, handler.FieldViolation("delivery_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authPb "github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/api/handler"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/kyamalabs/users/internal/worker"
+	mockwk "github.com/kyamalabs/users/internal/worker/mock"
+	"github.com/kyamalabs/users/internal/worker/workertest"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRedeliverWebhookAPI(t *testing.T) {
+	delivery := db.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: uuid.New(),
+		EventID:   uuid.NewString(),
+		EventType: event.TypeReferralRecorded,
+		Status:    worker.WebhookDeliveryStatusPending,
+		Attempts:  9,
+		CreatedAt: time.Now(),
+	}
+
+	req := &pb.RedeliverWebhookRequest{DeliveryId: delivery.ID.String()}
+
+	testCases := []struct {
+		name          string
+		req           *pb.RedeliverWebhookRequest
+		buildStubs    func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.RedeliverWebhookResponse, err error)
+	}{
+		{
+			name: "success",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					ResetWebhookDelivery(gomock.Any(), delivery.ID).
+					Times(1).
+					Return(delivery, nil)
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), workertest.EnqueuedTask(worker.DeliverWebhookTask, &worker.PayloadDeliverWebhook{DeliveryID: delivery.ID})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RedeliverWebhookResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, delivery.ID.String(), res.GetDelivery().GetId())
+				require.Equal(t, pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING, res.GetDelivery().GetStatus())
+			},
+		},
+		{
+			name: "invalid request arguments",
+			req:  &pb.RedeliverWebhookRequest{DeliveryId: "not-a-uuid"},
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+			},
+			checkResponse: func(t *testing.T, res *pb.RedeliverWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				expectedFieldViolations := []string{"delivery_id"}
+				handler.CheckInvalidRequestParams(t, err, expectedFieldViolations)
+			},
+		},
+		{
+			name: "not an admin",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_GAMER)
+
+				store.EXPECT().
+					ResetWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RedeliverWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.UnauthorizedAccessError)
+			},
+		},
+		{
+			name: "delivery does not exist",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					ResetWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WebhookDelivery{}, db.RecordNotFoundError)
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RedeliverWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, DeliveryDoesNotExist)
+			},
+		},
+		{
+			name: "task distributor error",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore, authService *mockservices.MockAuthGrpcService, taskDistributor *mockwk.MockTaskDistributor) {
+				expectAdminAuthorization(authService, authPb.AccessTokenPayload_ADMIN)
+
+				store.EXPECT().
+					ResetWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(delivery, nil)
+
+				taskDistributor.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("some queue error"))
+			},
+			checkResponse: func(t *testing.T, res *pb.RedeliverWebhookResponse, err error) {
+				require.Error(t, err)
+				require.Empty(t, res)
+
+				require.ErrorContains(t, err, handler.InternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+
+			tc.buildStubs(store, authService, taskDistributor)
+
+			h := newTestHandler(store, authService, taskDistributor)
+			res, err := h.RedeliverWebhook(newAdminContext(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
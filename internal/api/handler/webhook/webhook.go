@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/kyamalabs/users/api/pb"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/kyamalabs/users/internal/worker"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const webhookHostLookupTimeout = 5 * time.Second
+
+var lookupWebhookHost = net.DefaultResolver.LookupIPAddr
+
+// validateWebhookHost resolves the host of a webhook url that has passed ValidateWebhookURL and
+// checks that it does not point to a reserved address. Deliveries check the addresses they dial
+// again, since the host may resolve differently by then.
+func validateWebhookHost(ctx context.Context, webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookHostLookupTimeout)
+	defer cancel()
+
+	addrs, err := lookupWebhookHost(ctx, u.Hostname())
+	if err != nil {
+		return errors.New("host could not be resolved")
+	}
+
+	return validator.ValidateWebhookAddresses(addrs)
+}
+
+// webhookToPb maps a stored webhook to its API representation. The signing secret is never returned.
+func webhookToPb(webhook db.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:         webhook.ID.String(),
+		Url:        webhook.Url,
+		EventTypes: webhook.EventTypes,
+		CreatedAt:  timestamppb.New(webhook.CreatedAt),
+	}
+}
+
+func webhookDeliveryToPb(delivery db.WebhookDelivery) *pb.WebhookDelivery {
+	pbDelivery := &pb.WebhookDelivery{
+		Id:             delivery.ID.String(),
+		WebhookId:      delivery.WebhookID.String(),
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         webhookDeliveryStatusToPb(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus.Int32,
+		LastError:      delivery.LastError.String,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+
+	if delivery.DeliveredAt.Valid {
+		pbDelivery.DeliveredAt = timestamppb.New(delivery.DeliveredAt.Time)
+	}
+
+	return pbDelivery
+}
+
+func webhookDeliveryStatusToPb(status string) pb.WebhookDeliveryStatus {
+	switch status {
+	case worker.WebhookDeliveryStatusPending:
+		return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case worker.WebhookDeliveryStatusSucceeded:
+		return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+	case worker.WebhookDeliveryStatusFailed:
+		return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED
+	}
+
+	return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+}
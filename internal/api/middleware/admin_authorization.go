@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyamalabs/proto/proto/auth/pb"
+
+	"github.com/kyamalabs/users/internal/constants"
+
+	"github.com/kyamalabs/users/internal/services"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthorizeAdmin verifies that the caller holds an admin access token. Since access tokens are
+// verified against a wallet address, admins identify themselves through the x-wallet-address header.
+func AuthorizeAdmin(ctx context.Context, authService services.AuthGrpcService) (*pb.AccessTokenPayload, error) {
+	mtdt, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errors.New("could not get metadata from incoming context")
+	}
+
+	walletAddresses := mtdt.Get(constants.XWalletAddressHeader)
+	if len(walletAddresses) == 0 {
+		return nil, errors.New("missing wallet address header")
+	}
+
+	payload, err := AuthorizeUser(ctx, walletAddresses[0], authService)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.GetRole() != pb.AccessTokenPayload_ADMIN {
+		return nil, fmt.Errorf("account role %s is not allowed to perform admin actions", payload.GetRole())
+	}
+
+	return payload, nil
+}
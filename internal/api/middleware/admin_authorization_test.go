@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kyamalabs/proto/proto/auth/pb"
+
+	"github.com/kyamalabs/users/internal/constants"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthorizeAdmin(t *testing.T) {
+	walletAddress := "0xc0ffee254729296a45a3885639AC7E10F9d54979"
+
+	adminContext := func(t *testing.T) context.Context {
+		md := metadata.MD{
+			constants.AuthorizationHeader: []string{
+				fmt.Sprintf("%s %s", constants.AuthorizationBearer, "some-dummy-access-token"),
+			},
+			constants.XWalletAddressHeader: []string{walletAddress},
+		}
+
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	verifyAccessToken := func(authService *mockservices.MockAuthGrpcService, role pb.AccessTokenPayload_Role) {
+		authService.EXPECT().
+			VerifyAccessToken(gomock.Any(), &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress}, "some-dummy-access-token").
+			Times(1).
+			Return(&pb.VerifyAccessTokenResponse{
+				Payload: &pb.AccessTokenPayload{
+					Id:            "some-id",
+					WalletAddress: walletAddress,
+					Role:          role,
+				},
+			}, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(authService *mockservices.MockAuthGrpcService)
+		buildContext  func(t *testing.T) context.Context
+		checkResponse func(t *testing.T, payload *pb.AccessTokenPayload, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				verifyAccessToken(authService, pb.AccessTokenPayload_ADMIN)
+			},
+			buildContext: adminContext,
+			checkResponse: func(t *testing.T, payload *pb.AccessTokenPayload, err error) {
+				require.NoError(t, err)
+				require.Equal(t, pb.AccessTokenPayload_ADMIN, payload.GetRole())
+			},
+		},
+		{
+			name: "not an admin",
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				verifyAccessToken(authService, pb.AccessTokenPayload_GAMER)
+			},
+			buildContext: adminContext,
+			checkResponse: func(t *testing.T, payload *pb.AccessTokenPayload, err error) {
+				require.Error(t, err)
+				require.Nil(t, payload)
+			},
+		},
+		{
+			name: "missing wallet address header",
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+			},
+			buildContext: func(t *testing.T) context.Context {
+				md := metadata.MD{
+					constants.AuthorizationHeader: []string{
+						fmt.Sprintf("%s %s", constants.AuthorizationBearer, "some-dummy-access-token"),
+					},
+				}
+
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkResponse: func(t *testing.T, payload *pb.AccessTokenPayload, err error) {
+				require.Error(t, err)
+				require.Nil(t, payload)
+			},
+		},
+		{
+			name: "could not verify access token",
+			buildStubs: func(authService *mockservices.MockAuthGrpcService) {
+				authService.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some verify access token error"))
+			},
+			buildContext: adminContext,
+			checkResponse: func(t *testing.T, payload *pb.AccessTokenPayload, err error) {
+				require.Error(t, err)
+				require.Nil(t, payload)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+			tc.buildStubs(authService)
+
+			payload, err := AuthorizeAdmin(tc.buildContext(t), authService)
+			tc.checkResponse(t, payload, err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// trustedProxies are the networks of the load balancers and proxies in front of the servers.
+// Only they are trusted to report the client IP in the x-forwarded-for header.
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies configures the CIDRs of the proxies trusted to set x-forwarded-for. Single
+// addresses are accepted as well.
+func SetTrustedProxies(cidrs []string) error {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy address: %s", cidr)
+			}
+
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			cidr = fmt.Sprintf("%s/%d", cidr, bits)
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy CIDR: %w", err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	trustedProxies = networks
+
+	return nil
+}
+
+func isTrustedProxy(ip net.IP) bool {
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveClientIP returns the IP of the client behind the trusted proxies a request went
+// through. The peer is the client unless it is a trusted proxy, in which case x-forwarded-for
+// is read from the right, where the closest proxy appended the address it received the request
+// from, skipping trusted proxies. The right-most untrusted address is the client, since anything
+// to its left was sent by the client and may be spoofed. When every address is a trusted proxy
+// the left-most one is used.
+func resolveClientIP(peerAddr string, forwardedFor []string) string {
+	peerIP := parseIP(peerAddr)
+	if peerIP == nil {
+		return ""
+	}
+
+	if !isTrustedProxy(peerIP) {
+		return peerIP.String()
+	}
+
+	var hops []net.IP
+	for _, header := range forwardedFor {
+		for _, hop := range strings.Split(header, ",") {
+			if ip := parseIP(hop); ip != nil {
+				hops = append(hops, ip)
+			}
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !isTrustedProxy(hops[i]) {
+			return hops[i].String()
+		}
+	}
+
+	if len(hops) > 0 {
+		return hops[0].String()
+	}
+
+	return peerIP.String()
+}
+
+// parseIP parses an address with or without a port.
+func parseIP(addr string) net.IP {
+	addr = strings.TrimSpace(addr)
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	return net.ParseIP(addr)
+}
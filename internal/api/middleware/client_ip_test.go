@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupTrustedProxies trusts the proxies for the duration of a test.
+func setupTrustedProxies(t *testing.T, cidrs ...string) {
+	initialTrustedProxies := trustedProxies
+	t.Cleanup(func() {
+		trustedProxies = initialTrustedProxies
+	})
+
+	err := SetTrustedProxies(cidrs)
+	require.NoError(t, err)
+}
+
+func TestSetTrustedProxies(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cidrs       []string
+		expectError bool
+	}{
+		{
+			name:  "CIDRs and addresses",
+			cidrs: []string{"10.0.0.0/8", "192.168.1.1", "::1", " fd00::/8 ", ""},
+		},
+		{
+			name:        "invalid CIDR",
+			cidrs:       []string{"10.0.0.0/33"},
+			expectError: true,
+		},
+		{
+			name:        "invalid address",
+			cidrs:       []string{"some-proxy"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			initialTrustedProxies := trustedProxies
+			defer func() {
+				trustedProxies = initialTrustedProxies
+			}()
+
+			err := SetTrustedProxies(tc.cidrs)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, trustedProxies, 4)
+		})
+	}
+}
+
+func TestResolveClientIP(t *testing.T) {
+	setupTrustedProxies(t, "10.0.0.0/8", "::1")
+
+	testCases := []struct {
+		name             string
+		peerAddr         string
+		forwardedFor     []string
+		expectedClientIP string
+	}{
+		{
+			name:             "direct client",
+			peerAddr:         "203.0.113.7:52341",
+			expectedClientIP: "203.0.113.7",
+		},
+		{
+			name:             "untrusted peer cannot spoof its address",
+			peerAddr:         "203.0.113.7:52341",
+			forwardedFor:     []string{"198.51.100.1"},
+			expectedClientIP: "203.0.113.7",
+		},
+		{
+			name:             "client behind a trusted proxy",
+			peerAddr:         "10.0.0.2:8080",
+			forwardedFor:     []string{"198.51.100.1"},
+			expectedClientIP: "198.51.100.1",
+		},
+		{
+			name:             "spoofed addresses left of the client are ignored",
+			peerAddr:         "10.0.0.2:8080",
+			forwardedFor:     []string{"1.2.3.4, 198.51.100.1"},
+			expectedClientIP: "198.51.100.1",
+		},
+		{
+			name:             "client behind a chain of trusted proxies",
+			peerAddr:         "10.0.0.3:8080",
+			forwardedFor:     []string{"198.51.100.1, 10.0.0.1", "10.0.0.2"},
+			expectedClientIP: "198.51.100.1",
+		},
+		{
+			name:             "every hop is a trusted proxy",
+			peerAddr:         "10.0.0.3:8080",
+			forwardedFor:     []string{"10.0.0.1, 10.0.0.2"},
+			expectedClientIP: "10.0.0.1",
+		},
+		{
+			name:             "malformed hops are skipped",
+			peerAddr:         "[::1]:8080",
+			forwardedFor:     []string{"198.51.100.1, unknown"},
+			expectedClientIP: "198.51.100.1",
+		},
+		{
+			name:             "trusted proxy without forwarded addresses",
+			peerAddr:         "10.0.0.2:8080",
+			expectedClientIP: "10.0.0.2",
+		},
+		{
+			name:             "address without port",
+			peerAddr:         "203.0.113.7",
+			expectedClientIP: "203.0.113.7",
+		},
+		{
+			name:             "unknown peer",
+			peerAddr:         "",
+			expectedClientIP: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedClientIP, resolveClientIP(tc.peerAddr, tc.forwardedFor))
+		})
+	}
+}
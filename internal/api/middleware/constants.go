@@ -9,9 +9,9 @@ const (
 
 // Errors
 const (
-	InternalServerError             string = "An unexpected error occurred while processing your request."
-	RateLimitExceededError          string = "Slow down! Too many requests. Try again shortly. Thank you!"
-	MissingXForwardedForHeaderError string = "X-Forwarded-For header is required for accurate processing."
+	InternalServerError    string = "An unexpected error occurred while processing your request."
+	RateLimitExceededError string = "Slow down! Too many requests. Try again shortly. Thank you!"
+	UnknownClientIPError   string = "Could not determine the client IP of the request."
 )
 
 type ReqContextKey string
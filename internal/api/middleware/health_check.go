@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var healthCheckMethodPrefix = "/" + healthpb.Health_ServiceDesc.ServiceName + "/"
+
+// GrpcSkipHealthCheck lets health checks from the orchestrator through without running the
+// interceptor, since probes neither authenticate as a service nor should count towards limits.
+func GrpcSkipHealthCheck(interceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthCheckMethodPrefix) {
+			return handler(ctx, req)
+		}
+
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+// GrpcStreamSkipHealthCheck lets health check watches through without running the interceptor,
+// like GrpcSkipHealthCheck does for unary health checks.
+func GrpcStreamSkipHealthCheck(interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthCheckMethodPrefix) {
+			return handler(srv, stream)
+		}
+
+		return interceptor(srv, stream, info, handler)
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestGrpcSkipHealthCheck(t *testing.T) {
+	interceptorErr := errors.New("some interceptor error")
+	interceptor := GrpcSkipHealthCheck(func(_ context.Context, _ any, _ *grpc.UnaryServerInfo, _ grpc.UnaryHandler) (interface{}, error) {
+		return nil, interceptorErr
+	})
+
+	handler := func(_ context.Context, _ any) (interface{}, error) {
+		return "ok", nil
+	}
+
+	testCases := []struct {
+		name        string
+		method      string
+		expectedRes interface{}
+		expectedErr error
+	}{
+		{
+			name:        "health check",
+			method:      "/grpc.health.v1.Health/Check",
+			expectedRes: "ok",
+		},
+		{
+			name:        "other method",
+			method:      "/pb.Profiles/GetProfile",
+			expectedErr: interceptorErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			require.Equal(t, tc.expectedRes, res)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestGrpcStreamSkipHealthCheck(t *testing.T) {
+	interceptorErr := errors.New("some interceptor error")
+	interceptor := GrpcStreamSkipHealthCheck(func(_ any, _ grpc.ServerStream, _ *grpc.StreamServerInfo, _ grpc.StreamHandler) error {
+		return interceptorErr
+	})
+
+	handler := func(_ any, _ grpc.ServerStream) error {
+		return nil
+	}
+
+	err := interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}, handler)
+	require.NoError(t, err)
+
+	err = interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/pb.Profiles/WatchProfiles"}, handler)
+	require.ErrorIs(t, err, interceptorErr)
+}
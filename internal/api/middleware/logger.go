@@ -16,15 +16,31 @@ func GrpcLogger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handle
 	result, err := handler(ctx, req)
 	duration := time.Since(startTime)
 
+	logGrpcRequest(ctx, info.FullMethod, duration, err)
+
+	return result, err
+}
+
+func GrpcStreamLogger(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	startTime := time.Now()
+	err := handler(srv, stream)
+	duration := time.Since(startTime)
+
+	logGrpcRequest(stream.Context(), info.FullMethod, duration, err)
+
+	return err
+}
+
+func logGrpcRequest(ctx context.Context, method string, duration time.Duration, err error) {
 	statusCode := codes.Unknown
 	st, ok := status.FromError(err)
 	if ok {
 		statusCode = st.Code()
 	}
 
-	logger := log.Info()
+	logger := log.Ctx(ctx).Info()
 	if err != nil {
-		logger = log.Error().Err(err)
+		logger = log.Ctx(ctx).Error().Err(err)
 	}
 
 	ip, ok := ctx.Value(ClientIP).(string)
@@ -38,14 +54,12 @@ func GrpcLogger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handle
 	}
 
 	logger.Str("protocol", "grpc").
-		Str("method", info.FullMethod).
+		Str("method", method).
 		Int("status_code", int(statusCode)).
 		Str("status_text", statusCode.String()).
 		Str("client_ip", ip).
 		Dur("duration", duration).
 		Msg("processed gRPC request")
-
-	return result, err
 }
 
 type ResponseRecorder struct {
@@ -74,9 +88,9 @@ func HTTPLogger(handler http.Handler) http.Handler {
 		handler.ServeHTTP(rec, req)
 		duration := time.Since(startTime)
 
-		logger := log.Info()
+		logger := log.Ctx(req.Context()).Info()
 		if rec.StatusCode >= 400 && rec.StatusCode < 600 {
-			logger = log.Error().Bytes("body", rec.Body)
+			logger = log.Ctx(req.Context()).Error().Bytes("body", rec.Body)
 		}
 
 		ip, ok := req.Context().Value(ClientIP).(string)
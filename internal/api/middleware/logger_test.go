@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestGrpcLogger(t *testing.T) {
@@ -26,6 +27,39 @@ func TestGrpcLogger(t *testing.T) {
 	assert.Equal(t, "MockResponse", resp)
 }
 
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockServerStream) SetHeader(md metadata.MD) error {
+	m.header = metadata.Join(m.header, md)
+	return nil
+}
+
+func TestGrpcStreamLogger(t *testing.T) {
+	mockInfo := &grpc.StreamServerInfo{
+		FullMethod:     "SomeService/SomeStreamingMethod",
+		IsServerStream: true,
+	}
+
+	handlerCalled := false
+	mockHandler := func(srv any, stream grpc.ServerStream) error {
+		handlerCalled = true
+		return nil
+	}
+
+	err := GrpcStreamLogger(nil, &mockServerStream{ctx: context.Background()}, mockInfo, mockHandler)
+
+	assert.NoError(t, err)
+	assert.True(t, handlerCalled)
+}
+
 func TestHTTPLogger(t *testing.T) {
 	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusCreated)
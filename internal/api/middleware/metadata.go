@@ -5,34 +5,81 @@ import (
 	"net/http"
 
 	"github.com/kyamalabs/users/internal/constants"
+	"github.com/kyamalabs/users/internal/requestid"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 func GrpcExtractMetadata(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if ok {
-		clientIPs := md.Get(xForwardedForHeader)
-		if len(clientIPs) > 0 {
-			ctx = context.WithValue(ctx, ClientIP, clientIPs[0])
-		}
+	md, _ := metadata.FromIncomingContext(ctx)
 
-		serviceAuthentications := md.Get(constants.XServiceAuthenticationHeader)
-		if len(serviceAuthentications) > 0 {
-			ctx = context.WithValue(ctx, ServiceAuthentication, serviceAuthentications[0])
-		}
-	}
+	ctx = withCaller(withRequestID(ctx, md), md)
+
+	// the header is sent along with the error status too, so failed calls can be traced
+	_ = grpc.SetHeader(ctx, metadata.Pairs(constants.XRequestIDHeader, requestid.FromContext(ctx)))
 
 	result, err := handler(ctx, req)
 
 	return result, err
 }
 
+// GrpcStreamExtractMetadata tags streams with a request id and their caller the way
+// GrpcExtractMetadata tags unary calls.
+func GrpcStreamExtractMetadata(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	ctx := withCaller(withRequestID(stream.Context(), md), md)
+
+	_ = stream.SetHeader(metadata.Pairs(constants.XRequestIDHeader, requestid.FromContext(ctx)))
+
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// withRequestID tags the context with the request id sent by the caller, or a new one.
+func withRequestID(ctx context.Context, md metadata.MD) context.Context {
+	var requestID string
+	if values := md.Get(constants.XRequestIDHeader); len(values) > 0 {
+		requestID = values[0]
+	}
+
+	return requestid.NewContext(ctx, requestid.FromHeader(requestID))
+}
+
+// withCaller tags the context with the client IP and service authentication of the caller, which
+// service authentication and rate limiting rely on.
+func withCaller(ctx context.Context, md metadata.MD) context.Context {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if clientIP := resolveClientIP(p.Addr.String(), md.Get(xForwardedForHeader)); clientIP != "" {
+			ctx = context.WithValue(ctx, ClientIP, clientIP)
+		}
+	}
+
+	serviceAuthentications := md.Get(constants.XServiceAuthenticationHeader)
+	if len(serviceAuthentications) > 0 {
+		ctx = context.WithValue(ctx, ServiceAuthentication, serviceAuthentications[0])
+	}
+
+	return ctx
+}
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *contextServerStream) Context() context.Context {
+	return stream.ctx
+}
+
 func HTTPExtractMetadata(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		if xForwardedForHeaderVal := req.Header.Get(xForwardedForHeader); xForwardedForHeaderVal != "" {
-			req = req.WithContext(context.WithValue(req.Context(), ClientIP, xForwardedForHeaderVal))
+		requestID := requestid.FromHeader(req.Header.Get(constants.XRequestIDHeader))
+		req = req.WithContext(requestid.NewContext(req.Context(), requestID))
+		res.Header().Set(constants.XRequestIDHeader, requestID)
+
+		if clientIP := resolveClientIP(req.RemoteAddr, req.Header.Values(xForwardedForHeader)); clientIP != "" {
+			req = req.WithContext(context.WithValue(req.Context(), ClientIP, clientIP))
 		}
 
 		if xServiceAuthenticationHeaderVal := req.Header.Get(constants.XServiceAuthenticationHeader); xServiceAuthenticationHeaderVal != "" {
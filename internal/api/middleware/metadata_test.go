@@ -2,17 +2,31 @@ package middleware
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/kyamalabs/users/internal/constants"
+	"github.com/kyamalabs/users/internal/requestid"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
+func newPeerContext(addr string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{
+		IP:   net.ParseIP(strings.Split(addr, ":")[0]),
+		Port: 8080,
+	}})
+}
+
 func TestGrpcExtractMetadata(t *testing.T) {
+	setupTrustedProxies(t, "10.0.0.0/8")
+
 	testCases := []struct {
 		name        string
 		ctx         context.Context
@@ -20,10 +34,16 @@ func TestGrpcExtractMetadata(t *testing.T) {
 		expectedCtx context.Context
 	}{
 		{
-			name:        "set x-forwarded-for header",
-			ctx:         metadata.NewIncomingContext(context.Background(), metadata.Pairs(xForwardedForHeader, "testClientIP")),
+			name:        "set client IP of the peer",
+			ctx:         metadata.NewIncomingContext(newPeerContext("203.0.113.7:52341"), metadata.Pairs(xForwardedForHeader, "198.51.100.1")),
+			ctxKey:      ClientIP,
+			expectedCtx: context.WithValue(context.Background(), ClientIP, "203.0.113.7"),
+		},
+		{
+			name:        "set client IP forwarded by a trusted proxy",
+			ctx:         metadata.NewIncomingContext(newPeerContext("10.0.0.2:8080"), metadata.Pairs(xForwardedForHeader, "198.51.100.1")),
 			ctxKey:      ClientIP,
-			expectedCtx: context.WithValue(context.Background(), ClientIP, "testClientIP"),
+			expectedCtx: context.WithValue(context.Background(), ClientIP, "198.51.100.1"),
 		},
 		{
 			name:        "set x-service-authentication header",
@@ -40,25 +60,44 @@ func TestGrpcExtractMetadata(t *testing.T) {
 
 				return nil, nil
 			})
+			require.NoError(t, err)
+
+			// streams are tagged with the same caller
+			err = GrpcStreamExtractMetadata(nil, &mockServerStream{ctx: tc.ctx}, nil, func(_ any, stream grpc.ServerStream) error {
+				require.Equal(t, tc.expectedCtx.Value(tc.ctxKey), stream.Context().Value(tc.ctxKey))
 
+				return nil
+			})
 			require.NoError(t, err)
 		})
 	}
 }
 
 func TestHTTPExtractMetadata(t *testing.T) {
+	setupTrustedProxies(t, "10.0.0.0/8")
+
 	tests := []struct {
 		name                          string
+		remoteAddr                    string
 		headers                       map[string]string
 		expectedClientIP              string
 		expectedServiceAuthentication string
 	}{
 		{
-			name: "set x-forwarded-for header",
+			name:       "set client IP of the peer",
+			remoteAddr: "203.0.113.7:52341",
+			headers: map[string]string{
+				xForwardedForHeader: "198.51.100.1",
+			},
+			expectedClientIP: "203.0.113.7",
+		},
+		{
+			name:       "set client IP forwarded by a trusted proxy",
+			remoteAddr: "10.0.0.2:8080",
 			headers: map[string]string{
-				xForwardedForHeader: "testClientIP",
+				xForwardedForHeader: "198.51.100.1",
 			},
-			expectedClientIP: "testClientIP",
+			expectedClientIP: "198.51.100.1",
 		},
 		{
 			name: "set x-service-authentication header",
@@ -72,6 +111,9 @@ func TestHTTPExtractMetadata(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.remoteAddr != "" {
+				req.RemoteAddr = tt.remoteAddr
+			}
 
 			for key, val := range tt.headers {
 				req.Header.Set(key, val)
@@ -97,3 +139,86 @@ func TestHTTPExtractMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestGrpcExtractMetadata_RequestID(t *testing.T) {
+	testCases := []struct {
+		name              string
+		md                metadata.MD
+		expectedRequestID string
+	}{
+		{
+			name:              "request id sent by the caller",
+			md:                metadata.Pairs(constants.XRequestIDHeader, "some-request-id"),
+			expectedRequestID: "some-request-id",
+		},
+		{
+			name: "missing request id",
+			md:   metadata.MD{},
+		},
+		{
+			name: "malformed request id",
+			md:   metadata.Pairs(constants.XRequestIDHeader, "some request id"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+
+			_, err := GrpcExtractMetadata(ctx, nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+				requestID := requestid.FromContext(ctx)
+				require.NotEmpty(t, requestID)
+
+				if tc.expectedRequestID != "" {
+					require.Equal(t, tc.expectedRequestID, requestID)
+				}
+
+				return nil, nil
+			})
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHTTPExtractMetadata_RequestID(t *testing.T) {
+	testCases := []struct {
+		name              string
+		requestID         string
+		expectedRequestID string
+	}{
+		{
+			name:              "request id sent by the caller",
+			requestID:         "some-request-id",
+			expectedRequestID: "some-request-id",
+		},
+		{
+			name: "missing request id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tc.requestID != "" {
+				req.Header.Set(constants.XRequestIDHeader, tc.requestID)
+			}
+
+			rr := httptest.NewRecorder()
+
+			var requestID string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestID = requestid.FromContext(r.Context())
+			})
+
+			HTTPExtractMetadata(handler).ServeHTTP(rr, req)
+
+			require.NotEmpty(t, requestID)
+			require.Equal(t, requestID, rr.Header().Get(constants.XRequestIDHeader))
+
+			if tc.expectedRequestID != "" {
+				require.Equal(t, tc.expectedRequestID, requestID)
+			}
+		})
+	}
+}
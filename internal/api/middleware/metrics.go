@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/kyamalabs/users/internal/metrics"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// unmatchedHTTPRoute labels gateway requests that did not reach an RPC, such as swagger assets
+// and unknown paths, so that arbitrary paths do not become label values.
+const unmatchedHTTPRoute = "unmatched"
+
+type httpRouteKey struct{}
+
+// httpRoute is filled in by the gateway once it has matched a request to an RPC.
+type httpRoute struct {
+	method string
+}
+
+func GrpcMetrics(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	startTime := time.Now()
+	result, err := handler(ctx, req)
+
+	observeGrpcRequest(info.FullMethod, time.Since(startTime), err)
+
+	return result, err
+}
+
+func GrpcStreamMetrics(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	startTime := time.Now()
+	err := handler(srv, stream)
+
+	observeGrpcRequest(info.FullMethod, time.Since(startTime), err)
+
+	return err
+}
+
+func observeGrpcRequest(method string, duration time.Duration, err error) {
+	metrics.RPCDuration.
+		WithLabelValues(metrics.ProtocolGrpc, method, status.Code(err).String()).
+		Observe(duration.Seconds())
+}
+
+// HTTPMetrics records the latency of gateway requests labelled by the RPC they were routed to.
+// The gateway must be created with AnnotateHTTPRoute for the route to be known.
+func HTTPMetrics(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		startTime := time.Now()
+		rec := &ResponseRecorder{
+			ResponseWriter: res,
+			StatusCode:     http.StatusOK,
+		}
+
+		route := &httpRoute{method: unmatchedHTTPRoute}
+		ctx := context.WithValue(req.Context(), httpRouteKey{}, route)
+
+		handler.ServeHTTP(rec, req.WithContext(ctx))
+
+		metrics.RPCDuration.
+			WithLabelValues(metrics.ProtocolHTTP, route.method, strconv.Itoa(rec.StatusCode)).
+			Observe(time.Since(startTime).Seconds())
+	})
+}
+
+// AnnotateHTTPRoute is registered on the gateway with runtime.WithMetadata. It adds no metadata
+// and only reports the RPC a request was routed to back to HTTPMetrics and the request span.
+func AnnotateHTTPRoute(ctx context.Context, req *http.Request) metadata.MD {
+	route, ok := req.Context().Value(httpRouteKey{}).(*httpRoute)
+	if !ok {
+		return nil
+	}
+
+	if method, ok := runtime.RPCMethod(ctx); ok {
+		route.method = method
+		// the gateway span is started before routing and is named after the route once known
+		trace.SpanFromContext(req.Context()).SetName(method)
+	}
+
+	return nil
+}
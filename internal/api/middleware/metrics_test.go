@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func rpcSampleCount(t *testing.T, protocol string, method string, statusLabel string) uint64 {
+	observer, err := metrics.RPCDuration.GetMetricWithLabelValues(protocol, method, statusLabel)
+	require.NoError(t, err)
+
+	metric := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(metric))
+
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestGrpcMetrics(t *testing.T) {
+	method := "/pb.Test/GrpcMetrics"
+
+	handler := func(_ context.Context, req any) (interface{}, error) {
+		if req == "fail" {
+			return nil, status.Error(codes.NotFound, "some error")
+		}
+		return "ok", nil
+	}
+
+	for _, req := range []string{"succeed", "succeed", "fail"} {
+		_, _ = GrpcMetrics(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	require.Equal(t, uint64(2), rpcSampleCount(t, metrics.ProtocolGrpc, method, codes.OK.String()))
+	require.Equal(t, uint64(1), rpcSampleCount(t, metrics.ProtocolGrpc, method, codes.NotFound.String()))
+}
+
+func TestHTTPMetrics(t *testing.T) {
+	method := "/pb.Test/HTTPMetrics"
+
+	gatewayMux := runtime.NewServeMux(runtime.WithMetadata(AnnotateHTTPRoute))
+	err := gatewayMux.HandlePath(http.MethodGet, "/test/{id}", func(res http.ResponseWriter, req *http.Request, _ map[string]string) {
+		// generated gateway handlers annotate the context with the rpc method before calling it
+		_, err := runtime.AnnotateIncomingContext(req.Context(), gatewayMux, req, method)
+		require.NoError(t, err)
+
+		res.WriteHeader(http.StatusTeapot)
+	})
+	require.NoError(t, err)
+
+	handler := HTTPMetrics(gatewayMux)
+
+	for _, path := range []string{"/test/some-id", "/test/another-id", "/unknown"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Equal(t, uint64(2), rpcSampleCount(t, metrics.ProtocolHTTP, method, "418"))
+	require.Equal(t, uint64(1), rpcSampleCount(t, metrics.ProtocolHTTP, unmatchedHTTPRoute, "404"))
+}
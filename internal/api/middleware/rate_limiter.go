@@ -3,13 +3,15 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/ulule/limiter/v3/drivers/middleware/stdlib"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 
+	"github.com/kyamalabs/users/internal/metrics"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 	"github.com/ulule/limiter/v3"
@@ -20,23 +22,24 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-type rate struct {
-	Limit      int64
-	Period     time.Duration
-	Identifier string
-}
+const rateLimiterHealthCheckKey = "health_check"
 
-const defaultRateLimitIdentifier = "default_rate_limit_identifier"
+// ErrRateLimitExceeded is returned by AuthorizeUser when the authorized wallet address has
+// exhausted the rate limit of the endpoint.
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
 
-var rateLimits = map[string]rate{
-	// default global rate limit:
-	// applies to all endpoints collectively when no specific rate limit is defined for an individual endpoint.
-	defaultRateLimitIdentifier: {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier},
+// RateLimiter counts requests against the rate limits of the endpoints they are made to. The same
+// rate limiter is shared by the gRPC and HTTP transports so they count requests the same way.
+type RateLimiter struct {
+	rateLimits        map[string][]rate
+	rateLimitPatterns []string
+	limiters          map[string]*limiter.Limiter
+	redis             *redis.Client
 }
 
-var limiters = make(map[string]*limiter.Limiter)
-
-func CreateLimiterRedisStore(redisConnURL string) (limiter.Store, error) {
+// NewRedisRateLimiter creates a rate limiter backed by Redis with the rate limits in spec, which is
+// described by parseRateLimits. An empty spec applies the default rate limit to every endpoint.
+func NewRedisRateLimiter(redisConnURL string, spec string) (*RateLimiter, error) {
 	opts, err := redis.ParseURL(redisConnURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse redis connection url: %w", err)
@@ -47,44 +50,80 @@ func CreateLimiterRedisStore(redisConnURL string) (limiter.Store, error) {
 		Prefix: "api-rate-limiter",
 	})
 	if err != nil {
+		_ = rc.Close()
 		return nil, fmt.Errorf("could not create a new redis rate limiter store: %w", err)
 	}
 
-	return store, nil
+	rl, err := NewRateLimiter(store, spec)
+	if err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+	rl.redis = rc
+
+	return rl, nil
 }
 
-func InitializeLimiters(store limiter.Store) error {
-	limiters = make(map[string]*limiter.Limiter)
-	for _, rateLimit := range rateLimits {
-		_, exists := limiters[rateLimit.Identifier]
-		if exists {
-			return nil
-		}
+// NewRateLimiter creates a rate limiter backed by store with the rate limits in spec, which is
+// described by parseRateLimits.
+func NewRateLimiter(store limiter.Store, spec string) (*RateLimiter, error) {
+	limits, err := parseRateLimits(spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rate limits: %w", err)
+	}
+
+	rl := &RateLimiter{
+		rateLimits:        limits,
+		rateLimitPatterns: sortedRateLimitPatterns(limits),
+		limiters:          make(map[string]*limiter.Limiter),
+	}
+
+	for _, rates := range limits {
+		for _, r := range rates {
+			if _, exists := rl.limiters[r.Identifier]; exists {
+				continue
+			}
 
-		r := limiter.Rate{
-			Limit:  rateLimit.Limit,
-			Period: rateLimit.Period,
+			rl.limiters[r.Identifier] = limiter.New(store, r.Rate)
 		}
+	}
 
-		l := limiter.New(store, r, limiter.WithTrustForwardHeader(true))
+	return rl, nil
+}
 
-		limiters[rateLimit.Identifier] = l
+// Close closes the connection of the store created by NewRedisRateLimiter. It must only be called
+// once no request is rate limited anymore.
+func (rl *RateLimiter) Close() error {
+	if rl.redis == nil {
+		return nil
 	}
 
-	return nil
+	return rl.redis.Close()
 }
 
-func getEndpointRateLimit(endpoint string) rate {
-	rateLimit, exists := rateLimits[endpoint]
-	if !exists || rateLimit == (rate{}) {
-		rateLimit = rateLimits[defaultRateLimitIdentifier]
+// Ping checks that the store backing the rate limiters can be reached. Only the health check key
+// is read, so no client's limit is consumed.
+func (rl *RateLimiter) Ping(ctx context.Context) error {
+	defaults := rl.rateLimits[defaultRateLimitEndpoint]
+	if len(defaults) == 0 {
+		return errors.New("rate limiters are not initialized")
+	}
+
+	l, err := rl.getLimiter(defaults[0])
+	if err != nil {
+		return err
 	}
 
-	return rateLimit
+	_, err = l.Peek(ctx, rateLimiterHealthCheckKey)
+	if err != nil {
+		return fmt.Errorf("could not reach rate limiter store: %w", err)
+	}
+
+	return nil
 }
 
-func getLimiter(r rate) (*limiter.Limiter, error) {
-	l := limiters[r.Identifier]
+func (rl *RateLimiter) getLimiter(r rate) (*limiter.Limiter, error) {
+	l := rl.limiters[r.Identifier]
 	if l == nil {
 		return nil, fmt.Errorf("could not get rate limiter for identifier: %s", r.Identifier)
 	}
@@ -96,59 +135,193 @@ var getLimiterContext = func(ctx context.Context, l *limiter.Limiter, key string
 	return l.Get(ctx, key)
 }
 
-func shouldRateLimit(ctx context.Context) bool {
-	_, ok := ctx.Value(AuthenticatedService).(string)
-	return !ok
+// limitResult is the outcome of counting a request against the tiers of an endpoint.
+type limitResult struct {
+	// context is that of the tier that was exceeded, or else the tier with the fewest requests
+	// remaining. It is the zero value when no tier applies.
+	context limiter.Context
+	// exceeded is the tier that was exceeded, if any.
+	exceeded *rate
+}
+
+// limit counts a request against every tier of rates that applies to the key. Tiers after an
+// exceeded one are not counted.
+func (rl *RateLimiter) limit(ctx context.Context, rates []rate, key rateLimitKey, value string) (limitResult, error) {
+	var result limitResult
+
+	for i := range rates {
+		r := rates[i]
+		if r.Key != key {
+			continue
+		}
+
+		l, err := rl.getLimiter(r)
+		if err != nil {
+			return limitResult{}, err
+		}
+
+		c, err := getLimiterContext(ctx, l, fmt.Sprintf("%s:%s", r.Identifier, value))
+		if err != nil {
+			return limitResult{}, fmt.Errorf("could not get rate limiter context: %w", err)
+		}
+
+		if c.Reached {
+			return limitResult{context: c, exceeded: &r}, nil
+		}
+
+		if result.context.Reset == 0 || c.Remaining < result.context.Remaining {
+			result.context = c
+		}
+	}
+
+	return result, nil
+}
+
+// headers describes the limit of the caller the same way on both transports. Retry-After is
+// only set once the limit is exceeded.
+func (result limitResult) headers(now time.Time) map[string]string {
+	if result.context.Reset == 0 {
+		return nil
+	}
+
+	headers := map[string]string{
+		"X-RateLimit-Limit":     strconv.FormatInt(result.context.Limit, 10),
+		"X-RateLimit-Remaining": strconv.FormatInt(result.context.Remaining, 10),
+		"X-RateLimit-Reset":     strconv.FormatInt(result.context.Reset, 10),
+	}
+
+	if result.exceeded != nil {
+		retryAfter := result.context.Reset - now.Unix()
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		headers["Retry-After"] = strconv.FormatInt(retryAfter, 10)
+	}
+
+	return headers
+}
+
+// endpointRateLimitsKey carries the rate limits of the endpoint being called to AuthorizeUser,
+// which limits wallet addresses once it has authorized them.
+type endpointRateLimitsKey struct{}
+
+type endpointRateLimits struct {
+	limiter  *RateLimiter
+	protocol string
+	rates    []rate
 }
 
-func GrpcRateLimiter(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-	if !shouldRateLimit(ctx) {
-		return handler(ctx, req)
+// limitWallet counts a request of an authorized wallet address against the wallet tiers of the
+// endpoint. Failures to reach the store let the request through, since the client IP has
+// already been limited.
+func limitWallet(ctx context.Context, walletAddress string) error {
+	limits, ok := ctx.Value(endpointRateLimitsKey{}).(*endpointRateLimits)
+	if !ok {
+		return nil
 	}
 
-	endpoint := info.FullMethod
-	logger := log.With().Str("endpoint", endpoint).Logger()
+	logger := log.Ctx(ctx).With().Str("wallet_address", walletAddress).Logger()
 
-	rateLimit := getEndpointRateLimit(endpoint)
-	l, err := getLimiter(rateLimit)
+	result, err := limits.limiter.limit(ctx, limits.rates, RateLimitKeyWallet, walletAddress)
 	if err != nil {
-		logger.Error().Err(err).Msg("could not get rate limiter")
-		return nil, status.Error(codes.Internal, InternalServerError)
+		logger.Error().Err(err).Msg("could not apply wallet rate limits")
+		return nil
+	}
+
+	if result.exceeded != nil {
+		metrics.RateLimitRejections.WithLabelValues(limits.protocol, result.exceeded.Identifier).Inc()
+		logger.Error().Str("rate_limit", result.exceeded.Identifier).Msg("rate limit exceeded for wallet address")
+		return ErrRateLimitExceeded
+	}
+
+	return nil
+}
+
+// rateLimitCaller returns the key and value the caller is counted by: the calling service when
+// it has authenticated, or else its client IP.
+func rateLimitCaller(ctx context.Context) (rateLimitKey, string, bool) {
+	if service, ok := ctx.Value(AuthenticatedService).(string); ok {
+		return RateLimitKeyService, service, true
 	}
 
 	clientIP, ok := ctx.Value(ClientIP).(string)
+	return RateLimitKeyIP, clientIP, ok && clientIP != ""
+}
+
+// applyRateLimits counts a request against the rate limits of the endpoint that apply to the
+// caller, which is a gRPC full method or an HTTP "METHOD:path". Both transports rate limit
+// through it, so they reject requests and describe limits the same way. It returns the context
+// to call the handler with, the headers to respond with and a status error when the request
+// must be rejected.
+func (rl *RateLimiter) applyRateLimits(ctx context.Context, protocol string, endpoint string) (context.Context, map[string]string, error) {
+	logger := log.Ctx(ctx).With().Str("endpoint", endpoint).Logger()
+
+	rates := rl.getEndpointRateLimits(endpoint)
+	ctx = context.WithValue(ctx, endpointRateLimitsKey{}, &endpointRateLimits{limiter: rl, protocol: protocol, rates: rates})
+
+	key, value, ok := rateLimitCaller(ctx)
 	if !ok {
-		logger.Error().Msg("could not extract client IP for rate limiting")
-		return nil, status.Error(codes.InvalidArgument, MissingXForwardedForHeaderError)
+		logger.Error().Msg("could not determine client IP for rate limiting")
+		return ctx, nil, status.Error(codes.InvalidArgument, UnknownClientIPError)
 	}
 
-	logger = logger.With().Str("client_ip", clientIP).Logger()
+	logger = logger.With().Str(string(key), value).Logger()
 
-	c, err := getLimiterContext(ctx, l, clientIP)
+	result, err := rl.limit(ctx, rates, key, value)
 	if err != nil {
-		logger.Error().Err(err).Msg("could not get rate limiter context")
-		return nil, status.Error(codes.Internal, InternalServerError)
+		logger.Error().Err(err).Msg("could not apply rate limits")
+		return ctx, nil, status.Error(codes.Internal, InternalServerError)
 	}
 
-	headers := metadata.Pairs(
-		"X-RateLimit-Limit", strconv.FormatInt(c.Limit, 10),
-		"X-RateLimit-Remaining", strconv.FormatInt(c.Remaining, 10),
-		"X-RateLimit-Reset", strconv.FormatInt(c.Reset, 10),
-	)
-	err = grpc.SendHeader(ctx, headers)
-	if err != nil {
-		logger.Error().Err(err).Msg("could not send rate limit headers")
-		return nil, status.Error(codes.Internal, InternalServerError)
+	headers := result.headers(time.Now())
+
+	if result.exceeded != nil {
+		metrics.RateLimitRejections.WithLabelValues(protocol, result.exceeded.Identifier).Inc()
+		logger.Error().Str("rate_limit", result.exceeded.Identifier).Msgf("rate limit exceeded for %s", key)
+		return ctx, headers, status.Error(codes.ResourceExhausted, RateLimitExceededError)
 	}
 
-	if c.Reached {
-		logger.Error().Msg("rate limit exceeded for client IP")
-		return nil, status.Error(codes.ResourceExhausted, RateLimitExceededError)
+	return ctx, headers, nil
+}
+
+func (rl *RateLimiter) GrpcRateLimiter(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	ctx, headers, rateLimitErr := rl.applyRateLimits(ctx, metrics.ProtocolGrpc, info.FullMethod)
+
+	if headers != nil {
+		err = grpc.SetHeader(ctx, metadata.New(headers))
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("endpoint", info.FullMethod).Msg("could not set rate limit headers")
+			return nil, status.Error(codes.Internal, InternalServerError)
+		}
+	}
+
+	if rateLimitErr != nil {
+		return nil, rateLimitErr
 	}
 
 	return handler(ctx, req)
 }
 
+// GrpcStreamRateLimiter counts a stream against the rate limits of its method once, when it is
+// opened.
+func (rl *RateLimiter) GrpcStreamRateLimiter(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, headers, rateLimitErr := rl.applyRateLimits(stream.Context(), metrics.ProtocolGrpc, info.FullMethod)
+
+	if headers != nil {
+		err := stream.SetHeader(metadata.New(headers))
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("endpoint", info.FullMethod).Msg("could not set rate limit headers")
+			return status.Error(codes.Internal, InternalServerError)
+		}
+	}
+
+	if rateLimitErr != nil {
+		return rateLimitErr
+	}
+
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+}
+
 type ErrorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -180,37 +353,19 @@ func httpError(res http.ResponseWriter, grpcError error, httpStatusCode int) {
 	}
 }
 
-func HTTPRateLimiter(handler http.Handler) http.Handler {
+func (rl *RateLimiter) HTTPRateLimiter(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		if !shouldRateLimit(req.Context()) {
-			handler.ServeHTTP(res, req)
-			return
-		}
+		ctx, headers, err := rl.applyRateLimits(req.Context(), metrics.ProtocolHTTP, fmt.Sprintf("%s:%s", req.Method, req.URL.Path))
 
-		endpoint := req.URL.Path
-		logger := log.With().Str("endpoint", endpoint).Logger()
+		for header, value := range headers {
+			res.Header().Set(header, value)
+		}
 
-		rateLimit := getEndpointRateLimit(fmt.Sprintf("%s:%s", req.Method, endpoint))
-		l, err := getLimiter(rateLimit)
 		if err != nil {
-			logger.Error().Err(err).Msg("could not get rate limiter")
-			grpcError := status.Error(codes.Internal, InternalServerError)
-			httpError(res, grpcError, http.StatusInternalServerError)
+			httpError(res, err, runtime.HTTPStatusFromCode(status.Code(err)))
 			return
 		}
 
-		middleware := stdlib.NewMiddleware(l)
-
-		rateLimitKey := middleware.KeyGetter(req)
-		logger = logger.With().Str("client_ip", rateLimitKey).Logger()
-
-		middleware.OnLimitReached = func(w http.ResponseWriter, r *http.Request) {
-			logger.Error().Msg("rate limit exceeded for client IP")
-			grpcError := status.Error(codes.ResourceExhausted, RateLimitExceededError)
-			httpError(res, grpcError, http.StatusTooManyRequests)
-		}
-
-		handlerWithMiddleware := middleware.Handler(handler)
-		handlerWithMiddleware.ServeHTTP(res, req)
+		handler.ServeHTTP(res, req.WithContext(ctx))
 	})
 }
@@ -15,110 +15,189 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
-func TestInitializeLimiters(t *testing.T) {
+// newTestRateLimiter creates a rate limiter backed by an in-memory store.
+func newTestRateLimiter(t *testing.T, spec string) *RateLimiter {
+	rl, err := NewRateLimiter(memory.NewStore(), spec)
+	require.NoError(t, err)
+
+	return rl
+}
+
+func TestParseRateLimits(t *testing.T) {
 	testCases := []struct {
-		name                string
-		rateLimits          map[string]rate
-		expectedNumLimiters int
+		name           string
+		spec           string
+		expectedLimits map[string][]string
+		expectError    bool
 	}{
 		{
-			name: "only default rate limit",
-			rateLimits: map[string]rate{
-				defaultRateLimitIdentifier: {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier},
+			name: "empty spec applies the default rate limit",
+			spec: "",
+			expectedLimits: map[string][]string{
+				defaultRateLimitEndpoint: {"*|ip|1000-H"},
+			},
+		},
+		{
+			name: "endpoint rate limits keep the default rate limit",
+			spec: "/pb.Profiles/CreateProfile=ip:20-M,wallet:5-M; GET:/users/profiles/{wallet_address}=ip:10-S,ip:600-H",
+			expectedLimits: map[string][]string{
+				defaultRateLimitEndpoint:               {"*|ip|1000-H"},
+				"/pb.Profiles/CreateProfile":           {"/pb.Profiles/CreateProfile|ip|20-M", "/pb.Profiles/CreateProfile|wallet|5-M"},
+				"GET:/users/profiles/{wallet_address}": {"GET:/users/profiles/{wallet_address}|ip|10-S", "GET:/users/profiles/{wallet_address}|ip|600-H"},
 			},
-			expectedNumLimiters: 1,
 		},
 		{
-			name: "with additional endpoint rate limits",
-			rateLimits: map[string]rate{
-				defaultRateLimitIdentifier:     {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier},
-				"/pb.Profiles/ListAllProfiles": {Limit: 100, Period: time.Hour, Identifier: "gRPC List All Profiles"},
-				"GET:/users/profiles":          {Limit: 100, Period: time.Hour, Identifier: "HTTP List All Profiles"},
+			name: "overridden default rate limit",
+			spec: "*=ip:100-M,service:10000-H",
+			expectedLimits: map[string][]string{
+				defaultRateLimitEndpoint: {"*|ip|100-M", "*|service|10000-H"},
 			},
-			expectedNumLimiters: 3,
+		},
+		{
+			name:        "missing tiers",
+			spec:        "/pb.Profiles/CreateProfile",
+			expectError: true,
+		},
+		{
+			name:        "unsupported key",
+			spec:        "/pb.Profiles/CreateProfile=email:20-M",
+			expectError: true,
+		},
+		{
+			name:        "invalid rate",
+			spec:        "/pb.Profiles/CreateProfile=ip:20-W",
+			expectError: true,
+		},
+		{
+			name:        "duplicate endpoint",
+			spec:        "/pb.Profiles/CreateProfile=ip:20-M;/pb.Profiles/CreateProfile=ip:10-M",
+			expectError: true,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			store := memory.NewStore()
-
-			initialRateLimits := rateLimits
-			rateLimits = tc.rateLimits
-			defer func() {
-				rateLimits = initialRateLimits
-				limiters = make(map[string]*limiter.Limiter)
-			}()
+			limits, err := parseRateLimits(tc.spec)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
 
-			err := InitializeLimiters(store)
 			require.NoError(t, err)
-			require.Len(t, limiters, tc.expectedNumLimiters)
+
+			identifiers := make(map[string][]string)
+			for endpoint, rates := range limits {
+				for _, r := range rates {
+					identifiers[endpoint] = append(identifiers[endpoint], r.Identifier)
+				}
+			}
+			require.Equal(t, tc.expectedLimits, identifiers)
 		})
 	}
 }
 
-func TestGetEndpointRateLimit(t *testing.T) {
-	defaultRateLimit := rate{Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier}
-	testRateLimit := rate{Limit: 1000, Period: time.Hour, Identifier: "Test"}
-
+func TestNewRateLimiter(t *testing.T) {
 	testCases := []struct {
-		name              string
-		endpoint          string
-		rateLimits        map[string]rate
-		expectedRateLimit rate
+		name                string
+		spec                string
+		expectedNumLimiters int
 	}{
 		{
-			name:     "relies on default rate limit",
-			endpoint: "/test",
-			rateLimits: map[string]rate{
-				defaultRateLimitIdentifier: defaultRateLimit,
-			},
-			expectedRateLimit: defaultRateLimit,
+			name:                "only default rate limit",
+			spec:                "",
+			expectedNumLimiters: 1,
 		},
 		{
-			name:     "has a specific rate limit",
-			endpoint: "GET:/test",
-			rateLimits: map[string]rate{
-				defaultRateLimitIdentifier: defaultRateLimit,
-				"GET:/test":                testRateLimit,
-			},
-			expectedRateLimit: testRateLimit,
+			name:                "with additional endpoint rate limits",
+			spec:                "/pb.Profiles/ListAllProfiles=ip:100-H;GET:/users/profiles=ip:100-H,ip:10-S",
+			expectedNumLimiters: 4,
+		},
+		{
+			name:                "rate limits after a duplicate tier are initialized",
+			spec:                "*=ip:1000-H,ip:1000-H;/pb.Profiles/ListAllProfiles=ip:100-H",
+			expectedNumLimiters: 2,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			initialRateLimits := rateLimits
-			rateLimits = tc.rateLimits
-			defer func() {
-				rateLimits = initialRateLimits
-				limiters = make(map[string]*limiter.Limiter)
-			}()
-
-			rateLimit := getEndpointRateLimit(tc.endpoint)
-
-			require.Equal(t, tc.expectedRateLimit, rateLimit)
+			rl := newTestRateLimiter(t, tc.spec)
+			require.Len(t, rl.limiters, tc.expectedNumLimiters)
+
+			for _, rates := range rl.rateLimits {
+				for _, r := range rates {
+					_, err := rl.getLimiter(r)
+					require.NoError(t, err)
+				}
+			}
 		})
 	}
 }
 
-func TestGetLimiter(t *testing.T) {
-	testRateLimit := rate{Limit: 1000, Period: time.Hour, Identifier: "Test"}
-	uninitializedRateLimit := rate{Limit: 1000, Period: time.Hour, Identifier: "Uninitialized"}
+func TestGetEndpointRateLimits(t *testing.T) {
+	rl := newTestRateLimiter(t, "GET:/test=ip:10-M;GET:/test/{id}=ip:20-M;GET:/test/{id}/items=ip:30-M;GET:/test/special/items=ip:40-M;GET:/{resource}/{id}/items=ip:50-M")
+
+	testCases := []struct {
+		name               string
+		endpoint           string
+		expectedIdentifier string
+	}{
+		{
+			name:               "relies on default rate limit",
+			endpoint:           "/test",
+			expectedIdentifier: "*|ip|1000-H",
+		},
+		{
+			name:               "has a specific rate limit",
+			endpoint:           "GET:/test",
+			expectedIdentifier: "GET:/test|ip|10-M",
+		},
+		{
+			name:               "matches a path parameter",
+			endpoint:           "GET:/test/some-id",
+			expectedIdentifier: "GET:/test/{id}|ip|20-M",
+		},
+		{
+			name:               "exact endpoint takes precedence over patterns",
+			endpoint:           "GET:/test/special/items",
+			expectedIdentifier: "GET:/test/special/items|ip|40-M",
+		},
+		{
+			name:               "more specific pattern takes precedence",
+			endpoint:           "GET:/test/some-id/items",
+			expectedIdentifier: "GET:/test/{id}/items|ip|30-M",
+		},
+		{
+			name:               "less specific pattern",
+			endpoint:           "GET:/other/some-id/items",
+			expectedIdentifier: "GET:/{resource}/{id}/items|ip|50-M",
+		},
+		{
+			name:               "empty path parameter",
+			endpoint:           "GET:/test/",
+			expectedIdentifier: "*|ip|1000-H",
+		},
+		{
+			name:               "different method",
+			endpoint:           "POST:/test/some-id",
+			expectedIdentifier: "*|ip|1000-H",
+		},
+	}
 
-	initialRateLimits := rateLimits
-	rateLimits = map[string]rate{
-		"/test": testRateLimit,
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rates := rl.getEndpointRateLimits(tc.endpoint)
+			require.Len(t, rates, 1)
+			require.Equal(t, tc.expectedIdentifier, rates[0].Identifier)
+		})
 	}
-	defer func() {
-		rateLimits = initialRateLimits
-		limiters = make(map[string]*limiter.Limiter)
-	}()
+}
 
-	store := memory.NewStore()
+func TestGetLimiter(t *testing.T) {
+	rl := newTestRateLimiter(t, "/test=ip:1000-H")
 
-	err := InitializeLimiters(store)
-	require.NoError(t, err)
+	testRateLimit := rl.rateLimits["/test"][0]
+	uninitializedRateLimit := rate{Key: RateLimitKeyIP, Identifier: "Uninitialized"}
 
 	testCases := []struct {
 		name          string
@@ -139,7 +218,7 @@ func TestGetLimiter(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			l, err := getLimiter(tc.rateLimit)
+			l, err := rl.getLimiter(tc.rateLimit)
 			if !tc.expectLimiter {
 				require.Error(t, err)
 				return
@@ -160,13 +239,16 @@ func (m *mockUnaryHandler) mockHandle(_ context.Context, _ interface{}) (interfa
 	return m.resp, m.err
 }
 
-type mockServerTransportStream struct{}
+type mockServerTransportStream struct {
+	header metadata.MD
+}
 
 func (m *mockServerTransportStream) Method() string {
 	return "foo"
 }
 
-func (m *mockServerTransportStream) SetHeader(_ metadata.MD) error {
+func (m *mockServerTransportStream) SetHeader(md metadata.MD) error {
+	m.header = metadata.Join(m.header, md)
 	return nil
 }
 
@@ -181,72 +263,94 @@ func (m *mockServerTransportStream) SetTrailer(_ metadata.MD) error {
 func TestGrpcRateLimiter(t *testing.T) {
 	testCases := []struct {
 		name               string
-		rateLimits         map[string]rate
+		spec               string
+		uninitialized      bool
 		clientIP           string
+		callingService     string
 		limiterContext     limiter.Context
 		getLimiterCtxError error
 		expectedError      string
+		expectedKeys       []string
 	}{
 		{
 			name:           "valid request",
-			rateLimits:     map[string]rate{defaultRateLimitIdentifier: {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier}},
 			clientIP:       "127.0.0.1",
 			limiterContext: limiter.Context{Limit: 10, Remaining: 9, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: false},
 			expectedError:  "",
+			expectedKeys:   []string{"*|ip|1000-H:127.0.0.1"},
 		},
 		{
 			name:           "exceeded rate limit",
-			rateLimits:     map[string]rate{defaultRateLimitIdentifier: {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier}},
 			clientIP:       "127.0.0.1",
 			limiterContext: limiter.Context{Limit: 10, Remaining: 0, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: true},
 			expectedError:  RateLimitExceededError,
+			expectedKeys:   []string{"*|ip|1000-H:127.0.0.1"},
+		},
+		{
+			name:           "tiered rate limits",
+			spec:           "/test=ip:10-S,ip:100-H,wallet:10-M",
+			clientIP:       "127.0.0.1",
+			limiterContext: limiter.Context{Limit: 10, Remaining: 9, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: false},
+			expectedError:  "",
+			expectedKeys:   []string{"/test|ip|10-S:127.0.0.1", "/test|ip|100-H:127.0.0.1"},
 		},
 		{
 			name:           "could not get rate limiter",
-			rateLimits:     map[string]rate{},
+			uninitialized:  true,
 			clientIP:       "127.0.0.1",
 			limiterContext: limiter.Context{Limit: 10, Remaining: 9, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: false},
 			expectedError:  InternalServerError,
 		},
 		{
-			name:           "missing x-forwarded-for header",
-			rateLimits:     map[string]rate{defaultRateLimitIdentifier: {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier}},
+			name:           "unknown client IP",
 			clientIP:       "",
 			limiterContext: limiter.Context{Limit: 10, Remaining: 9, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: false},
-			expectedError:  MissingXForwardedForHeaderError,
+			expectedError:  UnknownClientIPError,
 		},
 		{
 			name:               "could not get limiter context",
-			rateLimits:         map[string]rate{defaultRateLimitIdentifier: {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier}},
 			clientIP:           "127.0.0.1",
 			limiterContext:     limiter.Context{Limit: 10, Remaining: 9, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: false},
 			getLimiterCtxError: errors.New("some error"),
 			expectedError:      InternalServerError,
 		},
+		{
+			name:           "authenticated service without service rate limit",
+			callingService: "some-service",
+			limiterContext: limiter.Context{Limit: 10, Remaining: 0, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: true},
+			expectedError:  "",
+		},
+		{
+			name:           "authenticated service with service rate limit",
+			spec:           "*=ip:1000-H,service:10000-H",
+			callingService: "some-service",
+			limiterContext: limiter.Context{Limit: 10, Remaining: 0, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: true},
+			expectedError:  RateLimitExceededError,
+			expectedKeys:   []string{"*|service|10000-H:some-service"},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Initialize default rate limiter
-			store := memory.NewStore()
-			initialRateLimits := rateLimits
-			rateLimits = tc.rateLimits
-			defer func() {
-				rateLimits = initialRateLimits
-				limiters = make(map[string]*limiter.Limiter)
-			}()
-			err := InitializeLimiters(store)
-			require.NoError(t, err)
+			rl := newTestRateLimiter(t, tc.spec)
+			if tc.uninitialized {
+				rl.limiters = make(map[string]*limiter.Limiter)
+			}
 
-			// add x-forwarded header to incoming context
-			ctx := grpc.NewContextWithServerTransportStream(context.Background(), &mockServerTransportStream{})
+			stream := &mockServerTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
 			if tc.clientIP != "" {
 				ctx = context.WithValue(ctx, ClientIP, tc.clientIP)
 			}
+			if tc.callingService != "" {
+				ctx = context.WithValue(ctx, AuthenticatedService, tc.callingService)
+			}
 			ctxWithHeader := metadata.NewIncomingContext(ctx, metadata.Pairs())
 
+			var keys []string
 			initialGetLimiterContext := getLimiterContext
 			getLimiterContext = func(ctx context.Context, l *limiter.Limiter, key string) (limiter.Context, error) {
+				keys = append(keys, key)
 				return tc.limiterContext, tc.getLimiterCtxError
 			}
 			defer func() {
@@ -258,10 +362,16 @@ func TestGrpcRateLimiter(t *testing.T) {
 				err:  nil,
 			}
 
-			_, err = GrpcRateLimiter(ctxWithHeader, nil, &grpc.UnaryServerInfo{
+			_, err := rl.GrpcRateLimiter(ctxWithHeader, nil, &grpc.UnaryServerInfo{
 				FullMethod: "/test",
 			}, mockHandler.mockHandle)
 
+			if tc.expectedKeys != nil {
+				require.Equal(t, tc.expectedKeys, keys)
+				require.Equal(t, []string{"10"}, stream.header.Get("x-ratelimit-limit"))
+				require.Equal(t, tc.limiterContext.Reached, len(stream.header.Get("retry-after")) > 0)
+			}
+
 			if tc.expectedError == "" {
 				require.NoError(t, err)
 				return
@@ -273,62 +383,150 @@ func TestGrpcRateLimiter(t *testing.T) {
 	}
 }
 
+func TestGrpcStreamRateLimiter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		clientIP       string
+		limiterContext limiter.Context
+		expectedError  string
+	}{
+		{
+			name:           "valid stream",
+			clientIP:       "127.0.0.1",
+			limiterContext: limiter.Context{Limit: 10, Remaining: 9, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: false},
+		},
+		{
+			name:           "exceeded rate limit",
+			clientIP:       "127.0.0.1",
+			limiterContext: limiter.Context{Limit: 10, Remaining: 0, Reset: time.Now().Add(1 * time.Minute).Unix(), Reached: true},
+			expectedError:  RateLimitExceededError,
+		},
+		{
+			name:          "unknown client IP",
+			expectedError: UnknownClientIPError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rl := newTestRateLimiter(t, "")
+
+			ctx := context.Background()
+			if tc.clientIP != "" {
+				ctx = context.WithValue(ctx, ClientIP, tc.clientIP)
+			}
+
+			var keys []string
+			initialGetLimiterContext := getLimiterContext
+			getLimiterContext = func(ctx context.Context, l *limiter.Limiter, key string) (limiter.Context, error) {
+				keys = append(keys, key)
+				return tc.limiterContext, nil
+			}
+			defer func() {
+				getLimiterContext = initialGetLimiterContext
+			}()
+
+			stream := &mockServerStream{ctx: ctx}
+			handlerCalled := false
+
+			err := rl.GrpcStreamRateLimiter(nil, stream, &grpc.StreamServerInfo{
+				FullMethod:     "/test",
+				IsServerStream: true,
+			}, func(_ any, stream grpc.ServerStream) error {
+				handlerCalled = true
+
+				// wallet addresses authorized by the handler are limited with the endpoint's rates
+				_, ok := stream.Context().Value(endpointRateLimitsKey{}).(*endpointRateLimits)
+				require.True(t, ok)
+
+				return nil
+			})
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				require.True(t, handlerCalled)
+				require.Equal(t, []string{"*|ip|1000-H:127.0.0.1"}, keys)
+				require.Equal(t, []string{"10"}, stream.header.Get("x-ratelimit-limit"))
+				return
+			}
+
+			require.ErrorContains(t, err, tc.expectedError)
+			require.False(t, handlerCalled)
+		})
+	}
+}
+
 func TestHTTPRateLimiter(t *testing.T) {
 	testCases := []struct {
 		name                 string
-		rateLimits           map[string]rate
+		spec                 string
+		uninitialized        bool
 		clientIP             string
 		expectedResponseCode int
+		expectedHeaders      map[string]string
 	}{
 		{
 			name:                 "valid request",
-			rateLimits:           map[string]rate{defaultRateLimitIdentifier: {Limit: 1000, Period: time.Hour, Identifier: defaultRateLimitIdentifier}},
 			clientIP:             "127.0.0.1",
 			expectedResponseCode: http.StatusOK,
+			expectedHeaders:      map[string]string{"X-RateLimit-Limit": "1000", "X-RateLimit-Remaining": "999"},
 		},
 		{
 			name:                 "exceeded rate limit",
-			rateLimits:           map[string]rate{defaultRateLimitIdentifier: {Limit: 0, Period: time.Hour, Identifier: defaultRateLimitIdentifier}},
+			spec:                 "*=ip:0-H",
 			clientIP:             "127.0.0.1",
 			expectedResponseCode: http.StatusTooManyRequests,
+			expectedHeaders:      map[string]string{"X-RateLimit-Limit": "0", "X-RateLimit-Remaining": "0"},
+		},
+		{
+			name:                 "exceeded burst rate limit",
+			spec:                 "GET:/test/{id}=ip:0-S,ip:1000-H",
+			clientIP:             "127.0.0.1",
+			expectedResponseCode: http.StatusTooManyRequests,
+			expectedHeaders:      map[string]string{"X-RateLimit-Limit": "0", "X-RateLimit-Remaining": "0"},
 		},
 		{
 			name:                 "could not get rate limiter",
-			rateLimits:           map[string]rate{},
+			uninitialized:        true,
 			clientIP:             "127.0.0.1",
 			expectedResponseCode: http.StatusInternalServerError,
 		},
+		{
+			name:                 "unknown client IP",
+			expectedResponseCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Initialize default rate limiter
-			store := memory.NewStore()
-			initialRateLimits := rateLimits
-			rateLimits = tc.rateLimits
-			defer func() {
-				rateLimits = initialRateLimits
-				limiters = make(map[string]*limiter.Limiter)
-			}()
-			err := InitializeLimiters(store)
-			require.NoError(t, err)
+			rl := newTestRateLimiter(t, tc.spec)
+			if tc.uninitialized {
+				rl.limiters = make(map[string]*limiter.Limiter)
+			}
 
-			req, err := http.NewRequest("GET", "/test", nil)
+			req, err := http.NewRequest("GET", "/test/some-id", nil)
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			req.Header.Set(xForwardedForHeader, tc.clientIP)
+			if tc.clientIP != "" {
+				req = req.WithContext(context.WithValue(req.Context(), ClientIP, tc.clientIP))
+			}
 
 			res := httptest.NewRecorder()
 
-			HTTPRateLimiter(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			rl.HTTPRateLimiter(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 				res.WriteHeader(http.StatusOK)
 				_, err := res.Write([]byte("OK"))
 				require.NoError(t, err)
 			})).ServeHTTP(res, req)
 
 			require.Equal(t, tc.expectedResponseCode, res.Code)
+
+			for header, value := range tc.expectedHeaders {
+				require.Equal(t, value, res.Header().Get(header))
+			}
+			require.Equal(t, tc.expectedResponseCode == http.StatusTooManyRequests, res.Header().Get("Retry-After") != "")
 		})
 	}
 }
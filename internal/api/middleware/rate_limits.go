@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ulule/limiter/v3"
+)
+
+// rateLimitKey is what requests are counted by.
+type rateLimitKey string
+
+const (
+	// RateLimitKeyIP counts the requests of a client IP. Authenticated services are not limited by it.
+	RateLimitKeyIP rateLimitKey = "ip"
+	// RateLimitKeyWallet counts the requests of a wallet address once the caller has been authorized
+	// for it, so endpoints that do not authorize users are not limited by it.
+	RateLimitKeyWallet rateLimitKey = "wallet"
+	// RateLimitKeyService counts the requests of an authenticated calling service.
+	RateLimitKeyService rateLimitKey = "service"
+)
+
+const (
+	// defaultRateLimitEndpoint holds the rate limits of the endpoints without limits of their own.
+	// Its limits are shared by all of those endpoints collectively.
+	defaultRateLimitEndpoint = "*"
+	defaultRateLimits        = defaultRateLimitEndpoint + "=ip:1000-H"
+
+	rateLimitEndpointSeparator = ";"
+	rateLimitTierSeparator     = ","
+)
+
+type rate struct {
+	Key        rateLimitKey
+	Rate       limiter.Rate
+	Identifier string
+}
+
+// parseRateLimits reads the rate limits of each endpoint from a spec such as
+//
+//	*=ip:1000-H;/pb.Profiles/CreateProfile=ip:20-M,wallet:5-M;GET:/users/profiles/{wallet_address}=ip:10-S,ip:600-H
+//
+// Endpoints are gRPC full methods or HTTP "METHOD:path" patterns, where a "{name}" segment matches
+// any path parameter. An endpoint may have several tiers of limits, all of which must be within
+// their limit for a request to pass, so a tier with a short period caps bursts while one with a
+// long period caps sustained use. Rates are formatted as "<limit>-<S|M|H|D>".
+func parseRateLimits(spec string) (map[string][]rate, error) {
+	rateLimits := make(map[string][]rate)
+
+	for _, entry := range strings.Split(spec, rateLimitEndpointSeparator) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		endpoint, tiers, found := strings.Cut(entry, "=")
+		endpoint = strings.TrimSpace(endpoint)
+		if !found || endpoint == "" {
+			return nil, fmt.Errorf("invalid rate limit %q: expected <endpoint>=<tiers>", entry)
+		}
+
+		if _, exists := rateLimits[endpoint]; exists {
+			return nil, fmt.Errorf("duplicate rate limit for endpoint %s", endpoint)
+		}
+
+		for _, tier := range strings.Split(tiers, rateLimitTierSeparator) {
+			r, err := parseRate(endpoint, strings.TrimSpace(tier))
+			if err != nil {
+				return nil, err
+			}
+
+			rateLimits[endpoint] = append(rateLimits[endpoint], r)
+		}
+	}
+
+	// endpoints keep the default limits unless the spec overrides them
+	if _, exists := rateLimits[defaultRateLimitEndpoint]; !exists {
+		defaults, err := parseRateLimits(defaultRateLimits)
+		if err != nil {
+			return nil, err
+		}
+
+		rateLimits[defaultRateLimitEndpoint] = defaults[defaultRateLimitEndpoint]
+	}
+
+	return rateLimits, nil
+}
+
+func parseRate(endpoint string, tier string) (rate, error) {
+	key, formatted, found := strings.Cut(tier, ":")
+	if !found {
+		return rate{}, fmt.Errorf("invalid rate limit %q for endpoint %s: expected <key>:<rate>", tier, endpoint)
+	}
+
+	switch rateLimitKey(key) {
+	case RateLimitKeyIP, RateLimitKeyWallet, RateLimitKeyService:
+	default:
+		return rate{}, fmt.Errorf("unsupported rate limit key %q for endpoint %s", key, endpoint)
+	}
+
+	limiterRate, err := limiter.NewRateFromFormatted(formatted)
+	if err != nil {
+		return rate{}, fmt.Errorf("invalid rate %q for endpoint %s: %w", formatted, endpoint, err)
+	}
+
+	return rate{
+		Key:        rateLimitKey(key),
+		Rate:       limiterRate,
+		Identifier: fmt.Sprintf("%s|%s|%s", endpoint, key, formatted),
+	}, nil
+}
+
+// getEndpointRateLimits returns the rate limits of a gRPC full method or an HTTP "METHOD:path".
+// Exact endpoints take precedence over patterns, and patterns with more literal segments take
+// precedence over ones with fewer.
+func (rl *RateLimiter) getEndpointRateLimits(endpoint string) []rate {
+	if rates, exists := rl.rateLimits[endpoint]; exists {
+		return rates
+	}
+
+	for _, pattern := range rl.rateLimitPatterns {
+		if matchEndpointPattern(pattern, endpoint) {
+			return rl.rateLimits[pattern]
+		}
+	}
+
+	return rl.rateLimits[defaultRateLimitEndpoint]
+}
+
+// sortedRateLimitPatterns lists the endpoints with path parameters, most specific first.
+func sortedRateLimitPatterns(rateLimits map[string][]rate) []string {
+	var patterns []string
+	for endpoint := range rateLimits {
+		if strings.Contains(endpoint, "{") {
+			patterns = append(patterns, endpoint)
+		}
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		li, lj := literalSegments(patterns[i]), literalSegments(patterns[j])
+		if li != lj {
+			return li > lj
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	return patterns
+}
+
+func literalSegments(pattern string) int {
+	count := 0
+	for _, segment := range strings.Split(pattern, "/") {
+		if !isPathParameter(segment) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func matchEndpointPattern(pattern string, endpoint string) bool {
+	patternSegments := strings.Split(pattern, "/")
+	endpointSegments := strings.Split(endpoint, "/")
+
+	if len(patternSegments) != len(endpointSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if isPathParameter(segment) {
+			if endpointSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+
+		if segment != endpointSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isPathParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
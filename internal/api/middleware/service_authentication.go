@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+
+	authMiddleware "github.com/kyamalabs/auth/pkg/middleware"
+	"google.golang.org/grpc"
+)
+
+// GrpcStreamAuthenticateService authenticates the calling service of a stream the way
+// AuthenticateServiceGrpc does for unary calls, which the auth package has no stream variant of.
+func GrpcStreamAuthenticateService(config *authMiddleware.AuthenticateServiceConfig) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		_, err := config.AuthenticateServiceGrpc(stream.Context(), nil, nil, func(ctx context.Context, _ any) (interface{}, error) {
+			return nil, handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+		})
+
+		return err
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	authMiddleware "github.com/kyamalabs/auth/pkg/middleware"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestGrpcStreamAuthenticateService(t *testing.T) {
+	interceptor := GrpcStreamAuthenticateService(&authMiddleware.AuthenticateServiceConfig{})
+
+	handlerErr := errors.New("some handler error")
+	ctx := context.WithValue(context.Background(), ClientIP, "127.0.0.1")
+
+	handlerCalled := false
+	err := interceptor(nil, &mockServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/test"}, func(_ any, stream grpc.ServerStream) error {
+		handlerCalled = true
+
+		// streams without a service authentication are handled unauthenticated
+		require.Nil(t, stream.Context().Value(AuthenticatedService))
+		require.Equal(t, "127.0.0.1", stream.Context().Value(ClientIP))
+
+		return handlerErr
+	})
+
+	require.True(t, handlerCalled)
+	require.ErrorIs(t, err, handlerErr)
+}
@@ -14,6 +14,9 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// AuthorizeUser verifies that the caller holds an access token for the wallet address, then
+// counts the request against the wallet rate limits of the endpoint. ErrRateLimitExceeded is
+// returned once those are exhausted.
 func AuthorizeUser(ctx context.Context, walletAddress string, authService services.AuthGrpcService) (*pb.AccessTokenPayload, error) {
 	mtdt, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -40,10 +43,15 @@ func AuthorizeUser(ctx context.Context, walletAddress string, authService servic
 	payload := &pb.VerifyAccessTokenRequest{
 		WalletAddress: walletAddress,
 	}
-	response, err := authService.VerifyAccessToken(payload, accessToken)
+	response, err := authService.VerifyAccessToken(ctx, payload, accessToken)
 	if err != nil {
 		return nil, fmt.Errorf("could not verify access token: %w", err)
 	}
 
+	err = limitWallet(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	return response.GetPayload(), nil
 }
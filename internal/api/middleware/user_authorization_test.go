@@ -9,6 +9,7 @@ import (
 	"github.com/kyamalabs/proto/proto/auth/pb"
 
 	"github.com/kyamalabs/users/internal/constants"
+	"github.com/kyamalabs/users/internal/metrics"
 	mockservices "github.com/kyamalabs/users/internal/services/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -39,7 +40,7 @@ func TestAuthorizeUser(t *testing.T) {
 				}
 
 				authService.EXPECT().
-					VerifyAccessToken(expectedPayload, "some-dummy-access-token").
+					VerifyAccessToken(gomock.Any(), expectedPayload, "some-dummy-access-token").
 					Times(1).
 					Return(response, nil)
 			},
@@ -140,7 +141,7 @@ func TestAuthorizeUser(t *testing.T) {
 				}
 
 				authService.EXPECT().
-					VerifyAccessToken(expectedPayload, "some-dummy-access-token").
+					VerifyAccessToken(gomock.Any(), expectedPayload, "some-dummy-access-token").
 					Times(1).
 					Return(nil, errors.New("invalid token error"))
 			},
@@ -176,3 +177,64 @@ func TestAuthorizeUser(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizeUser_WalletRateLimit(t *testing.T) {
+	walletAddress := "0xc0ffee254729296a45a3885639AC7E10F9d54979"
+
+	testCases := []struct {
+		name        string
+		spec        string
+		expectedErr error
+	}{
+		{
+			name: "endpoint without wallet rate limit",
+			spec: "/test=ip:1000-H",
+		},
+		{
+			name: "within wallet rate limit",
+			spec: "/test=ip:1000-H,wallet:10-M",
+		},
+		{
+			name:        "exceeded wallet rate limit",
+			spec:        "/test=ip:1000-H,wallet:0-M",
+			expectedErr: ErrRateLimitExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rl := newTestRateLimiter(t, tc.spec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+			authService.EXPECT().
+				VerifyAccessToken(gomock.Any(), gomock.Any(), "some-dummy-access-token").
+				Times(1).
+				Return(&pb.VerifyAccessTokenResponse{Payload: &pb.AccessTokenPayload{WalletAddress: walletAddress}}, nil)
+
+			md := metadata.MD{
+				constants.AuthorizationHeader: []string{
+					fmt.Sprintf("%s %s", constants.AuthorizationBearer, "some-dummy-access-token"),
+				},
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			ctx = context.WithValue(ctx, endpointRateLimitsKey{}, &endpointRateLimits{
+				limiter:  rl,
+				protocol: metrics.ProtocolGrpc,
+				rates:    rl.getEndpointRateLimits("/test"),
+			})
+
+			payload, err := AuthorizeUser(ctx, walletAddress, authService)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, payload)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, walletAddress, payload.GetWalletAddress())
+		})
+	}
+}
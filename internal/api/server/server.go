@@ -2,66 +2,91 @@ package server
 
 import (
 	"fmt"
-	"sync"
 
+	"github.com/kyamalabs/users/internal/api/handler/profile"
 	"github.com/kyamalabs/users/internal/api/handler/referral"
-
-	"github.com/kyamalabs/users/internal/cache"
-
-	"github.com/kyamalabs/users/internal/worker"
-
+	"github.com/kyamalabs/users/internal/api/handler/task"
+	"github.com/kyamalabs/users/internal/api/handler/webhook"
 	"github.com/kyamalabs/users/internal/api/middleware"
-	"github.com/ulule/limiter/v3"
-
-	"github.com/kyamalabs/users/internal/api/handler/profile"
+	"github.com/kyamalabs/users/internal/cache"
 	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	"github.com/kyamalabs/users/internal/services"
 	"github.com/kyamalabs/users/internal/util"
+	"github.com/kyamalabs/users/internal/worker"
 )
 
 type Server struct {
 	ProfileHandler  profile.Handler
 	ReferralHandler referral.Handler
+	WebhookHandler  webhook.Handler
+	TaskHandler     task.Handler
+	// RateLimiter is shared by the gRPC and HTTP transports.
+	RateLimiter *middleware.RateLimiter
 }
 
-var once sync.Once
-
-func NewServer(config util.Config, cache cache.Cache, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	authService, err := services.NewAuthServiceGrpcClient(config.AuthServiceGRPCServerAddress, config.ServiceAuthPrivateKeys)
+func NewServer(config util.Config, cache cache.Cache, store db.Store, taskDistributor worker.TaskDistributor, profileWatcher event.ProfileWatcher, inspector worker.DeadLetterInspector, authService services.AuthGrpcService) (*Server, error) {
+	verifier, err := newAccessTokenVerifier(config, cache, authService)
 	if err != nil {
-		return nil, fmt.Errorf("could not create auth service gRPC client: %w", err)
+		return nil, err
 	}
 
-	err = setupRateLimiter(config.RedisConnURL)
+	rateLimiter, err := setupRateLimiter(config)
 	if err != nil {
 		return nil, err
 	}
 
 	server := &Server{
-		ProfileHandler:  profile.NewHandler(config, cache, store, taskDistributor, authService),
+		ProfileHandler:  profile.NewHandler(config, cache, store, taskDistributor, verifier, profileWatcher),
 		ReferralHandler: referral.NewHandler(config, store),
+		WebhookHandler:  webhook.NewHandler(config, store, taskDistributor, verifier),
+		TaskHandler:     task.NewHandler(config, inspector, verifier),
+		RateLimiter:     rateLimiter,
 	}
 
 	return server, nil
 }
 
-func setupRateLimiter(redisConnURL string) error {
-	var store limiter.Store
-	var createLimiterRedisStoreErr, initializeLimitersErr error
+// newAccessTokenVerifier verifies access tokens locally or through the auth service depending on
+// config.AccessTokenVerification, caching the verifications when config.AccessTokenCacheMaxTTL is set.
+func newAccessTokenVerifier(config util.Config, cache cache.Cache, authService services.AuthGrpcService) (services.AuthGrpcService, error) {
+	verifier := authService
 
-	once.Do(func() {
-		store, createLimiterRedisStoreErr = middleware.CreateLimiterRedisStore(redisConnURL)
-		if createLimiterRedisStoreErr == nil {
-			initializeLimitersErr = middleware.InitializeLimiters(store)
+	switch config.AccessTokenVerification {
+	case services.AccessTokenVerificationLocal:
+		localAuthService, err := services.NewLocalAuthService(authService, cache, config.AccessTokenRevocationTTL, config.AccessTokenPublicKeys, config.AccessTokenPublicKeysURL, config.AccessTokenPublicKeysRefresh)
+		if err != nil {
+			return nil, fmt.Errorf("could not create local access token verifier: %w", err)
 		}
-	})
+		verifier = localAuthService
+	case services.AccessTokenVerificationRemote, "":
+	default:
+		return nil, fmt.Errorf("unsupported access token verification: %s", config.AccessTokenVerification)
+	}
 
-	if createLimiterRedisStoreErr != nil {
-		return fmt.Errorf("could not create limiter redis client: %w", createLimiterRedisStoreErr)
+	if config.AccessTokenCacheMaxTTL > 0 {
+		verifier = services.NewCachedAuthService(verifier, cache, config.AccessTokenCacheMaxTTL)
 	}
-	if initializeLimitersErr != nil {
-		return fmt.Errorf("could not initialize rate limiters: %w", initializeLimitersErr)
+
+	return verifier, nil
+}
+
+// StopStreams ends the streaming RPCs being served, which would otherwise keep a graceful stop
+// waiting until their clients disconnect.
+func (server *Server) StopStreams() {
+	server.ProfileHandler.StopWatches()
+}
+
+func setupRateLimiter(config util.Config) (*middleware.RateLimiter, error) {
+	err := middleware.SetTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("could not set trusted proxies: %w", err)
+	}
+
+	rateLimiter, err := middleware.NewRedisRateLimiter(config.RedisConnURL, config.RateLimits)
+	if err != nil {
+		return nil, fmt.Errorf("could not create rate limiter: %w", err)
 	}
 
-	return nil
+	return rateLimiter, nil
 }
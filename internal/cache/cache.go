@@ -2,11 +2,47 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"time"
+
+	"github.com/kyamalabs/users/internal/util"
+)
+
+const (
+	BackendRedis   = "redis"
+	BackendMemory  = "memory"
+	BackendTwoTier = "two_tier"
 )
 
 type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	// SetNX sets the value only if the key does not exist yet and reports whether it did.
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string) (interface{}, error)
 	Del(ctx context.Context, key string) error
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	MGet(ctx context.Context, keys ...string) ([]interface{}, error)
+	MSet(ctx context.Context, values map[string]interface{}, expiration time.Duration) error
+}
+
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// NewCache builds the cache backend selected by config.CacheBackend, defaulting to redis.
+func NewCache(config util.Config) (Cache, error) {
+	switch config.CacheBackend {
+	case BackendMemory:
+		return NewMemoryCache(config.CacheLocalCapacity), nil
+	case BackendTwoTier:
+		remote, err := NewRedisCache(config.RedisConnURL)
+		if err != nil {
+			return nil, err
+		}
+		return NewTwoTierCache(remote.(*RedisCache), config.CacheLocalCapacity, config.CacheLocalTTL), nil
+	case BackendRedis, "":
+		return NewRedisCache(config.RedisConnURL)
+	}
+
+	return nil, fmt.Errorf("unsupported cache backend: %s", config.CacheBackend)
 }
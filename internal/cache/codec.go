@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec converts between a typed value and the representation handed to a Cache.
+type Codec[T any] interface {
+	Encode(value T) (interface{}, error)
+	Decode(value interface{}) (T, error)
+}
+
+// JSONCodec stores values as JSON documents, which keeps entries readable by untyped callers.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(value T) (interface{}, error) {
+	return value, nil
+}
+
+func (JSONCodec[T]) Decode(value interface{}) (T, error) {
+	var decoded T
+
+	if typed, ok := value.(T); ok {
+		return typed, nil
+	}
+
+	serializedValue, err := json.Marshal(value)
+	if err != nil {
+		return decoded, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	err = json.Unmarshal(serializedValue, &decoded)
+	if err != nil {
+		return decoded, fmt.Errorf("could not deserialize value: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// ProtoCodec stores protobuf messages in their binary wire format.
+type ProtoCodec[T proto.Message] struct{}
+
+func (ProtoCodec[T]) Encode(value T) (interface{}, error) {
+	serializedValue, err := proto.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not serialize protobuf message: %w", err)
+	}
+
+	return serializedValue, nil
+}
+
+func (ProtoCodec[T]) Decode(value interface{}) (T, error) {
+	var zero T
+
+	serializedValue, err := decodeBytes(value)
+	if err != nil {
+		return zero, err
+	}
+
+	decoded, ok := zero.ProtoReflect().New().Interface().(T)
+	if !ok {
+		return zero, fmt.Errorf("could not instantiate protobuf message of type %T", zero)
+	}
+
+	err = proto.Unmarshal(serializedValue, decoded)
+	if err != nil {
+		return zero, fmt.Errorf("could not deserialize protobuf message: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// MsgpackCodec stores values in the compact MessagePack format.
+type MsgpackCodec[T any] struct{}
+
+func (MsgpackCodec[T]) Encode(value T) (interface{}, error) {
+	serializedValue, err := msgpack.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	return serializedValue, nil
+}
+
+func (MsgpackCodec[T]) Decode(value interface{}) (T, error) {
+	var decoded T
+
+	serializedValue, err := decodeBytes(value)
+	if err != nil {
+		return decoded, err
+	}
+
+	err = msgpack.Unmarshal(serializedValue, &decoded)
+	if err != nil {
+		return decoded, fmt.Errorf("could not deserialize value: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// decodeBytes accepts binary payloads either as-is or base64 encoded, which is how
+// byte slices come back from caches that serialize values as JSON.
+func decodeBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		decoded, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode binary value: %w", err)
+		}
+		return decoded, nil
+	}
+
+	return nil, fmt.Errorf("unexpected binary value of type %T", value)
+}
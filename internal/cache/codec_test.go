@@ -0,0 +1,108 @@
+package cache_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/cache"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type testValue struct {
+	Name  string `json:"name" msgpack:"name"`
+	Count int    `json:"count" msgpack:"count"`
+}
+
+func TestJSONCodec(t *testing.T) {
+	codec := cache.JSONCodec[testValue]{}
+
+	testCases := []struct {
+		name          string
+		cachedValue   interface{}
+		checkResponse func(t *testing.T, value testValue, err error)
+	}{
+		{
+			name:        "typed value",
+			cachedValue: testValue{Name: "bulba", Count: 3},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testValue{Name: "bulba", Count: 3}, value)
+			},
+		},
+		{
+			name:        "json decoded value",
+			cachedValue: map[string]interface{}{"name": "bulba", "count": float64(3)},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testValue{Name: "bulba", Count: 3}, value)
+			},
+		},
+		{
+			name:        "mismatched value",
+			cachedValue: "bulba",
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := codec.Decode(tc.cachedValue)
+			tc.checkResponse(t, value, err)
+		})
+	}
+}
+
+func TestProtoCodec(t *testing.T) {
+	codec := cache.ProtoCodec[*pb.PublicProfile]{}
+
+	profile := &pb.PublicProfile{
+		WalletAddress: "0xc0ffee254729296a45a3885639AC7E10F9d54979",
+		GamerTag:      "bulba",
+		EnsName:       "bulba.eth",
+		CreatedAt:     timestamppb.New(time.Now().UTC().Truncate(time.Second)),
+		EnsStatus:     pb.EnsStatus_ENS_STATUS_RESOLVED,
+	}
+
+	encodedValue, err := codec.Encode(profile)
+	require.NoError(t, err)
+
+	serializedValue, ok := encodedValue.([]byte)
+	require.True(t, ok)
+
+	// redis caches hand binary values back as base64 strings after a JSON round trip
+	for _, cachedValue := range []interface{}{serializedValue, base64.StdEncoding.EncodeToString(serializedValue)} {
+		decodedValue, err := codec.Decode(cachedValue)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(profile, decodedValue))
+	}
+
+	_, err = codec.Decode(42)
+	require.Error(t, err)
+}
+
+func TestMsgpackCodec(t *testing.T) {
+	codec := cache.MsgpackCodec[testValue]{}
+
+	value := testValue{Name: "bulba", Count: 3}
+
+	encodedValue, err := codec.Encode(value)
+	require.NoError(t, err)
+
+	serializedValue, ok := encodedValue.([]byte)
+	require.True(t, ok)
+
+	for _, cachedValue := range []interface{}{serializedValue, base64.StdEncoding.EncodeToString(serializedValue)} {
+		decodedValue, err := codec.Decode(cachedValue)
+		require.NoError(t, err)
+		require.Equal(t, value, decodedValue)
+	}
+
+	_, err = codec.Decode("not base64!")
+	require.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultMemoryCacheCapacity = 10000
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process cache that evicts the least recently used entry once it
+// is full. Values are stored as JSON so that reads behave exactly like RedisCache.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity <= 0 {
+		capacity = defaultMemoryCacheCapacity
+	}
+
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (mc *MemoryCache) Set(_ context.Context, key string, value interface{}, expiration time.Duration) error {
+	serializedValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.set(key, serializedValue, expiration)
+
+	return nil
+}
+
+func (mc *MemoryCache) SetNX(_ context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	serializedValue, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if mc.get(key) != nil {
+		return false, nil
+	}
+	mc.set(key, serializedValue, expiration)
+
+	return true, nil
+}
+
+func (mc *MemoryCache) Get(_ context.Context, key string) (interface{}, error) {
+	mc.mu.Lock()
+	entry := mc.get(key)
+	mc.mu.Unlock()
+
+	if entry == nil {
+		return nil, nil
+	}
+
+	return deserialize(string(entry.value))
+}
+
+func (mc *MemoryCache) MGet(_ context.Context, keys ...string) ([]interface{}, error) {
+	serializedValues := make([][]byte, len(keys))
+
+	mc.mu.Lock()
+	for i, key := range keys {
+		if entry := mc.get(key); entry != nil {
+			serializedValues[i] = entry.value
+		}
+	}
+	mc.mu.Unlock()
+
+	values := make([]interface{}, len(keys))
+	for i, serializedValue := range serializedValues {
+		if serializedValue == nil {
+			continue
+		}
+
+		value, err := deserialize(string(serializedValue))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+func (mc *MemoryCache) MSet(_ context.Context, values map[string]interface{}, expiration time.Duration) error {
+	serializedValues := make(map[string][]byte, len(values))
+	for key, value := range values {
+		serializedValue, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("could not serialize value: %w", err)
+		}
+		serializedValues[key] = serializedValue
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for key, serializedValue := range serializedValues {
+		mc.set(key, serializedValue, expiration)
+	}
+
+	return nil
+}
+
+func (mc *MemoryCache) Del(_ context.Context, key string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if mc.get(key) == nil {
+		return Nil
+	}
+	mc.remove(mc.entries[key])
+
+	return nil
+}
+
+// TTL mirrors redis by returning a negative duration for entries that never expire.
+func (mc *MemoryCache) TTL(_ context.Context, key string) (time.Duration, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry := mc.get(key)
+	if entry == nil {
+		return 0, Nil
+	}
+	if entry.expiresAt.IsZero() {
+		return -1, nil
+	}
+
+	return entry.expiresAt.Sub(mc.now()), nil
+}
+
+// get returns the live entry for key, dropping it if it has expired. mu must be held.
+func (mc *MemoryCache) get(key string) *memoryCacheEntry {
+	element, ok := mc.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry := element.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && !mc.now().Before(entry.expiresAt) {
+		mc.remove(element)
+		return nil
+	}
+
+	mc.order.MoveToFront(element)
+
+	return entry
+}
+
+// set stores a serialized value, evicting the least recently used entries when full. mu must be held.
+func (mc *MemoryCache) set(key string, value []byte, expiration time.Duration) {
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = mc.now().Add(expiration)
+	}
+
+	if element, ok := mc.entries[key]; ok {
+		entry := element.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		mc.order.MoveToFront(element)
+		return
+	}
+
+	mc.entries[key] = mc.order.PushFront(&memoryCacheEntry{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	for mc.order.Len() > mc.capacity {
+		mc.remove(mc.order.Back())
+	}
+}
+
+func (mc *MemoryCache) remove(element *list.Element) {
+	entry := mc.order.Remove(element).(*memoryCacheEntry)
+	delete(mc.entries, entry.key)
+}
+
+// invalidate drops keys without reporting whether they existed.
+func (mc *MemoryCache) invalidate(keys ...string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := mc.entries[key]; ok {
+			mc.remove(element)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache_GetSet(t *testing.T) {
+	cache := NewMemoryCache(10)
+
+	testCases := []struct {
+		name       string
+		key        string
+		value      interface{}
+		checkValue func(t *testing.T, res interface{}, err error)
+	}{
+		{
+			name:  "Success - nil",
+			key:   "test_key:get:nil",
+			value: nil,
+			checkValue: func(t *testing.T, res interface{}, err error) {
+				require.NoError(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name:  "Success - string",
+			key:   "test_key:get:string",
+			value: "test_val",
+			checkValue: func(t *testing.T, res interface{}, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "test_val", res)
+			},
+		},
+		{
+			name:  "Success - int",
+			key:   "test_key:get:int",
+			value: 420,
+			checkValue: func(t *testing.T, res interface{}, err error) {
+				require.NoError(t, err)
+				require.Equal(t, float64(420), res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := cache.Set(context.Background(), tc.key, tc.value, 30*time.Second)
+			require.NoError(t, err)
+
+			val, err := cache.Get(context.Background(), tc.key)
+			tc.checkValue(t, val, err)
+		})
+	}
+}
+
+func TestMemoryCache_Expiration(t *testing.T) {
+	now := time.Now()
+
+	cache := NewMemoryCache(10)
+	cache.now = func() time.Time { return now }
+
+	err := cache.Set(context.Background(), "expiring", "test_val", time.Minute)
+	require.NoError(t, err)
+	err = cache.Set(context.Background(), "persistent", "test_val", 0)
+	require.NoError(t, err)
+
+	ttl, err := cache.TTL(context.Background(), "expiring")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, ttl)
+
+	ttl, err = cache.TTL(context.Background(), "persistent")
+	require.NoError(t, err)
+	require.Negative(t, ttl)
+
+	now = now.Add(time.Minute)
+
+	val, err := cache.Get(context.Background(), "expiring")
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	_, err = cache.TTL(context.Background(), "expiring")
+	require.Equal(t, Nil, err)
+
+	val, err = cache.Get(context.Background(), "persistent")
+	require.NoError(t, err)
+	require.Equal(t, "test_val", val)
+}
+
+func TestMemoryCache_Eviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+
+	require.NoError(t, cache.Set(context.Background(), "a", "a", 0))
+	require.NoError(t, cache.Set(context.Background(), "b", "b", 0))
+
+	// reading a makes b the least recently used entry
+	_, err := cache.Get(context.Background(), "a")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set(context.Background(), "c", "c", 0))
+
+	values, err := cache.MGet(context.Background(), "a", "b", "c")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", nil, "c"}, values)
+}
+
+func TestMemoryCache_Del(t *testing.T) {
+	cache := NewMemoryCache(10)
+
+	require.NoError(t, cache.MSet(context.Background(), map[string]interface{}{"a": 1, "b": 2}, time.Minute))
+
+	require.NoError(t, cache.Del(context.Background(), "a"))
+	require.Equal(t, Nil, cache.Del(context.Background(), "a"))
+
+	values, err := cache.MGet(context.Background(), "a", "b")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{nil, float64(2)}, values)
+}
+
+func TestMemoryCache_SetNX(t *testing.T) {
+	cache := NewMemoryCache(10)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	set, err := cache.SetNX(context.Background(), "key", "first", time.Minute)
+	require.NoError(t, err)
+	require.True(t, set)
+
+	set, err = cache.SetNX(context.Background(), "key", "second", time.Minute)
+	require.NoError(t, err)
+	require.False(t, set)
+
+	val, err := cache.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, "first", val)
+
+	// expired keys can be set again
+	now = now.Add(time.Minute)
+
+	set, err = cache.SetNX(context.Background(), "key", "third", time.Minute)
+	require.NoError(t, err)
+	require.True(t, set)
+}
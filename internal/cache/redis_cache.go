@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyamalabs/users/internal/tracing"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -21,6 +22,20 @@ func (rc *RedisCache) Set(ctx context.Context, key string, value interface{}, ex
 	return rc.client.Set(ctx, key, serializedValue, expiration).Err()
 }
 
+func (rc *RedisCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	serializedValue, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("could not serialize value: %w", err)
+	}
+
+	set, err := rc.client.SetNX(ctx, key, serializedValue, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("could not set value: %w", err)
+	}
+
+	return set, nil
+}
+
 func (rc *RedisCache) Get(ctx context.Context, key string) (interface{}, error) {
 	res, err := rc.client.Get(ctx, key).Result()
 	if err == redis.Nil {
@@ -29,8 +44,20 @@ func (rc *RedisCache) Get(ctx context.Context, key string) (interface{}, error)
 		return nil, fmt.Errorf("could not get value: %w", err)
 	}
 
+	return deserialize(res)
+}
+
+func (rc *RedisCache) Ping(ctx context.Context) error {
+	return rc.client.Ping(ctx).Err()
+}
+
+func (rc *RedisCache) Close() error {
+	return rc.client.Close()
+}
+
+func deserialize(value string) (interface{}, error) {
 	var deserializedValue interface{}
-	err = json.Unmarshal([]byte(res), &deserializedValue)
+	err := json.Unmarshal([]byte(value), &deserializedValue)
 	if err != nil {
 		return nil, fmt.Errorf("could not deserialize value: %w", err)
 	}
@@ -38,6 +65,52 @@ func (rc *RedisCache) Get(ctx context.Context, key string) (interface{}, error)
 	return deserializedValue, nil
 }
 
+// MGet returns the values of keys in order, with nil entries for keys that do not exist.
+func (rc *RedisCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	if len(keys) == 0 {
+		return []interface{}{}, nil
+	}
+
+	res, err := rc.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("could not get values: %w", err)
+	}
+
+	values := make([]interface{}, len(res))
+	for i, value := range res {
+		serializedValue, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		values[i], err = deserialize(serializedValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (rc *RedisCache) MSet(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	pipe := rc.client.TxPipeline()
+
+	for key, value := range values {
+		serializedValue, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("could not serialize value: %w", err)
+		}
+		pipe.Set(ctx, key, serializedValue, expiration)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("could not set values: %w", err)
+	}
+
+	return nil
+}
+
 func (rc *RedisCache) Del(ctx context.Context, key string) error {
 	numKeysDeleted, err := rc.client.Del(ctx, key).Result()
 	if err != nil {
@@ -51,14 +124,31 @@ func (rc *RedisCache) Del(ctx context.Context, key string) error {
 	return nil
 }
 
+func (rc *RedisCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := rc.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("could not get key ttl in redis cache: %w", err)
+	}
+
+	// redis replies with -2 when the key does not exist and -1 when it has no expiry
+	if ttl == -2 {
+		return 0, Nil
+	}
+
+	return ttl, nil
+}
+
 func NewRedisCache(connURL string) (Cache, error) {
 	opts, err := redis.ParseURL(connURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse redis connection url: %w", err)
 	}
 
+	client := redis.NewClient(opts)
+	client.AddHook(tracing.NewRedisHook())
+
 	rc := &RedisCache{
-		client: redis.NewClient(opts),
+		client: client,
 	}
 
 	return rc, nil
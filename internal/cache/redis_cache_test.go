@@ -196,3 +196,125 @@ func TestRedisCache_Del(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisCache_TTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain redis cache state")
+	}
+
+	cache := newRedisCache(t)
+
+	testCases := []struct {
+		name       string
+		key        string
+		expiration time.Duration
+		shouldSet  bool
+		checkTTL   func(t *testing.T, ttl time.Duration, err error)
+	}{
+		{
+			name:       "Success - with expiration",
+			key:        "test_key:ttl:expiration",
+			expiration: 30 * time.Second,
+			shouldSet:  true,
+			checkTTL: func(t *testing.T, ttl time.Duration, err error) {
+				require.NoError(t, err)
+				require.InDelta(t, 30*time.Second, ttl, float64(2*time.Second))
+			},
+		},
+		{
+			name:       "Success - without expiration",
+			key:        "test_key:ttl:no-expiration",
+			expiration: 0,
+			shouldSet:  true,
+			checkTTL: func(t *testing.T, ttl time.Duration, err error) {
+				require.NoError(t, err)
+				require.Negative(t, ttl)
+			},
+		},
+		{
+			name:      "key not in cache",
+			key:       "test_key:ttl:not-in-cache",
+			shouldSet: false,
+			checkTTL: func(t *testing.T, ttl time.Duration, err error) {
+				require.Equal(t, Nil, err)
+				require.Zero(t, ttl)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.shouldSet {
+				err := cache.Set(context.Background(), tc.key, "test_val", tc.expiration)
+				require.NoError(t, err)
+
+				defer func() {
+					_ = cache.Del(context.Background(), tc.key)
+				}()
+			}
+
+			ttl, err := cache.TTL(context.Background(), tc.key)
+			tc.checkTTL(t, ttl, err)
+		})
+	}
+}
+
+func TestRedisCache_MSetMGet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain redis cache state")
+	}
+
+	cache := newRedisCache(t)
+
+	values := map[string]interface{}{
+		"test_key:mset:string": "test_val",
+		"test_key:mset:int":    420,
+	}
+
+	err := cache.MSet(context.Background(), values, 30*time.Second)
+	require.NoError(t, err)
+
+	res, err := cache.MGet(context.Background(), "test_key:mset:string", "test_key:mget:not-in-cache", "test_key:mset:int")
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	require.Equal(t, "test_val", res[0])
+	require.Nil(t, res[1])
+	require.Equal(t, float64(420), res[2])
+
+	ttl, err := cache.TTL(context.Background(), "test_key:mset:int")
+	require.NoError(t, err)
+	require.InDelta(t, 30*time.Second, ttl, float64(2*time.Second))
+
+	res, err = cache.MGet(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
+func TestRedisCache_SetNX(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain redis cache state")
+	}
+
+	cache := newRedisCache(t)
+	key := "test_key:setnx"
+
+	err := cache.Del(context.Background(), key)
+	if err != nil {
+		require.ErrorIs(t, err, Nil)
+	}
+
+	set, err := cache.SetNX(context.Background(), key, "first", 30*time.Second)
+	require.NoError(t, err)
+	require.True(t, set)
+
+	set, err = cache.SetNX(context.Background(), key, "second", 30*time.Second)
+	require.NoError(t, err)
+	require.False(t, set)
+
+	res, err := cache.Get(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, "first", res)
+}
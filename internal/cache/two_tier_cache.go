@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	invalidationChannel    = "cache:invalidations"
+	defaultLocalExpiration = time.Minute
+)
+
+type invalidationMessage struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// TwoTierCache serves reads from an in-process MemoryCache in front of a shared remote cache.
+// Writes go to both tiers and are broadcast over redis pub/sub so that other replicas drop
+// their local copies. Local entries never outlive localExpiration, which bounds staleness
+// when an invalidation is missed.
+type TwoTierCache struct {
+	local           *MemoryCache
+	remote          Cache
+	localExpiration time.Duration
+	id              string
+	publish         func(ctx context.Context, keys ...string) error
+	cancel          context.CancelFunc
+}
+
+func NewTwoTierCache(remote *RedisCache, localCapacity int, localExpiration time.Duration) *TwoTierCache {
+	if localExpiration <= 0 {
+		localExpiration = defaultLocalExpiration
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tc := &TwoTierCache{
+		local:           NewMemoryCache(localCapacity),
+		remote:          remote,
+		localExpiration: localExpiration,
+		id:              uuid.NewString(),
+		cancel:          cancel,
+	}
+	tc.publish = func(ctx context.Context, keys ...string) error {
+		return publishInvalidation(ctx, remote.client, tc.id, keys)
+	}
+
+	go tc.subscribe(ctx, remote.client)
+
+	return tc
+}
+
+func (tc *TwoTierCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	err := tc.remote.Set(ctx, key, value, expiration)
+	if err != nil {
+		return err
+	}
+
+	tc.invalidateReplicas(ctx, key)
+
+	return tc.local.Set(ctx, key, value, tc.localExpirationFor(expiration))
+}
+
+// SetNX is decided by the remote cache alone, since the local one may not hold every key.
+func (tc *TwoTierCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	set, err := tc.remote.SetNX(ctx, key, value, expiration)
+	if err != nil || !set {
+		return set, err
+	}
+
+	tc.invalidateReplicas(ctx, key)
+
+	return true, tc.local.Set(ctx, key, value, tc.localExpirationFor(expiration))
+}
+
+func (tc *TwoTierCache) Get(ctx context.Context, key string) (interface{}, error) {
+	value, err := tc.local.Get(ctx, key)
+	if err == nil && value != nil {
+		return value, nil
+	}
+
+	value, err = tc.remote.Get(ctx, key)
+	if err != nil || value == nil {
+		return value, err
+	}
+
+	tc.storeLocally(ctx, key, value)
+
+	return value, nil
+}
+
+func (tc *TwoTierCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	values, err := tc.local.MGet(ctx, keys...)
+	if err != nil {
+		values = make([]interface{}, len(keys))
+	}
+
+	var missingIndexes []int
+	var missingKeys []string
+	for i, value := range values {
+		if value == nil {
+			missingIndexes = append(missingIndexes, i)
+			missingKeys = append(missingKeys, keys[i])
+		}
+	}
+
+	if len(missingKeys) == 0 {
+		return values, nil
+	}
+
+	remoteValues, err := tc.remote.MGet(ctx, missingKeys...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range remoteValues {
+		if value == nil {
+			continue
+		}
+
+		values[missingIndexes[i]] = value
+		tc.storeLocally(ctx, missingKeys[i], value)
+	}
+
+	return values, nil
+}
+
+func (tc *TwoTierCache) MSet(ctx context.Context, values map[string]interface{}, expiration time.Duration) error {
+	err := tc.remote.MSet(ctx, values, expiration)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	tc.invalidateReplicas(ctx, keys...)
+
+	return tc.local.MSet(ctx, values, tc.localExpirationFor(expiration))
+}
+
+func (tc *TwoTierCache) Del(ctx context.Context, key string) error {
+	err := tc.remote.Del(ctx, key)
+	if err != nil && err != Nil {
+		return err
+	}
+
+	tc.local.invalidate(key)
+	tc.invalidateReplicas(ctx, key)
+
+	return err
+}
+
+func (tc *TwoTierCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return tc.remote.TTL(ctx, key)
+}
+
+// Ping checks the remote cache, which backs every miss of the local one.
+func (tc *TwoTierCache) Ping(ctx context.Context) error {
+	pinger, ok := tc.remote.(pinger)
+	if !ok {
+		return nil
+	}
+
+	return pinger.Ping(ctx)
+}
+
+// Close stops listening for invalidations from other replicas and closes the remote cache.
+func (tc *TwoTierCache) Close() error {
+	if tc.cancel != nil {
+		tc.cancel()
+	}
+
+	if closer, ok := tc.remote.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (tc *TwoTierCache) localExpirationFor(expiration time.Duration) time.Duration {
+	if expiration > 0 && expiration < tc.localExpiration {
+		return expiration
+	}
+
+	return tc.localExpiration
+}
+
+func (tc *TwoTierCache) storeLocally(ctx context.Context, key string, value interface{}) {
+	err := tc.local.Set(ctx, key, value, tc.localExpiration)
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("could not store value in local cache")
+	}
+}
+
+func (tc *TwoTierCache) invalidateReplicas(ctx context.Context, keys ...string) {
+	if tc.publish == nil {
+		return
+	}
+
+	err := tc.publish(ctx, keys...)
+	if err != nil {
+		log.Error().Err(err).Strs("keys", keys).Msg("could not publish cache invalidation")
+	}
+}
+
+func (tc *TwoTierCache) handleInvalidation(payload string) {
+	var message invalidationMessage
+	err := json.Unmarshal([]byte(payload), &message)
+	if err != nil {
+		log.Error().Err(err).Msg("could not decode cache invalidation")
+		return
+	}
+
+	if message.Origin == tc.id {
+		return
+	}
+
+	tc.local.invalidate(message.Keys...)
+}
+
+func (tc *TwoTierCache) subscribe(ctx context.Context, client *redis.Client) {
+	pubsub := client.Subscribe(ctx, invalidationChannel)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			tc.handleInvalidation(message.Payload)
+		}
+	}
+}
+
+func publishInvalidation(ctx context.Context, client *redis.Client, origin string, keys []string) error {
+	payload, err := json.Marshal(invalidationMessage{
+		Origin: origin,
+		Keys:   keys,
+	})
+	if err != nil {
+		return fmt.Errorf("could not serialize cache invalidation: %w", err)
+	}
+
+	return client.Publish(ctx, invalidationChannel, payload).Err()
+}
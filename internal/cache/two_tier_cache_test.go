@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type publishedInvalidations struct {
+	keys [][]string
+}
+
+func newTestTwoTierCache(remote Cache, published *publishedInvalidations) *TwoTierCache {
+	return &TwoTierCache{
+		local:           NewMemoryCache(10),
+		remote:          remote,
+		localExpiration: time.Minute,
+		id:              "replica-a",
+		publish: func(_ context.Context, keys ...string) error {
+			published.keys = append(published.keys, keys)
+			return nil
+		},
+	}
+}
+
+func TestTwoTierCache_ReadThrough(t *testing.T) {
+	remote := NewMemoryCache(10)
+	published := &publishedInvalidations{}
+	tc := newTestTwoTierCache(remote, published)
+
+	require.NoError(t, remote.Set(context.Background(), "key", "remote_val", time.Hour))
+
+	val, err := tc.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, "remote_val", val)
+
+	// later reads are served locally even if the remote entry goes away
+	require.NoError(t, remote.Del(context.Background(), "key"))
+
+	val, err = tc.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, "remote_val", val)
+
+	ttl, err := tc.local.TTL(context.Background(), "key")
+	require.NoError(t, err)
+	require.LessOrEqual(t, ttl, time.Minute)
+
+	require.Empty(t, published.keys)
+}
+
+func TestTwoTierCache_MGet(t *testing.T) {
+	remote := NewMemoryCache(10)
+	tc := newTestTwoTierCache(remote, &publishedInvalidations{})
+
+	require.NoError(t, tc.local.Set(context.Background(), "a", "local_a", time.Minute))
+	require.NoError(t, remote.MSet(context.Background(), map[string]interface{}{"a": "remote_a", "b": "remote_b"}, time.Hour))
+
+	values, err := tc.MGet(context.Background(), "a", "b", "c")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"local_a", "remote_b", nil}, values)
+
+	val, err := tc.local.Get(context.Background(), "b")
+	require.NoError(t, err)
+	require.Equal(t, "remote_b", val)
+}
+
+func TestTwoTierCache_WritesInvalidateReplicas(t *testing.T) {
+	remote := NewMemoryCache(10)
+	published := &publishedInvalidations{}
+	tc := newTestTwoTierCache(remote, published)
+
+	require.NoError(t, tc.Set(context.Background(), "a", "val", 10*time.Second))
+	require.NoError(t, tc.MSet(context.Background(), map[string]interface{}{"b": "val"}, time.Hour))
+	require.NoError(t, tc.Del(context.Background(), "a"))
+	require.Equal(t, Nil, tc.Del(context.Background(), "a"))
+
+	require.Equal(t, [][]string{{"a"}, {"b"}, {"a"}, {"a"}}, published.keys)
+
+	val, err := remote.Get(context.Background(), "b")
+	require.NoError(t, err)
+	require.Equal(t, "val", val)
+
+	ttl, err := tc.local.TTL(context.Background(), "b")
+	require.NoError(t, err)
+	require.LessOrEqual(t, ttl, time.Minute)
+
+	_, err = tc.local.TTL(context.Background(), "a")
+	require.Equal(t, Nil, err)
+}
+
+func TestTwoTierCache_HandleInvalidation(t *testing.T) {
+	tc := newTestTwoTierCache(NewMemoryCache(10), &publishedInvalidations{})
+
+	require.NoError(t, tc.local.MSet(context.Background(), map[string]interface{}{"a": 1, "b": 2}, time.Minute))
+
+	payload := func(origin string, keys ...string) string {
+		serialized, err := json.Marshal(invalidationMessage{Origin: origin, Keys: keys})
+		require.NoError(t, err)
+		return string(serialized)
+	}
+
+	tc.handleInvalidation(payload(tc.id, "a"))
+	tc.handleInvalidation("not json")
+
+	values, err := tc.local.MGet(context.Background(), "a", "b")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{float64(1), float64(2)}, values)
+
+	tc.handleInvalidation(payload("replica-b", "a", "b"))
+
+	values, err = tc.local.MGet(context.Background(), "a", "b")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{nil, nil}, values)
+}
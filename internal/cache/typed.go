@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Typed is a type-safe view over a Cache whose values are converted with a Codec.
+type Typed[T any] struct {
+	cache Cache
+	codec Codec[T]
+}
+
+func NewTyped[T any](c Cache, codec Codec[T]) *Typed[T] {
+	return &Typed[T]{
+		cache: c,
+		codec: codec,
+	}
+}
+
+// Get returns Nil when the key does not exist.
+func (t *Typed[T]) Get(ctx context.Context, key string) (T, error) {
+	var zero T
+
+	res, err := t.cache.Get(ctx, key)
+	if err != nil {
+		return zero, err
+	}
+	if res == nil {
+		return zero, Nil
+	}
+
+	return t.codec.Decode(res)
+}
+
+func (t *Typed[T]) Set(ctx context.Context, key string, value T, expiration time.Duration) error {
+	encodedValue, err := t.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	return t.cache.Set(ctx, key, encodedValue, expiration)
+}
+
+// MGet returns the decoded values of the keys that exist in the cache.
+func (t *Typed[T]) MGet(ctx context.Context, keys ...string) (map[string]T, error) {
+	res, err := t.cache.MGet(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]T, len(keys))
+	for i, value := range res {
+		if value == nil {
+			continue
+		}
+
+		decodedValue, err := t.codec.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+
+		values[keys[i]] = decodedValue
+	}
+
+	return values, nil
+}
+
+func (t *Typed[T]) MSet(ctx context.Context, values map[string]T, expiration time.Duration) error {
+	encodedValues := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		encodedValue, err := t.codec.Encode(value)
+		if err != nil {
+			return err
+		}
+
+		encodedValues[key] = encodedValue
+	}
+
+	return t.cache.MSet(ctx, encodedValues, expiration)
+}
+
+// GetOrLoad returns the cached value for key, calling load and caching its result on a miss.
+// Failing to store the loaded value does not fail the call.
+func (t *Typed[T]) GetOrLoad(ctx context.Context, key string, expiration time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	value, err := t.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	if err != Nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("could not read value from cache")
+	}
+
+	value, err = load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	if setErr := t.Set(ctx, key, value, expiration); setErr != nil {
+		log.Ctx(ctx).Warn().Err(setErr).Str("key", key).Msg("could not store loaded value in cache")
+	}
+
+	return value, nil
+}
@@ -0,0 +1,222 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyamalabs/users/internal/cache"
+	mockcache "github.com/kyamalabs/users/internal/cache/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTyped_Get(t *testing.T) {
+	testCases := []struct {
+		name          string
+		buildStubs    func(c *mockcache.MockCache)
+		checkResponse func(t *testing.T, value testValue, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(c *mockcache.MockCache) {
+				c.EXPECT().
+					Get(gomock.Any(), "key").
+					Times(1).
+					Return(map[string]interface{}{"name": "bulba", "count": float64(3)}, nil)
+			},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testValue{Name: "bulba", Count: 3}, value)
+			},
+		},
+		{
+			name: "key not in cache",
+			buildStubs: func(c *mockcache.MockCache) {
+				c.EXPECT().
+					Get(gomock.Any(), "key").
+					Times(1).
+					Return(nil, nil)
+			},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.Equal(t, cache.Nil, err)
+				require.Zero(t, value)
+			},
+		},
+		{
+			name: "cache error",
+			buildStubs: func(c *mockcache.MockCache) {
+				c.EXPECT().
+					Get(gomock.Any(), "key").
+					Times(1).
+					Return(nil, errors.New("some cache error"))
+			},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.Error(t, err)
+				require.NotEqual(t, cache.Nil, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := mockcache.NewMockCache(ctrl)
+			tc.buildStubs(c)
+
+			value, err := cache.NewTyped[testValue](c, cache.JSONCodec[testValue]{}).Get(context.Background(), "key")
+			tc.checkResponse(t, value, err)
+		})
+	}
+}
+
+func TestTyped_MGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mockcache.NewMockCache(ctrl)
+	c.EXPECT().
+		MGet(gomock.Any(), "a", "b", "c").
+		Times(1).
+		Return([]interface{}{
+			map[string]interface{}{"name": "a", "count": float64(1)},
+			nil,
+			map[string]interface{}{"name": "c", "count": float64(3)},
+		}, nil)
+
+	values, err := cache.NewTyped[testValue](c, cache.JSONCodec[testValue]{}).MGet(context.Background(), "a", "b", "c")
+	require.NoError(t, err)
+	require.Equal(t, map[string]testValue{
+		"a": {Name: "a", Count: 1},
+		"c": {Name: "c", Count: 3},
+	}, values)
+}
+
+func TestTyped_MSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mockcache.NewMockCache(ctrl)
+	c.EXPECT().
+		MSet(gomock.Any(), gomock.Any(), time.Minute).
+		Times(1).
+		DoAndReturn(func(_ context.Context, values map[string]interface{}, _ time.Duration) error {
+			require.Len(t, values, 2)
+			for _, value := range values {
+				_, ok := value.([]byte)
+				require.True(t, ok)
+			}
+			return nil
+		})
+
+	err := cache.NewTyped[testValue](c, cache.MsgpackCodec[testValue]{}).MSet(context.Background(), map[string]testValue{
+		"a": {Name: "a", Count: 1},
+		"b": {Name: "b", Count: 2},
+	}, time.Minute)
+	require.NoError(t, err)
+}
+
+func TestTyped_GetOrLoad(t *testing.T) {
+	loaded := testValue{Name: "loaded", Count: 1}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(c *mockcache.MockCache)
+		load          func(ctx context.Context) (testValue, error)
+		checkResponse func(t *testing.T, value testValue, err error)
+	}{
+		{
+			name: "cache hit",
+			buildStubs: func(c *mockcache.MockCache) {
+				c.EXPECT().
+					Get(gomock.Any(), "key").
+					Times(1).
+					Return(testValue{Name: "cached"}, nil)
+				c.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			load: func(ctx context.Context) (testValue, error) {
+				return testValue{}, errors.New("should not be called")
+			},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "cached", value.Name)
+			},
+		},
+		{
+			name: "cache miss",
+			buildStubs: func(c *mockcache.MockCache) {
+				c.EXPECT().
+					Get(gomock.Any(), "key").
+					Times(1).
+					Return(nil, nil)
+				c.EXPECT().
+					Set(gomock.Any(), "key", loaded, time.Minute).
+					Times(1).
+					Return(nil)
+			},
+			load: func(ctx context.Context) (testValue, error) {
+				return loaded, nil
+			},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.NoError(t, err)
+				require.Equal(t, loaded, value)
+			},
+		},
+		{
+			name: "cache unavailable",
+			buildStubs: func(c *mockcache.MockCache) {
+				c.EXPECT().
+					Get(gomock.Any(), "key").
+					Times(1).
+					Return(nil, errors.New("some cache error"))
+				c.EXPECT().
+					Set(gomock.Any(), "key", loaded, time.Minute).
+					Times(1).
+					Return(errors.New("some cache error"))
+			},
+			load: func(ctx context.Context) (testValue, error) {
+				return loaded, nil
+			},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.NoError(t, err)
+				require.Equal(t, loaded, value)
+			},
+		},
+		{
+			name: "load error",
+			buildStubs: func(c *mockcache.MockCache) {
+				c.EXPECT().
+					Get(gomock.Any(), "key").
+					Times(1).
+					Return(nil, nil)
+				c.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			load: func(ctx context.Context) (testValue, error) {
+				return testValue{}, errors.New("some db error")
+			},
+			checkResponse: func(t *testing.T, value testValue, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := mockcache.NewMockCache(ctrl)
+			tc.buildStubs(c)
+
+			value, err := cache.NewTyped[testValue](c, cache.JSONCodec[testValue]{}).GetOrLoad(context.Background(), "key", time.Minute, tc.load)
+			tc.checkResponse(t, value, err)
+		})
+	}
+}
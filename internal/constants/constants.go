@@ -5,6 +5,8 @@ const (
 	AuthorizationHeader          = "authorization"
 	AuthorizationBearer          = "bearer"
 	XServiceAuthenticationHeader = "x-service-authentication"
+	XWalletAddressHeader         = "x-wallet-address"
+	XRequestIDHeader             = "x-request-id"
 )
 
 const (
@@ -0,0 +1,16 @@
+CREATE TABLE "outbox" (
+  "id" bigserial PRIMARY KEY,
+  "sink" varchar NOT NULL,
+  "topic" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "headers" jsonb NOT NULL DEFAULT ('{}'),
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar,
+  "available_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "published_at" timestamptz
+);
+
+CREATE INDEX ON "outbox" ("available_at") WHERE "published_at" IS NULL;
+
+CREATE INDEX ON "outbox" ("published_at");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ens_name_expiries;
@@ -0,0 +1,8 @@
+CREATE TABLE "ens_name_expiries" (
+  "wallet_address" varchar PRIMARY KEY,
+  "expires_at" timestamptz NOT NULL
+);
+
+CREATE INDEX ON "ens_name_expiries" ("expires_at");
+
+ALTER TABLE "ens_name_expiries" ADD FOREIGN KEY ("wallet_address") REFERENCES "profiles" ("wallet_address") ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "outbox_available_at_idx";
+
+CREATE INDEX ON "outbox" ("available_at") WHERE "published_at" IS NULL;
+
+ALTER TABLE "outbox" DROP COLUMN IF EXISTS "failed_at";
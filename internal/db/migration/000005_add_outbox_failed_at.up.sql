@@ -0,0 +1,5 @@
+ALTER TABLE "outbox" ADD COLUMN "failed_at" timestamptz;
+
+DROP INDEX IF EXISTS "outbox_available_at_idx";
+
+CREATE INDEX ON "outbox" ("available_at") WHERE "published_at" IS NULL AND "failed_at" IS NULL;
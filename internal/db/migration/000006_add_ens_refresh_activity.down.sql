@@ -0,0 +1,5 @@
+ALTER TABLE "ens_name_expiries" DROP COLUMN IF EXISTS "resolved_at";
+
+DROP INDEX IF EXISTS "profiles_updated_at_idx";
+
+ALTER TABLE "profiles" DROP COLUMN IF EXISTS "updated_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "profiles" ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT (now());
+
+CREATE INDEX ON "profiles" ("updated_at");
+
+ALTER TABLE "ens_name_expiries" ADD COLUMN "resolved_at" timestamptz NOT NULL DEFAULT (now());
@@ -0,0 +1,23 @@
+-- name: UpsertEnsNameExpiry :exec
+INSERT INTO ens_name_expiries (
+    wallet_address,
+    expires_at
+)
+SELECT wallet_address, sqlc.arg(expires_at)::timestamptz FROM profiles
+WHERE wallet_address = sqlc.arg(wallet_address)
+ON CONFLICT (wallet_address) DO UPDATE
+SET
+    expires_at = EXCLUDED.expires_at,
+    resolved_at = now();
+
+-- name: ListProfilesDueForEnsRefresh :many
+SELECT p.wallet_address FROM profiles p
+LEFT JOIN ens_name_expiries e ON e.wallet_address = p.wallet_address
+WHERE p.wallet_address > sqlc.arg(after_wallet_address)
+AND (
+    e.expires_at IS NULL
+    OR e.expires_at < sqlc.arg(expires_before)
+    OR (p.updated_at > sqlc.arg(active_since) AND e.resolved_at < p.updated_at)
+)
+ORDER BY p.wallet_address
+LIMIT sqlc.arg(batch_size);
@@ -0,0 +1,46 @@
+-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+    sink,
+    topic,
+    payload,
+    headers
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ClaimOutboxMessages :many
+UPDATE outbox
+SET
+    available_at = sqlc.arg(lease_expires_at),
+    attempts = attempts + 1
+WHERE id IN (
+    SELECT id FROM outbox
+    WHERE published_at IS NULL AND failed_at IS NULL AND available_at <= now()
+    ORDER BY id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxMessagePublished :exec
+UPDATE outbox
+SET published_at = now()
+WHERE id = $1;
+
+-- name: RescheduleOutboxMessage :exec
+UPDATE outbox
+SET
+    available_at = $2,
+    last_error = $3
+WHERE id = $1;
+
+-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox
+SET
+    failed_at = now(),
+    last_error = $2
+WHERE id = $1;
+
+-- name: DeletePublishedOutboxMessages :execrows
+DELETE FROM outbox
+WHERE published_at < sqlc.arg(published_before)::timestamptz;
@@ -11,6 +11,12 @@ SELECT * FROM profiles
 WHERE wallet_address = $1
 LIMIT 1;
 
+-- name: GetProfileForUpdate :one
+SELECT * FROM profiles
+WHERE wallet_address = $1
+LIMIT 1
+FOR UPDATE;
+
 -- name: GetProfilesCount :one
 SELECT COUNT(*) as total_profiles FROM profiles;
 
@@ -23,11 +29,12 @@ OFFSET $2;
 -- name: UpdateProfile :one
 UPDATE profiles
 SET
-    gamer_tag = COALESCE(sqlc.narg(gamer_tag), gamer_tag)
+    gamer_tag = COALESCE(sqlc.narg(gamer_tag), gamer_tag),
+    updated_at = now()
 WHERE
     wallet_address = sqlc.arg(wallet_address)
 RETURNING *;
 
--- name: DeleteProfile :exec
+-- name: DeleteProfile :execrows
 DELETE FROM profiles
 WHERE wallet_address = $1;
@@ -0,0 +1,26 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+    url,
+    secret,
+    event_types
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks
+ORDER BY created_at DESC;
+
+-- name: ListWebhooksForEventType :many
+SELECT * FROM webhooks
+WHERE sqlc.arg(event_type)::varchar = ANY(event_types)
+ORDER BY created_at;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks
+WHERE id = $1;
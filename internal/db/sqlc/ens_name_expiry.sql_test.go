@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListProfilesDueForEnsRefresh(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	expiring := createTestProfile(t)
+	fresh := createTestProfile(t)
+	unresolved := createTestProfile(t)
+	updatedAfterResolving := createTestProfile(t)
+	resolvedAfterUpdating := createTestProfile(t)
+
+	err := testStore.UpsertEnsNameExpiry(context.Background(), UpsertEnsNameExpiryParams{
+		WalletAddress: expiring.WalletAddress,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	for _, profile := range []Profile{fresh, updatedAfterResolving} {
+		err = testStore.UpsertEnsNameExpiry(context.Background(), UpsertEnsNameExpiryParams{
+			WalletAddress: profile.WalletAddress,
+			ExpiresAt:     time.Now().Add(7 * 24 * time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	for _, profile := range []Profile{updatedAfterResolving, resolvedAfterUpdating} {
+		_, err = testStore.UpdateProfile(context.Background(), UpdateProfileParams{
+			WalletAddress: profile.WalletAddress,
+			GamerTag:      pgtype.Text{String: fmt.Sprintf("%s--updated", profile.GamerTag), Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	err = testStore.UpsertEnsNameExpiry(context.Background(), UpsertEnsNameExpiryParams{
+		WalletAddress: resolvedAfterUpdating.WalletAddress,
+		ExpiresAt:     time.Now().Add(7 * 24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	var due []string
+	var afterWalletAddress string
+	for {
+		walletAddresses, err := testStore.ListProfilesDueForEnsRefresh(context.Background(), ListProfilesDueForEnsRefreshParams{
+			AfterWalletAddress: afterWalletAddress,
+			ExpiresBefore:      time.Now().Add(24 * time.Hour),
+			ActiveSince:        time.Now().Add(-time.Hour),
+			BatchSize:          100,
+		})
+		require.NoError(t, err)
+
+		due = append(due, walletAddresses...)
+		if len(walletAddresses) < 100 {
+			break
+		}
+		afterWalletAddress = walletAddresses[len(walletAddresses)-1]
+	}
+
+	require.Contains(t, due, expiring.WalletAddress)
+	require.Contains(t, due, unresolved.WalletAddress)
+	require.Contains(t, due, updatedAfterResolving.WalletAddress)
+	require.NotContains(t, due, fresh.WalletAddress)
+	require.NotContains(t, due, resolvedAfterUpdating.WalletAddress)
+}
+
+func TestUpsertEnsNameExpiry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	// wallet addresses without a profile are ignored
+	err := testStore.UpsertEnsNameExpiry(context.Background(), UpsertEnsNameExpiryParams{
+		WalletAddress: "0x0000000000000000000000000000000000000000",
+		ExpiresAt:     time.Now(),
+	})
+	require.NoError(t, err)
+}
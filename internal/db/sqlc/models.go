@@ -8,12 +8,34 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type EnsNameExpiry struct {
+	WalletAddress string    `json:"wallet_address"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	ResolvedAt    time.Time `json:"resolved_at"`
+}
+
+type Outbox struct {
+	ID          int64              `json:"id"`
+	Sink        string             `json:"sink"`
+	Topic       string             `json:"topic"`
+	Payload     []byte             `json:"payload"`
+	Headers     []byte             `json:"headers"`
+	Attempts    int32              `json:"attempts"`
+	LastError   pgtype.Text        `json:"last_error"`
+	AvailableAt time.Time          `json:"available_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	FailedAt    pgtype.Timestamptz `json:"failed_at"`
+}
+
 type Profile struct {
 	WalletAddress string    `json:"wallet_address"`
 	GamerTag      string    `json:"gamer_tag"`
 	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type Referral struct {
@@ -22,3 +44,25 @@ type Referral struct {
 	Referee    string    `json:"referee"`
 	ReferredAt time.Time `json:"referred_at"`
 }
+
+type Webhook struct {
+	ID         uuid.UUID `json:"id"`
+	Url        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID          `json:"id"`
+	WebhookID      uuid.UUID          `json:"webhook_id"`
+	EventID        string             `json:"event_id"`
+	EventType      string             `json:"event_type"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	LastError      pgtype.Text        `json:"last_error"`
+	CreatedAt      time.Time          `json:"created_at"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+}
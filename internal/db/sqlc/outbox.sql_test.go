@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestOutboxMessage(t *testing.T) Outbox {
+	params := CreateOutboxMessageParams{
+		Sink:    "tasks",
+		Topic:   "task:test",
+		Payload: []byte(fmt.Sprintf(`{"id": "%s"}`, gofakeit.UUID())),
+		Headers: []byte(`{}`),
+	}
+
+	message, err := testStore.CreateOutboxMessage(context.Background(), params)
+	require.NoError(t, err)
+	require.NotEmpty(t, message)
+
+	require.Equal(t, params.Sink, message.Sink)
+	require.Equal(t, params.Topic, message.Topic)
+	require.JSONEq(t, string(params.Payload), string(message.Payload))
+	require.Zero(t, message.Attempts)
+	require.False(t, message.PublishedAt.Valid)
+	require.NotZero(t, message.AvailableAt)
+
+	return message
+}
+
+func TestCreateOutboxMessage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	message := createTestOutboxMessage(t)
+	require.NotEmpty(t, message)
+}
+
+func TestClaimOutboxMessages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	message := createTestOutboxMessage(t)
+	leaseExpiresAt := time.Now().Add(time.Minute)
+
+	var claimed *Outbox
+	for {
+		messages, err := testStore.ClaimOutboxMessages(context.Background(), ClaimOutboxMessagesParams{
+			LeaseExpiresAt: leaseExpiresAt,
+			BatchSize:      100,
+		})
+		require.NoError(t, err)
+
+		for i := range messages {
+			if messages[i].ID == message.ID {
+				claimed = &messages[i]
+			}
+		}
+
+		if claimed != nil || len(messages) == 0 {
+			break
+		}
+	}
+
+	require.NotNil(t, claimed)
+	require.Equal(t, int32(1), claimed.Attempts)
+	require.WithinDuration(t, leaseExpiresAt, claimed.AvailableAt, time.Second)
+
+	// leased messages are not claimed again until the lease expires
+	messages, err := testStore.ClaimOutboxMessages(context.Background(), ClaimOutboxMessagesParams{
+		LeaseExpiresAt: leaseExpiresAt,
+		BatchSize:      100,
+	})
+	require.NoError(t, err)
+	for _, m := range messages {
+		require.NotEqual(t, message.ID, m.ID)
+	}
+}
+
+func TestRescheduleOutboxMessage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	message := createTestOutboxMessage(t)
+
+	err := testStore.RescheduleOutboxMessage(context.Background(), RescheduleOutboxMessageParams{
+		ID:          message.ID,
+		AvailableAt: time.Now().Add(-time.Second),
+		LastError: pgtype.Text{
+			String: "some publish error",
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestMarkOutboxMessagePublished(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	message := createTestOutboxMessage(t)
+
+	err := testStore.MarkOutboxMessagePublished(context.Background(), message.ID)
+	require.NoError(t, err)
+
+	deleted, err := testStore.DeletePublishedOutboxMessages(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+}
+
+func TestMarkOutboxMessageFailed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	message := createTestOutboxMessage(t)
+
+	err := testStore.MarkOutboxMessageFailed(context.Background(), MarkOutboxMessageFailedParams{
+		ID: message.ID,
+		LastError: pgtype.Text{
+			String: "some publish error",
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	// failed messages are never claimed again
+	for {
+		messages, err := testStore.ClaimOutboxMessages(context.Background(), ClaimOutboxMessagesParams{
+			LeaseExpiresAt: time.Now().Add(time.Minute),
+			BatchSize:      100,
+		})
+		require.NoError(t, err)
+
+		for _, m := range messages {
+			require.NotEqual(t, message.ID, m.ID)
+		}
+
+		if len(messages) == 0 {
+			break
+		}
+	}
+}
@@ -17,7 +17,7 @@ INSERT INTO profiles (
     gamer_tag
 ) VALUES (
     $1, $2
-) RETURNING wallet_address, gamer_tag, created_at
+) RETURNING wallet_address, gamer_tag, created_at, updated_at
 `
 
 type CreateProfileParams struct {
@@ -28,22 +28,25 @@ type CreateProfileParams struct {
 func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (Profile, error) {
 	row := q.db.QueryRow(ctx, createProfile, arg.WalletAddress, arg.GamerTag)
 	var i Profile
-	err := row.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt)
+	err := row.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt, &i.UpdatedAt)
 	return i, err
 }
 
-const deleteProfile = `-- name: DeleteProfile :exec
+const deleteProfile = `-- name: DeleteProfile :execrows
 DELETE FROM profiles
 WHERE wallet_address = $1
 `
 
-func (q *Queries) DeleteProfile(ctx context.Context, walletAddress string) error {
-	_, err := q.db.Exec(ctx, deleteProfile, walletAddress)
-	return err
+func (q *Queries) DeleteProfile(ctx context.Context, walletAddress string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProfile, walletAddress)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getProfile = `-- name: GetProfile :one
-SELECT wallet_address, gamer_tag, created_at FROM profiles
+SELECT wallet_address, gamer_tag, created_at, updated_at FROM profiles
 WHERE wallet_address = $1
 LIMIT 1
 `
@@ -51,7 +54,21 @@ LIMIT 1
 func (q *Queries) GetProfile(ctx context.Context, walletAddress string) (Profile, error) {
 	row := q.db.QueryRow(ctx, getProfile, walletAddress)
 	var i Profile
-	err := row.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt)
+	err := row.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt, &i.UpdatedAt)
+	return i, err
+}
+
+const getProfileForUpdate = `-- name: GetProfileForUpdate :one
+SELECT wallet_address, gamer_tag, created_at, updated_at FROM profiles
+WHERE wallet_address = $1
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetProfileForUpdate(ctx context.Context, walletAddress string) (Profile, error) {
+	row := q.db.QueryRow(ctx, getProfileForUpdate, walletAddress)
+	var i Profile
+	err := row.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt, &i.UpdatedAt)
 	return i, err
 }
 
@@ -67,7 +84,7 @@ func (q *Queries) GetProfilesCount(ctx context.Context) (int64, error) {
 }
 
 const listProfiles = `-- name: ListProfiles :many
-SELECT wallet_address, gamer_tag, created_at FROM profiles
+SELECT wallet_address, gamer_tag, created_at, updated_at FROM profiles
 ORDER BY created_at DESC
 LIMIT $1
 OFFSET $2
@@ -87,7 +104,7 @@ func (q *Queries) ListProfiles(ctx context.Context, arg ListProfilesParams) ([]P
 	items := []Profile{}
 	for rows.Next() {
 		var i Profile
-		if err := rows.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt); err != nil {
+		if err := rows.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt, &i.UpdatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -101,10 +118,11 @@ func (q *Queries) ListProfiles(ctx context.Context, arg ListProfilesParams) ([]P
 const updateProfile = `-- name: UpdateProfile :one
 UPDATE profiles
 SET
-    gamer_tag = COALESCE($1, gamer_tag)
+    gamer_tag = COALESCE($1, gamer_tag),
+    updated_at = now()
 WHERE
     wallet_address = $2
-RETURNING wallet_address, gamer_tag, created_at
+RETURNING wallet_address, gamer_tag, created_at, updated_at
 `
 
 type UpdateProfileParams struct {
@@ -115,6 +133,6 @@ type UpdateProfileParams struct {
 func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (Profile, error) {
 	row := q.db.QueryRow(ctx, updateProfile, arg.GamerTag, arg.WalletAddress)
 	var i Profile
-	err := row.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt)
+	err := row.Scan(&i.WalletAddress, &i.GamerTag, &i.CreatedAt, &i.UpdatedAt)
 	return i, err
 }
@@ -117,8 +117,9 @@ func TestDeleteProfile(t *testing.T) {
 	profile := createTestProfile(t)
 	require.NotEmpty(t, profile)
 
-	err := testStore.DeleteProfile(context.Background(), profile.WalletAddress)
+	deleted, err := testStore.DeleteProfile(context.Background(), profile.WalletAddress)
 	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
 
 	deletedProfile, err := testStore.GetProfile(context.Background(), profile.WalletAddress)
 	require.Error(t, err)
@@ -151,4 +152,5 @@ func TestUpdateProfile(t *testing.T) {
 	require.Equal(t, expectedUpdatedGamerTag, updatedProfile.GamerTag)
 	require.Equal(t, profile.WalletAddress, updatedProfile.WalletAddress)
 	require.WithinDuration(t, profile.CreatedAt, updatedProfile.CreatedAt, time.Second)
+	require.True(t, updatedProfile.UpdatedAt.After(profile.UpdatedAt))
 }
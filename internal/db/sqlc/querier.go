@@ -6,19 +6,42 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Querier interface {
+	ClaimOutboxMessages(ctx context.Context, arg ClaimOutboxMessagesParams) ([]Outbox, error)
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (Outbox, error)
 	CreateProfile(ctx context.Context, arg CreateProfileParams) (Profile, error)
 	CreateReferral(ctx context.Context, arg CreateReferralParams) (Referral, error)
-	DeleteProfile(ctx context.Context, walletAddress string) error
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	DeleteProfile(ctx context.Context, walletAddress string) (int64, error)
+	DeletePublishedOutboxMessages(ctx context.Context, publishedBefore time.Time) (int64, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
 	GetProfile(ctx context.Context, walletAddress string) (Profile, error)
+	GetProfileForUpdate(ctx context.Context, walletAddress string) (Profile, error)
 	GetProfilesCount(ctx context.Context) (int64, error)
 	GetReferralsCount(ctx context.Context, referrer string) (int64, error)
 	GetReferrer(ctx context.Context, referee string) (Referral, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (Webhook, error)
+	GetWebhookDeliveriesCount(ctx context.Context, webhookID uuid.UUID) (int64, error)
+	GetWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
 	ListProfiles(ctx context.Context, arg ListProfilesParams) ([]Profile, error)
+	ListProfilesDueForEnsRefresh(ctx context.Context, arg ListProfilesDueForEnsRefreshParams) ([]string, error)
 	ListReferrals(ctx context.Context, arg ListReferralsParams) ([]Referral, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	ListWebhooksForEventType(ctx context.Context, eventType string) ([]Webhook, error)
+	MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error
+	MarkOutboxMessagePublished(ctx context.Context, id int64) error
+	RescheduleOutboxMessage(ctx context.Context, arg RescheduleOutboxMessageParams) error
+	ResetWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
 	UpdateProfile(ctx context.Context, arg UpdateProfileParams) (Profile, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	UpsertEnsNameExpiry(ctx context.Context, arg UpsertEnsNameExpiryParams) error
 }
 
 var _ Querier = (*Queries)(nil)
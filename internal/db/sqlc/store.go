@@ -6,11 +6,14 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Store runs queries and the transactions that span several of them. The OutboxMessages passed to
+// a transaction are recorded in it and published by the outbox relay once it commits, and only
+// when the transaction actually changed the profile.
 type Store interface {
 	Querier
-	GetProfileTx(ctx context.Context, params GetProfileTxParams) (GetProfileTxResult, error)
-	UpdateProfileTx(ctx context.Context, params UpdateProfileTxParams) (UpdateProfileTxResult, error)
 	CreateProfileTx(ctx context.Context, params CreateProfileTxParams) (CreateProfileTxResult, error)
+	UpdateProfileTx(ctx context.Context, params UpdateProfileTxParams) (UpdateProfileTxResult, error)
+	DeleteProfileTx(ctx context.Context, params DeleteProfileTxParams) error
 }
 
 type SQLStore struct {
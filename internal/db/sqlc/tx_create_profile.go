@@ -17,8 +17,8 @@ var (
 
 type CreateProfileTxParams struct {
 	CreateProfileParams
-	Referrer    string
-	AfterCreate func() error
+	Referrer       string
+	OutboxMessages []CreateOutboxMessageParams
 }
 
 type CreateProfileTxResult struct {
@@ -59,7 +59,7 @@ func (store *SQLStore) CreateProfileTx(ctx context.Context, params CreateProfile
 			return err
 		}
 
-		return params.AfterCreate()
+		return createOutboxMessages(ctx, q, params.OutboxMessages)
 	})
 	if err != nil {
 		return CreateProfileTxResult{}, err
@@ -106,3 +106,19 @@ func createProfileReferral(ctx context.Context, q *Queries, createReferralParams
 
 	return referral, nil
 }
+
+func createOutboxMessages(ctx context.Context, q *Queries, messages []CreateOutboxMessageParams) error {
+	for _, message := range messages {
+		if message.Headers == nil {
+			message.Headers = []byte("{}")
+		}
+
+		_, err := q.CreateOutboxMessage(ctx, message)
+		if err != nil {
+			log.Error().Err(err).Str("topic", message.Topic).Msg("could not create outbox message in db")
+			return err
+		}
+	}
+
+	return nil
+}
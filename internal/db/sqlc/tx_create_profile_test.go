@@ -20,14 +20,12 @@ func TestCreateProfileTx(t *testing.T) {
 			t *testing.T,
 			newProfileEthereumWallet *util.EthereumWallet,
 			referrerEthereumWallet *util.EthereumWallet,
-			newProfileGamerTag string,
-			isAfterCreateCalled *bool) CreateProfileTxParams
+			newProfileGamerTag string) CreateProfileTxParams
 		checkResponse func(
 			t *testing.T,
 			newProfileEthereumWallet *util.EthereumWallet,
 			referrerEthereumWallet *util.EthereumWallet,
 			newProfileGamerTag string,
-			isAfterCreateCalled *bool,
 			txResult CreateProfileTxResult,
 			err error)
 	}{
@@ -38,13 +36,8 @@ func TestCreateProfileTx(t *testing.T) {
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
 				newProfileGamerTag string,
-				isAfterCreateCalled *bool,
 			) CreateProfileTxParams {
 				return CreateProfileTxParams{
-					AfterCreate: func() error {
-						*isAfterCreateCalled = true
-						return nil
-					},
 					CreateProfileParams: CreateProfileParams{
 						WalletAddress: newProfileEthereumWallet.Address,
 						GamerTag:      newProfileGamerTag,
@@ -55,7 +48,7 @@ func TestCreateProfileTx(t *testing.T) {
 				t *testing.T,
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
-				newProfileGamerTag string, isAfterCreateCalled *bool,
+				newProfileGamerTag string,
 				txResult CreateProfileTxResult,
 				err error,
 			) {
@@ -66,8 +59,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.Empty(t, txResult.Referral)
 
 				require.NoError(t, err)
-
-				require.True(t, *isAfterCreateCalled)
 			},
 		},
 		{
@@ -77,7 +68,6 @@ func TestCreateProfileTx(t *testing.T) {
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
 				newProfileGamerTag string,
-				isAfterCreateCalled *bool,
 			) CreateProfileTxParams {
 				referrer, err := testStore.CreateProfile(context.Background(), CreateProfileParams{
 					WalletAddress: referrerEthereumWallet.Address,
@@ -87,10 +77,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.NoError(t, err)
 
 				return CreateProfileTxParams{
-					AfterCreate: func() error {
-						*isAfterCreateCalled = true
-						return nil
-					},
 					CreateProfileParams: CreateProfileParams{
 						WalletAddress: newProfileEthereumWallet.Address,
 						GamerTag:      newProfileGamerTag,
@@ -102,7 +88,7 @@ func TestCreateProfileTx(t *testing.T) {
 				t *testing.T,
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
-				newProfileGamerTag string, isAfterCreateCalled *bool,
+				newProfileGamerTag string,
 				txResult CreateProfileTxResult,
 				err error,
 			) {
@@ -115,8 +101,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.NotZero(t, txResult.Referral.ReferredAt)
 
 				require.NoError(t, err)
-
-				require.True(t, *isAfterCreateCalled)
 			},
 		},
 		{
@@ -126,7 +110,6 @@ func TestCreateProfileTx(t *testing.T) {
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
 				newProfileGamerTag string,
-				isAfterCreateCalled *bool,
 			) CreateProfileTxParams {
 				profile, err := testStore.CreateProfile(context.Background(), CreateProfileParams{
 					WalletAddress: newProfileEthereumWallet.Address,
@@ -136,10 +119,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.NoError(t, err)
 
 				return CreateProfileTxParams{
-					AfterCreate: func() error {
-						*isAfterCreateCalled = true
-						return nil
-					},
 					CreateProfileParams: CreateProfileParams{
 						WalletAddress: newProfileEthereumWallet.Address,
 						GamerTag:      newProfileGamerTag,
@@ -150,7 +129,7 @@ func TestCreateProfileTx(t *testing.T) {
 				t *testing.T,
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
-				newProfileGamerTag string, isAfterCreateCalled *bool,
+				newProfileGamerTag string,
 				txResult CreateProfileTxResult,
 				err error,
 			) {
@@ -158,8 +137,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.Empty(t, txResult.Referral)
 
 				require.Equal(t, UserProfileAlreadyExistsError, err)
-
-				require.False(t, *isAfterCreateCalled)
 			},
 		},
 		{
@@ -169,7 +146,6 @@ func TestCreateProfileTx(t *testing.T) {
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
 				newProfileGamerTag string,
-				isAfterCreateCalled *bool,
 			) CreateProfileTxParams {
 				someEthereumWallet, err := util.NewEthereumWallet()
 				require.NoError(t, err)
@@ -183,10 +159,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.NoError(t, err)
 
 				return CreateProfileTxParams{
-					AfterCreate: func() error {
-						*isAfterCreateCalled = true
-						return nil
-					},
 					CreateProfileParams: CreateProfileParams{
 						WalletAddress: newProfileEthereumWallet.Address,
 						GamerTag:      newProfileGamerTag,
@@ -197,7 +169,7 @@ func TestCreateProfileTx(t *testing.T) {
 				t *testing.T,
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
-				newProfileGamerTag string, isAfterCreateCalled *bool,
+				newProfileGamerTag string,
 				txResult CreateProfileTxResult,
 				err error,
 			) {
@@ -205,8 +177,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.Empty(t, txResult.Referral)
 
 				require.Equal(t, GamerTagAlreadyInUseError, err)
-
-				require.False(t, *isAfterCreateCalled)
 			},
 		},
 		{
@@ -216,13 +186,8 @@ func TestCreateProfileTx(t *testing.T) {
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
 				newProfileGamerTag string,
-				isAfterCreateCalled *bool,
 			) CreateProfileTxParams {
 				return CreateProfileTxParams{
-					AfterCreate: func() error {
-						*isAfterCreateCalled = true
-						return nil
-					},
 					CreateProfileParams: CreateProfileParams{
 						WalletAddress: newProfileEthereumWallet.Address,
 						GamerTag:      newProfileGamerTag,
@@ -234,7 +199,7 @@ func TestCreateProfileTx(t *testing.T) {
 				t *testing.T,
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
-				newProfileGamerTag string, isAfterCreateCalled *bool,
+				newProfileGamerTag string,
 				txResult CreateProfileTxResult,
 				err error,
 			) {
@@ -242,8 +207,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.Empty(t, txResult.Referral)
 
 				require.Equal(t, SelfReferralError, err)
-
-				require.False(t, *isAfterCreateCalled)
 			},
 		},
 		{
@@ -253,13 +216,8 @@ func TestCreateProfileTx(t *testing.T) {
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
 				newProfileGamerTag string,
-				isAfterCreateCalled *bool,
 			) CreateProfileTxParams {
 				return CreateProfileTxParams{
-					AfterCreate: func() error {
-						*isAfterCreateCalled = true
-						return nil
-					},
 					CreateProfileParams: CreateProfileParams{
 						WalletAddress: newProfileEthereumWallet.Address,
 						GamerTag:      newProfileGamerTag,
@@ -271,7 +229,7 @@ func TestCreateProfileTx(t *testing.T) {
 				t *testing.T,
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
-				newProfileGamerTag string, isAfterCreateCalled *bool,
+				newProfileGamerTag string,
 				txResult CreateProfileTxResult,
 				err error,
 			) {
@@ -279,8 +237,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.Empty(t, txResult.Referral)
 
 				require.Equal(t, ReferrerDoesNotExistError, err)
-
-				require.False(t, *isAfterCreateCalled)
 			},
 		},
 		{
@@ -290,7 +246,6 @@ func TestCreateProfileTx(t *testing.T) {
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
 				newProfileGamerTag string,
-				isAfterCreateCalled *bool,
 			) CreateProfileTxParams {
 				referrer, err := testStore.CreateProfile(context.Background(), CreateProfileParams{
 					WalletAddress: referrerEthereumWallet.Address,
@@ -318,10 +273,6 @@ func TestCreateProfileTx(t *testing.T) {
 				require.NoError(t, err)
 
 				return CreateProfileTxParams{
-					AfterCreate: func() error {
-						*isAfterCreateCalled = true
-						return nil
-					},
 					CreateProfileParams: CreateProfileParams{
 						WalletAddress: newProfileEthereumWallet.Address,
 						GamerTag:      newProfileGamerTag,
@@ -333,7 +284,7 @@ func TestCreateProfileTx(t *testing.T) {
 				t *testing.T,
 				newProfileEthereumWallet *util.EthereumWallet,
 				referrerEthereumWallet *util.EthereumWallet,
-				newProfileGamerTag string, isAfterCreateCalled *bool,
+				newProfileGamerTag string,
 				txResult CreateProfileTxResult,
 				err error,
 			) {
@@ -341,16 +292,12 @@ func TestCreateProfileTx(t *testing.T) {
 				require.Empty(t, txResult.Referral)
 
 				require.Equal(t, UserAlreadyReferredError, err)
-
-				require.False(t, *isAfterCreateCalled)
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			isAfterCreateCalled := false
-
 			newProfileEthereumWallet, err := util.NewEthereumWallet()
 			require.NoError(t, err)
 			require.NotEmpty(t, newProfileEthereumWallet)
@@ -361,9 +308,9 @@ func TestCreateProfileTx(t *testing.T) {
 			require.NoError(t, err)
 			require.NotEmpty(t, referrerEthereumWallet)
 
-			params := tc.buildParams(t, newProfileEthereumWallet, referrerEthereumWallet, newProfileGamerTag, &isAfterCreateCalled)
+			params := tc.buildParams(t, newProfileEthereumWallet, referrerEthereumWallet, newProfileGamerTag)
 			txResult, err := testStore.CreateProfileTx(context.Background(), params)
-			tc.checkResponse(t, newProfileEthereumWallet, referrerEthereumWallet, newProfileGamerTag, &isAfterCreateCalled, txResult, err)
+			tc.checkResponse(t, newProfileEthereumWallet, referrerEthereumWallet, newProfileGamerTag, txResult, err)
 		})
 	}
 }
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+type DeleteProfileTxParams struct {
+	WalletAddress  string
+	OutboxMessages []CreateOutboxMessageParams
+}
+
+func (store *SQLStore) DeleteProfileTx(ctx context.Context, params DeleteProfileTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		deleted, err := q.DeleteProfile(ctx, params.WalletAddress)
+		if err != nil {
+			log.Error().Err(err).Msg("could not delete user profile in db")
+			return err
+		}
+
+		if deleted == 0 {
+			return RecordNotFoundError
+		}
+
+		return createOutboxMessages(ctx, q, params.OutboxMessages)
+	})
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteProfileTx(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	profile := createTestProfile(t)
+
+	err := testStore.DeleteProfileTx(context.Background(), DeleteProfileTxParams{
+		WalletAddress: profile.WalletAddress,
+		OutboxMessages: []CreateOutboxMessageParams{
+			{
+				Sink:    "events",
+				Topic:   "profile.deleted",
+				Payload: []byte(`{}`),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.GetProfile(context.Background(), profile.WalletAddress)
+	require.Equal(t, RecordNotFoundError, err)
+}
+
+func TestDeleteProfileTx_NotFound(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	err := testStore.DeleteProfileTx(context.Background(), DeleteProfileTxParams{
+		WalletAddress: "0x0000000000000000000000000000000000000000",
+		OutboxMessages: []CreateOutboxMessageParams{
+			{
+				Sink:    "events",
+				Topic:   "profile.deleted",
+				Payload: []byte(`{}`),
+			},
+		},
+	})
+	require.Equal(t, RecordNotFoundError, err)
+}
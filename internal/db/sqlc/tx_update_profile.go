@@ -3,43 +3,47 @@ package db
 import (
 	"context"
 
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
 )
 
 type UpdateProfileTxParams struct {
-	WalletAddress string
-	GamerTag      string
-	AfterCreate   func() (string, error)
+	UpdateProfileParams
+	OutboxMessages []CreateOutboxMessageParams
 }
 
 type UpdateProfileTxResult struct {
-	EnsName string
 	Profile Profile
+	Changed bool
 }
 
 func (store *SQLStore) UpdateProfileTx(ctx context.Context, params UpdateProfileTxParams) (UpdateProfileTxResult, error) {
 	var result UpdateProfileTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
-		var err error
-
-		arg := UpdateProfileParams{
-			WalletAddress: params.WalletAddress,
-			GamerTag: pgtype.Text{
-				String: params.GamerTag,
-				Valid:  params.GamerTag != "",
-			},
+		// the row stays locked until commit, so concurrent updates see each other's changes
+		current, err := q.GetProfileForUpdate(ctx, params.WalletAddress)
+		if err != nil {
+			log.Error().Err(err).Msg("could not get user profile in db")
+			return err
 		}
 
-		result.Profile, err = q.UpdateProfile(ctx, arg)
+		result.Profile, err = q.UpdateProfile(ctx, params.UpdateProfileParams)
 		if err != nil {
+			log.Error().Err(err).Msg("could not update user profile in db")
 			return err
 		}
 
-		result.EnsName, err = params.AfterCreate()
+		// the gamer tag is the only field that can be updated
+		result.Changed = result.Profile.GamerTag != current.GamerTag
+		if !result.Changed {
+			return nil
+		}
 
-		return err
+		return createOutboxMessages(ctx, q, params.OutboxMessages)
 	})
+	if err != nil {
+		return UpdateProfileTxResult{}, err
+	}
 
-	return result, err
+	return result, nil
 }
@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
-	"github.com/kyamalabs/auth/pkg/util"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,37 +15,74 @@ func TestUpdateProfileTx(t *testing.T) {
 		t.Skip("skipping test to maintain db state")
 	}
 
-	ethereumWallet, err := util.NewEthereumWallet()
-	require.NoError(t, err)
-	require.NotEmpty(t, ethereumWallet)
+	profile := createTestProfile(t)
+	updatedGamerTag := gofakeit.Gamertag()
 
-	profile, err := testStore.CreateProfile(context.Background(), CreateProfileParams{
-		WalletAddress: ethereumWallet.Address,
-		GamerTag:      gofakeit.Gamertag(),
+	txResult, err := testStore.UpdateProfileTx(context.Background(), UpdateProfileTxParams{
+		UpdateProfileParams: UpdateProfileParams{
+			WalletAddress: profile.WalletAddress,
+			GamerTag: pgtype.Text{
+				String: updatedGamerTag,
+				Valid:  true,
+			},
+		},
+		OutboxMessages: []CreateOutboxMessageParams{
+			{
+				Sink:    "events",
+				Topic:   "profile.gamer_tag_changed",
+				Payload: []byte(`{}`),
+			},
+		},
 	})
 	require.NoError(t, err)
-	require.NotEmpty(t, profile)
-
-	isAfterCreateCalled := false
+	require.True(t, txResult.Changed)
 
-	updatedGamerTag := gofakeit.Gamertag()
+	require.Equal(t, profile.WalletAddress, txResult.Profile.WalletAddress)
+	require.Equal(t, updatedGamerTag, txResult.Profile.GamerTag)
+	require.WithinDuration(t, profile.CreatedAt, txResult.Profile.CreatedAt, time.Second)
+}
 
-	params := UpdateProfileTxParams{
-		WalletAddress: ethereumWallet.Address,
-		GamerTag:      updatedGamerTag,
-		AfterCreate: func() (string, error) {
-			isAfterCreateCalled = true
-			return "", nil
-		},
+func TestUpdateProfileTx_Unchanged(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
 	}
 
-	txResult, err := testStore.UpdateProfileTx(context.Background(), params)
+	profile := createTestProfile(t)
+
+	txResult, err := testStore.UpdateProfileTx(context.Background(), UpdateProfileTxParams{
+		UpdateProfileParams: UpdateProfileParams{
+			WalletAddress: profile.WalletAddress,
+			GamerTag: pgtype.Text{
+				String: profile.GamerTag,
+				Valid:  true,
+			},
+		},
+		OutboxMessages: []CreateOutboxMessageParams{
+			{
+				Sink:    "events",
+				Topic:   "profile.gamer_tag_changed",
+				Payload: []byte(`{}`),
+			},
+		},
+	})
 	require.NoError(t, err)
-	require.NotEmpty(t, txResult)
+	require.False(t, txResult.Changed)
+	require.Equal(t, profile.GamerTag, txResult.Profile.GamerTag)
+}
 
-	require.Equal(t, params.WalletAddress, txResult.Profile.WalletAddress)
-	require.Equal(t, updatedGamerTag, txResult.Profile.GamerTag)
-	require.WithinDuration(t, profile.CreatedAt, txResult.Profile.CreatedAt, time.Second)
+func TestUpdateProfileTx_NotFound(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
 
-	require.True(t, isAfterCreateCalled)
+	_, err := testStore.UpdateProfileTx(context.Background(), UpdateProfileTxParams{
+		UpdateProfileParams: UpdateProfileParams{
+			WalletAddress: "0x0000000000000000000000000000000000000000",
+			GamerTag: pgtype.Text{
+				String: gofakeit.Gamertag(),
+				Valid:  true,
+			},
+		},
+	})
+	require.Equal(t, RecordNotFoundError, err)
 }
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestWebhook(t *testing.T, eventTypes ...string) Webhook {
+	params := CreateWebhookParams{
+		Url:        gofakeit.URL(),
+		Secret:     gofakeit.Password(true, true, true, false, false, 32),
+		EventTypes: eventTypes,
+	}
+
+	webhook, err := testStore.CreateWebhook(context.Background(), params)
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook)
+
+	require.Equal(t, params.Url, webhook.Url)
+	require.Equal(t, params.Secret, webhook.Secret)
+	require.Equal(t, params.EventTypes, webhook.EventTypes)
+	require.NotZero(t, webhook.CreatedAt)
+
+	return webhook
+}
+
+func TestCreateWebhook(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	webhook := createTestWebhook(t, "referral.recorded")
+	require.NotEmpty(t, webhook)
+}
+
+func TestListWebhooksForEventType(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	eventType := "test." + gofakeit.UUID()
+	subscribed := createTestWebhook(t, "referral.recorded", eventType)
+	createTestWebhook(t, "referral.recorded")
+
+	webhooks, err := testStore.ListWebhooksForEventType(context.Background(), eventType)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, subscribed.ID, webhooks[0].ID)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	webhook := createTestWebhook(t, "referral.recorded")
+
+	err := testStore.DeleteWebhook(context.Background(), webhook.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.GetWebhook(context.Background(), webhook.ID)
+	require.ErrorIs(t, err, RecordNotFoundError)
+}
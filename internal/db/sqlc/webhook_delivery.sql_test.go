@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestWebhookDelivery(t *testing.T, webhook Webhook) WebhookDelivery {
+	eventID := gofakeit.UUID()
+	params := CreateWebhookDeliveryParams{
+		WebhookID: webhook.ID,
+		EventID:   eventID,
+		EventType: "referral.recorded",
+		Payload:   []byte(fmt.Sprintf(`{"id": "%s"}`, eventID)),
+	}
+
+	delivery, err := testStore.CreateWebhookDelivery(context.Background(), params)
+	require.NoError(t, err)
+	require.NotEmpty(t, delivery)
+
+	require.Equal(t, params.WebhookID, delivery.WebhookID)
+	require.Equal(t, params.EventID, delivery.EventID)
+	require.Equal(t, params.EventType, delivery.EventType)
+	require.JSONEq(t, string(params.Payload), string(delivery.Payload))
+	require.Equal(t, "pending", delivery.Status)
+	require.Zero(t, delivery.Attempts)
+	require.False(t, delivery.DeliveredAt.Valid)
+
+	return delivery
+}
+
+func TestCreateWebhookDelivery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	webhook := createTestWebhook(t, "referral.recorded")
+	delivery := createTestWebhookDelivery(t, webhook)
+
+	// recording the same event again returns the existing delivery
+	again, err := testStore.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		WebhookID: webhook.ID,
+		EventID:   delivery.EventID,
+		EventType: delivery.EventType,
+		Payload:   delivery.Payload,
+	})
+	require.NoError(t, err)
+	require.Equal(t, delivery.ID, again.ID)
+
+	count, err := testStore.GetWebhookDeliveriesCount(context.Background(), webhook.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestUpdateWebhookDeliveryAttempt(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	webhook := createTestWebhook(t, "referral.recorded")
+	delivery := createTestWebhookDelivery(t, webhook)
+
+	failed, err := testStore.UpdateWebhookDeliveryAttempt(context.Background(), UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         "failed",
+		ResponseStatus: pgtype.Int4{Int32: 503, Valid: true},
+		LastError:      pgtype.Text{String: "webhook endpoint responded with status 503", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "failed", failed.Status)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, int32(503), failed.ResponseStatus.Int32)
+
+	reset, err := testStore.ResetWebhookDelivery(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, "pending", reset.Status)
+	require.Equal(t, int32(1), reset.Attempts)
+
+	succeeded, err := testStore.UpdateWebhookDeliveryAttempt(context.Background(), UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         "succeeded",
+		ResponseStatus: pgtype.Int4{Int32: 200, Valid: true},
+		DeliveredAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "succeeded", succeeded.Status)
+	require.Equal(t, int32(2), succeeded.Attempts)
+	require.False(t, succeeded.LastError.Valid)
+	require.True(t, succeeded.DeliveredAt.Valid)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain db state")
+	}
+
+	webhook := createTestWebhook(t, "referral.recorded")
+	for i := 0; i < 3; i++ {
+		createTestWebhookDelivery(t, webhook)
+	}
+
+	deliveries, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		WebhookID: webhook.ID,
+		Limit:     2,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	// deleting a webhook deletes its delivery log
+	err = testStore.DeleteWebhook(context.Background(), webhook.ID)
+	require.NoError(t, err)
+
+	count, err := testStore.GetWebhookDeliveriesCount(context.Background(), webhook.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
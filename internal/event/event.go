@@ -0,0 +1,101 @@
+package event
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kyamalabs/users/api/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	TypeProfileCreated   = "profile.created"
+	TypeGamerTagChanged  = "profile.gamer_tag_changed"
+	TypeProfileDeleted   = "profile.deleted"
+	TypeReferralRecorded = "referral.recorded"
+)
+
+// EventPublisher delivers domain events to the services that react to them.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *pb.Event) error
+}
+
+// Type returns the name consumers use to route an event without decoding its payload.
+func Type(event *pb.Event) string {
+	switch event.GetPayload().(type) {
+	case *pb.Event_ProfileCreated:
+		return TypeProfileCreated
+	case *pb.Event_GamerTagChanged:
+		return TypeGamerTagChanged
+	case *pb.Event_ProfileDeleted:
+		return TypeProfileDeleted
+	case *pb.Event_ReferralRecorded:
+		return TypeReferralRecorded
+	}
+
+	return ""
+}
+
+// IsKnownType reports whether an event type is one this service publishes.
+func IsKnownType(eventType string) bool {
+	switch eventType {
+	case TypeProfileCreated, TypeGamerTagChanged, TypeProfileDeleted, TypeReferralRecorded:
+		return true
+	}
+
+	return false
+}
+
+func newEvent() *pb.Event {
+	return &pb.Event{
+		Id:         uuid.NewString(),
+		OccurredAt: timestamppb.Now(),
+	}
+}
+
+func NewProfileCreated(walletAddress string, gamerTag string) *pb.Event {
+	event := newEvent()
+	event.Payload = &pb.Event_ProfileCreated{
+		ProfileCreated: &pb.ProfileCreated{
+			WalletAddress: walletAddress,
+			GamerTag:      gamerTag,
+		},
+	}
+
+	return event
+}
+
+func NewGamerTagChanged(walletAddress string, gamerTag string) *pb.Event {
+	event := newEvent()
+	event.Payload = &pb.Event_GamerTagChanged{
+		GamerTagChanged: &pb.GamerTagChanged{
+			WalletAddress: walletAddress,
+			GamerTag:      gamerTag,
+		},
+	}
+
+	return event
+}
+
+func NewProfileDeleted(walletAddress string) *pb.Event {
+	event := newEvent()
+	event.Payload = &pb.Event_ProfileDeleted{
+		ProfileDeleted: &pb.ProfileDeleted{
+			WalletAddress: walletAddress,
+		},
+	}
+
+	return event
+}
+
+func NewReferralRecorded(referrer string, referee string) *pb.Event {
+	event := newEvent()
+	event.Payload = &pb.Event_ReferralRecorded{
+		ReferralRecorded: &pb.ReferralRecorded{
+			Referrer: referrer,
+			Referee:  referee,
+		},
+	}
+
+	return event
+}
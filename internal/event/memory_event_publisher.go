@@ -0,0 +1,40 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kyamalabs/users/api/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// MemoryEventPublisher records published events in memory. It is meant for tests and local
+// development where no consumers are running.
+type MemoryEventPublisher struct {
+	mu     sync.Mutex
+	events []*pb.Event
+}
+
+func NewMemoryEventPublisher() *MemoryEventPublisher {
+	return &MemoryEventPublisher{}
+}
+
+func (publisher *MemoryEventPublisher) Publish(_ context.Context, event *pb.Event) error {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	publisher.events = append(publisher.events, proto.Clone(event).(*pb.Event))
+
+	return nil
+}
+
+// Events returns the events published so far in publish order.
+func (publisher *MemoryEventPublisher) Events() []*pb.Event {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	events := make([]*pb.Event, len(publisher.events))
+	copy(events, publisher.events)
+
+	return events
+}
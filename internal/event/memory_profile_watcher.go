@@ -0,0 +1,51 @@
+package event
+
+import (
+	"context"
+
+	"github.com/kyamalabs/users/api/pb"
+)
+
+// MemoryProfileWatcher fans out profile changes within a single process. It is meant for tests
+// and local development.
+type MemoryProfileWatcher struct {
+	hub *profileChangeHub
+}
+
+func NewMemoryProfileWatcher() *MemoryProfileWatcher {
+	return &MemoryProfileWatcher{
+		hub: newProfileChangeHub(0),
+	}
+}
+
+func (watcher *MemoryProfileWatcher) Notify(_ context.Context, change *pb.ProfileChange) error {
+	watcher.hub.publish(getProfileChangesChannel(change.GetWalletAddress()), change)
+
+	return nil
+}
+
+func (watcher *MemoryProfileWatcher) Watch(ctx context.Context, walletAddresses []string) (<-chan *pb.ProfileChange, error) {
+	channels := make([]string, 0, len(walletAddresses))
+	for _, walletAddress := range walletAddresses {
+		channels = append(channels, getProfileChangesChannel(walletAddress))
+	}
+
+	changes, _, err := watcher.hub.add(channels)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		watcher.hub.remove(changes)
+	}()
+
+	return changes, nil
+}
+
+// Close ends every watch.
+func (watcher *MemoryProfileWatcher) Close() error {
+	watcher.hub.close()
+
+	return nil
+}
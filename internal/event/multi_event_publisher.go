@@ -0,0 +1,32 @@
+package event
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kyamalabs/users/api/pb"
+)
+
+// MultiEventPublisher publishes every event to each of its publishers. An event is retried as a
+// whole when any publisher fails, so publishers must tolerate seeing the same event id twice.
+type MultiEventPublisher struct {
+	publishers []EventPublisher
+}
+
+func NewMultiEventPublisher(publishers ...EventPublisher) EventPublisher {
+	return &MultiEventPublisher{
+		publishers: publishers,
+	}
+}
+
+func (publisher *MultiEventPublisher) Publish(ctx context.Context, event *pb.Event) error {
+	var errs []error
+
+	for _, p := range publisher.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
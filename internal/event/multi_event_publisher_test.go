@@ -0,0 +1,38 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/stretchr/testify/require"
+)
+
+type failingEventPublisher struct {
+	err error
+}
+
+func (publisher failingEventPublisher) Publish(context.Context, *pb.Event) error {
+	return publisher.err
+}
+
+func TestMultiEventPublisher_Publish(t *testing.T) {
+	e := NewProfileCreated("0x0000000000000000000000000000000000000001", "mamabear")
+
+	first := NewMemoryEventPublisher()
+	second := NewMemoryEventPublisher()
+
+	err := NewMultiEventPublisher(first, second).Publish(context.Background(), e)
+	require.NoError(t, err)
+	require.Len(t, first.Events(), 1)
+	require.Len(t, second.Events(), 1)
+
+	// a failing publisher does not stop the others from receiving the event
+	publishErr := errors.New("some publish error")
+	third := NewMemoryEventPublisher()
+
+	err = NewMultiEventPublisher(failingEventPublisher{err: publishErr}, third).Publish(context.Background(), e)
+	require.ErrorIs(t, err, publishErr)
+	require.Len(t, third.Events(), 1)
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyamalabs/users/api/pb"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const OutboxSinkEvents = "events"
+
+// NewOutboxMessages builds outbox messages that publish events once the transaction they are
+// recorded in commits.
+func NewOutboxMessages(events ...*pb.Event) ([]db.CreateOutboxMessageParams, error) {
+	messages := make([]db.CreateOutboxMessageParams, 0, len(events))
+
+	for _, event := range events {
+		payload, err := protojson.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("could not serialize event: %w", err)
+		}
+
+		messages = append(messages, db.CreateOutboxMessageParams{
+			Sink:    OutboxSinkEvents,
+			Topic:   Type(event),
+			Payload: payload,
+		})
+	}
+
+	return messages, nil
+}
+
+// OutboxSink publishes events relayed from the outbox.
+type OutboxSink struct {
+	publisher EventPublisher
+}
+
+func NewOutboxSink(publisher EventPublisher) *OutboxSink {
+	return &OutboxSink{
+		publisher: publisher,
+	}
+}
+
+func (sink *OutboxSink) Publish(ctx context.Context, message db.Outbox) error {
+	var event pb.Event
+	if err := protojson.Unmarshal(message.Payload, &event); err != nil {
+		return fmt.Errorf("could not deserialize event: %w", err)
+	}
+
+	return sink.publisher.Publish(ctx, &event)
+}
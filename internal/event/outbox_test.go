@@ -0,0 +1,75 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyamalabs/users/api/pb"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOutboxSink_Publish(t *testing.T) {
+	events := []*pb.Event{
+		NewProfileCreated("0x0000000000000000000000000000000000000001", "mamabear"),
+		NewGamerTagChanged("0x0000000000000000000000000000000000000001", "papabear"),
+		NewReferralRecorded("0x0000000000000000000000000000000000000002", "0x0000000000000000000000000000000000000001"),
+		NewProfileDeleted("0x0000000000000000000000000000000000000001"),
+	}
+
+	messages, err := NewOutboxMessages(events...)
+	require.NoError(t, err)
+	require.Len(t, messages, len(events))
+
+	publisher := NewMemoryEventPublisher()
+	sink := NewOutboxSink(publisher)
+
+	for i, message := range messages {
+		require.Equal(t, OutboxSinkEvents, message.Sink)
+		require.Equal(t, Type(events[i]), message.Topic)
+
+		err = sink.Publish(context.Background(), db.Outbox{
+			Sink:    message.Sink,
+			Topic:   message.Topic,
+			Payload: message.Payload,
+		})
+		require.NoError(t, err)
+	}
+
+	published := publisher.Events()
+	require.Len(t, published, len(events))
+	for i := range events {
+		require.True(t, proto.Equal(events[i], published[i]))
+	}
+}
+
+func TestOutboxSink_PublishInvalidPayload(t *testing.T) {
+	publisher := NewMemoryEventPublisher()
+	sink := NewOutboxSink(publisher)
+
+	err := sink.Publish(context.Background(), db.Outbox{
+		Sink:    OutboxSinkEvents,
+		Topic:   TypeProfileCreated,
+		Payload: []byte("not an event"),
+	})
+	require.Error(t, err)
+	require.Empty(t, publisher.Events())
+}
+
+func TestType(t *testing.T) {
+	testCases := []struct {
+		event        *pb.Event
+		expectedType string
+	}{
+		{event: NewProfileCreated("0x01", "mamabear"), expectedType: TypeProfileCreated},
+		{event: NewGamerTagChanged("0x01", "mamabear"), expectedType: TypeGamerTagChanged},
+		{event: NewProfileDeleted("0x01"), expectedType: TypeProfileDeleted},
+		{event: NewReferralRecorded("0x02", "0x01"), expectedType: TypeReferralRecorded},
+		{event: &pb.Event{}, expectedType: ""},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expectedType, Type(tc.event))
+	}
+}
@@ -0,0 +1,123 @@
+package event
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrTooManyProfileWatchers = errors.New("too many profile watchers")
+	ErrProfileWatcherClosed   = errors.New("profile watcher is closed")
+)
+
+// profileChangeHub fans profile changes out to the watchers of this process. A watcher that
+// falls behind misses changes rather than holding up the others.
+type profileChangeHub struct {
+	mu          sync.Mutex
+	maxWatchers int
+	channels    map[string]map[chan *pb.ProfileChange]struct{}
+	watchers    map[chan *pb.ProfileChange][]string
+	closed      bool
+}
+
+// newProfileChangeHub accepts at most maxWatchers watchers at a time, or any number of them if
+// maxWatchers is not positive.
+func newProfileChangeHub(maxWatchers int) *profileChangeHub {
+	return &profileChangeHub{
+		maxWatchers: maxWatchers,
+		channels:    make(map[string]map[chan *pb.ProfileChange]struct{}),
+		watchers:    make(map[chan *pb.ProfileChange][]string),
+	}
+}
+
+// add registers a watcher of channels and returns the channels nobody watched before. Channels
+// listed more than once are watched once, so that removing the watcher releases each of them once.
+func (hub *profileChangeHub) add(channels []string) (chan *pb.ProfileChange, []string, error) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if hub.closed {
+		return nil, nil, ErrProfileWatcherClosed
+	}
+	if hub.maxWatchers > 0 && len(hub.watchers) >= hub.maxWatchers {
+		return nil, nil, ErrTooManyProfileWatchers
+	}
+
+	unique := make([]string, 0, len(channels))
+	seen := make(map[string]struct{}, len(channels))
+	for _, channel := range channels {
+		if _, ok := seen[channel]; ok {
+			continue
+		}
+		seen[channel] = struct{}{}
+		unique = append(unique, channel)
+	}
+
+	changes := make(chan *pb.ProfileChange, profileChangesBufferSize)
+	hub.watchers[changes] = unique
+
+	var added []string
+	for _, channel := range unique {
+		if hub.channels[channel] == nil {
+			hub.channels[channel] = make(map[chan *pb.ProfileChange]struct{})
+			added = append(added, channel)
+		}
+		hub.channels[channel][changes] = struct{}{}
+	}
+
+	return changes, added, nil
+}
+
+// remove closes the changes of a watcher and returns the channels nobody watches anymore.
+func (hub *profileChangeHub) remove(changes chan *pb.ProfileChange) []string {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	channels, ok := hub.watchers[changes]
+	if !ok {
+		return nil
+	}
+	delete(hub.watchers, changes)
+	close(changes)
+
+	var removed []string
+	for _, channel := range channels {
+		delete(hub.channels[channel], changes)
+		if len(hub.channels[channel]) == 0 {
+			delete(hub.channels, channel)
+			removed = append(removed, channel)
+		}
+	}
+
+	return removed
+}
+
+func (hub *profileChangeHub) publish(channel string, change *pb.ProfileChange) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for changes := range hub.channels[channel] {
+		select {
+		case changes <- proto.Clone(change).(*pb.ProfileChange):
+		default:
+			log.Warn().Str("wallet_address", change.GetWalletAddress()).Msg("dropped profile change for slow watcher")
+		}
+	}
+}
+
+// close ends every watch and rejects new ones.
+func (hub *profileChangeHub) close() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	hub.closed = true
+	for changes := range hub.watchers {
+		close(changes)
+	}
+	hub.watchers = make(map[chan *pb.ProfileChange][]string)
+	hub.channels = make(map[string]map[chan *pb.ProfileChange]struct{})
+}
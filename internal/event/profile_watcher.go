@@ -0,0 +1,235 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	profileChangesChannelPrefix = "profiles:changes"
+	profileChangesBufferSize    = 16
+	defaultMaxProfileWatchers   = 10000
+)
+
+// ProfileWatcher fans out live profile changes to the streams watching them. Changes are
+// delivered at most once and only to watchers subscribed when the change is notified.
+type ProfileWatcher interface {
+	Notify(ctx context.Context, change *pb.ProfileChange) error
+	// Watch returns a channel of changes to the given profiles. The channel is closed once ctx
+	// is done or the watcher is closed. It fails with ErrTooManyProfileWatchers when the
+	// process already serves as many watchers as it accepts.
+	Watch(ctx context.Context, walletAddresses []string) (<-chan *pb.ProfileChange, error)
+}
+
+func getProfileChangesChannel(walletAddress string) string {
+	return fmt.Sprintf("%s:%s", profileChangesChannelPrefix, strings.ToLower(walletAddress))
+}
+
+// RedisProfileWatcher shares profile changes between replicas over Redis pub/sub with a
+// channel per wallet address. All watchers of the process share one subscription, which is
+// subscribed to a channel while anybody watches it.
+type RedisProfileWatcher struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+	hub    *profileChangeHub
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// mu serializes changes to the subscription with the watchers they are made for
+	mu        sync.Mutex
+	confirmed map[string]chan struct{}
+}
+
+// NewRedisProfileWatcher accepts at most maxWatchers concurrent watchers.
+func NewRedisProfileWatcher(connURL string, maxWatchers int) (ProfileWatcher, error) {
+	opts, err := redis.ParseURL(connURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse redis connection url: %w", err)
+	}
+
+	if maxWatchers <= 0 {
+		maxWatchers = defaultMaxProfileWatchers
+	}
+
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	watcher := &RedisProfileWatcher{
+		client:    client,
+		pubsub:    client.Subscribe(ctx),
+		hub:       newProfileChangeHub(maxWatchers),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		confirmed: make(map[string]chan struct{}),
+	}
+
+	go watcher.receive(ctx)
+
+	return watcher, nil
+}
+
+// Close ends every watch and closes the subscription.
+func (watcher *RedisProfileWatcher) Close() error {
+	watcher.cancel()
+	watcher.hub.close()
+
+	err := watcher.pubsub.Close()
+	<-watcher.done
+
+	return errors.Join(err, watcher.client.Close())
+}
+
+func (watcher *RedisProfileWatcher) Notify(ctx context.Context, change *pb.ProfileChange) error {
+	payload, err := proto.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("could not serialize profile change: %w", err)
+	}
+
+	err = watcher.client.Publish(ctx, getProfileChangesChannel(change.GetWalletAddress()), payload).Err()
+	if err != nil {
+		return fmt.Errorf("could not publish profile change: %w", err)
+	}
+
+	return nil
+}
+
+func (watcher *RedisProfileWatcher) Watch(ctx context.Context, walletAddresses []string) (<-chan *pb.ProfileChange, error) {
+	channels := make([]string, 0, len(walletAddresses))
+	for _, walletAddress := range walletAddresses {
+		channels = append(channels, getProfileChangesChannel(walletAddress))
+	}
+
+	changes, confirmations, err := watcher.subscribe(ctx, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	// wait for the subscription to be confirmed so that no change notified after Watch returns is missed
+	for _, confirmed := range confirmations {
+		select {
+		case <-confirmed:
+		case <-ctx.Done():
+			watcher.unsubscribe(changes)
+			return nil, fmt.Errorf("could not subscribe to profile changes: %w", ctx.Err())
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		watcher.unsubscribe(changes)
+	}()
+
+	return changes, nil
+}
+
+func (watcher *RedisProfileWatcher) subscribe(ctx context.Context, channels []string) (chan *pb.ProfileChange, []chan struct{}, error) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	changes, added, err := watcher.hub.add(channels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(added) > 0 {
+		for _, channel := range added {
+			watcher.confirmed[channel] = make(chan struct{})
+		}
+
+		err = watcher.pubsub.Subscribe(ctx, added...)
+		if err != nil {
+			watcher.removeLocked(changes)
+			return nil, nil, fmt.Errorf("could not subscribe to profile changes: %w", err)
+		}
+	}
+
+	confirmations := make([]chan struct{}, 0, len(channels))
+	for _, channel := range channels {
+		confirmations = append(confirmations, watcher.confirmed[channel])
+	}
+
+	return changes, confirmations, nil
+}
+
+func (watcher *RedisProfileWatcher) unsubscribe(changes chan *pb.ProfileChange) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	watcher.removeLocked(changes)
+}
+
+// removeLocked ends a watch and unsubscribes from the channels nobody watches anymore. mu must
+// be held.
+func (watcher *RedisProfileWatcher) removeLocked(changes chan *pb.ProfileChange) {
+	removed := watcher.hub.remove(changes)
+	if len(removed) == 0 {
+		return
+	}
+
+	for _, channel := range removed {
+		delete(watcher.confirmed, channel)
+	}
+
+	err := watcher.pubsub.Unsubscribe(context.Background(), removed...)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not unsubscribe from profile changes")
+	}
+}
+
+// receive fans the messages of the shared subscription out to the watchers until ctx is done.
+func (watcher *RedisProfileWatcher) receive(ctx context.Context) {
+	defer close(watcher.done)
+
+	messages := watcher.pubsub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			switch message := message.(type) {
+			case *redis.Subscription:
+				watcher.confirm(message)
+			case *redis.Message:
+				var change pb.ProfileChange
+				if err := proto.Unmarshal([]byte(message.Payload), &change); err != nil {
+					log.Warn().Err(err).Str("channel", message.Channel).Msg("could not deserialize profile change")
+					continue
+				}
+
+				watcher.hub.publish(message.Channel, &change)
+			}
+		}
+	}
+}
+
+func (watcher *RedisProfileWatcher) confirm(subscription *redis.Subscription) {
+	if subscription.Kind != "subscribe" {
+		return
+	}
+
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	confirmed, ok := watcher.confirmed[subscription.Channel]
+	if !ok {
+		return
+	}
+
+	select {
+	case <-confirmed:
+	default:
+		close(confirmed)
+	}
+}
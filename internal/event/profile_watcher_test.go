@@ -0,0 +1,128 @@
+package event
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func testProfileWatcher(t *testing.T, watcher ProfileWatcher) {
+	watchedAddress := "0xc0ffee254729296a45a3885639AC7E10F9d54979"
+	unwatchedAddress := "0x0000000000000000000000000000000000000001"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := watcher.Watch(ctx, []string{watchedAddress})
+	require.NoError(t, err)
+
+	err = watcher.Notify(context.Background(), &pb.ProfileChange{
+		Type:          pb.ProfileChangeType_PROFILE_CHANGE_TYPE_DELETED,
+		WalletAddress: unwatchedAddress,
+	})
+	require.NoError(t, err)
+
+	// addresses are matched case insensitively
+	err = watcher.Notify(context.Background(), &pb.ProfileChange{
+		Type:          pb.ProfileChangeType_PROFILE_CHANGE_TYPE_ENS_NAME_RESOLVED,
+		WalletAddress: "0xC0FFEE254729296A45A3885639AC7E10F9D54979",
+		EnsName:       "mamabear.eth",
+		EnsStatus:     pb.EnsStatus_ENS_STATUS_RESOLVED,
+	})
+	require.NoError(t, err)
+
+	select {
+	case change := <-changes:
+		require.Equal(t, pb.ProfileChangeType_PROFILE_CHANGE_TYPE_ENS_NAME_RESOLVED, change.GetType())
+		require.Equal(t, "mamabear.eth", change.GetEnsName())
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for profile change")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-changes:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		require.Fail(t, "changes channel was not closed")
+	}
+}
+
+func TestMemoryProfileWatcher(t *testing.T) {
+	testProfileWatcher(t, NewMemoryProfileWatcher())
+}
+
+func TestProfileChangeHub_MaxWatchers(t *testing.T) {
+	watcher := &MemoryProfileWatcher{hub: newProfileChangeHub(1)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := watcher.Watch(ctx, []string{"0x0000000000000000000000000000000000000001"})
+	require.NoError(t, err)
+
+	_, err = watcher.Watch(context.Background(), []string{"0x0000000000000000000000000000000000000002"})
+	require.ErrorIs(t, err, ErrTooManyProfileWatchers)
+
+	// watchers that are gone make room for new ones
+	cancel()
+	require.Eventually(t, func() bool {
+		_, err = watcher.Watch(context.Background(), []string{"0x0000000000000000000000000000000000000002"})
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProfileChangeHub_DuplicateChannels(t *testing.T) {
+	hub := newProfileChangeHub(0)
+
+	// the same address in different case maps to the same channel
+	channels := []string{
+		getProfileChangesChannel("0xc0ffee254729296a45a3885639AC7E10F9d54979"),
+		getProfileChangesChannel("0xC0FFEE254729296A45A3885639AC7E10F9D54979"),
+	}
+
+	changes, added, err := hub.add(channels)
+	require.NoError(t, err)
+	require.Equal(t, channels[:1], added)
+
+	// the channel is released only once
+	require.Equal(t, channels[:1], hub.remove(changes))
+}
+
+func TestMemoryProfileWatcher_Close(t *testing.T) {
+	watcher := NewMemoryProfileWatcher()
+
+	changes, err := watcher.Watch(context.Background(), []string{"0x0000000000000000000000000000000000000001"})
+	require.NoError(t, err)
+
+	require.NoError(t, watcher.Close())
+
+	_, ok := <-changes
+	require.False(t, ok)
+
+	_, err = watcher.Watch(context.Background(), []string{"0x0000000000000000000000000000000000000001"})
+	require.ErrorIs(t, err, ErrProfileWatcherClosed)
+}
+
+func TestRedisProfileWatcher(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that requires redis")
+	}
+
+	config, err := util.LoadConfig("../../")
+	require.NoError(t, err)
+
+	watcher, err := NewRedisProfileWatcher(config.RedisConnURL, 0)
+	require.NoError(t, err)
+	defer watcher.(io.Closer).Close()
+
+	testProfileWatcher(t, watcher)
+
+	// watchers of the same profile share the subscription
+	testProfileWatcher(t, watcher)
+}
@@ -0,0 +1,73 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultStream       = "users:events"
+	defaultStreamMaxLen = 100000
+)
+
+// RedisStreamEventPublisher appends events to a Redis stream. Each entry carries the event id
+// and type next to the protobuf encoded event so consumers can filter without decoding it.
+type RedisStreamEventPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+func NewRedisStreamEventPublisher(connURL string, stream string, maxLen int64) (EventPublisher, error) {
+	opts, err := redis.ParseURL(connURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse redis connection url: %w", err)
+	}
+
+	if stream == "" {
+		stream = defaultStream
+	}
+
+	if maxLen <= 0 {
+		maxLen = defaultStreamMaxLen
+	}
+
+	publisher := &RedisStreamEventPublisher{
+		client: redis.NewClient(opts),
+		stream: stream,
+		maxLen: maxLen,
+	}
+
+	return publisher, nil
+}
+
+func (publisher *RedisStreamEventPublisher) Close() error {
+	return publisher.client.Close()
+}
+
+func (publisher *RedisStreamEventPublisher) Publish(ctx context.Context, event *pb.Event) error {
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("could not serialize event: %w", err)
+	}
+
+	err = publisher.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: publisher.stream,
+		MaxLen: publisher.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"id":      event.GetId(),
+			"type":    Type(event),
+			"payload": payload,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("could not add event to stream: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyamalabs/users/api/pb"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRedisStreamEventPublisher_Publish(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that requires redis")
+	}
+
+	config, err := util.LoadConfig("../../")
+	require.NoError(t, err)
+
+	stream := "users:events:test"
+
+	publisher, err := NewRedisStreamEventPublisher(config.RedisConnURL, stream, 10)
+	require.NoError(t, err)
+
+	client := publisher.(*RedisStreamEventPublisher).client
+	defer client.Del(context.Background(), stream)
+
+	event := NewProfileCreated("0x0000000000000000000000000000000000000001", "mamabear")
+
+	err = publisher.Publish(context.Background(), event)
+	require.NoError(t, err)
+
+	entries, err := client.XRevRangeN(context.Background(), stream, "+", "-", 1).Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.Equal(t, event.GetId(), entries[0].Values["id"])
+	require.Equal(t, TypeProfileCreated, entries[0].Values["type"])
+
+	var published pb.Event
+	err = proto.Unmarshal([]byte(entries[0].Values["payload"].(string)), &published)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(event, &published))
+}
@@ -0,0 +1,200 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+
+	defaultCheckTimeout = 2 * time.Second
+)
+
+// CheckFunc reports whether a dependency the service needs to serve requests is usable.
+type CheckFunc func(ctx context.Context) error
+
+// Pinger is implemented by dependencies that can cheaply check their connection.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type check struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker runs the dependency checks behind the readiness endpoints. Liveness only reflects
+// that the process is up, so an outage of a dependency never gets the service restarted.
+type Checker struct {
+	mu       sync.RWMutex
+	checks   []check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+
+	return &Checker{
+		timeout: timeout,
+	}
+}
+
+// AddCheck registers a dependency check under name.
+func (checker *Checker) AddCheck(name string, c CheckFunc) {
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+
+	checker.checks = append(checker.checks, check{name: name, check: c})
+}
+
+// Drain marks the service as shutting down so readiness fails and traffic is routed away while
+// in-flight requests finish. It is safe to call more than once.
+func (checker *Checker) Drain() {
+	if !checker.draining.Swap(true) {
+		log.Info().Msg("marked service as not ready to drain traffic")
+	}
+}
+
+func (checker *Checker) IsDraining() bool {
+	return checker.draining.Load()
+}
+
+// Check runs every registered check concurrently, each bounded by the check timeout, and
+// returns the error of each failing check by name.
+func (checker *Checker) Check(ctx context.Context) map[string]error {
+	checker.mu.RLock()
+	checks := make([]check, len(checker.checks))
+	copy(checks, checker.checks)
+	checker.mu.RUnlock()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]error, len(checks))
+
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checker.timeout)
+			defer cancel()
+
+			err := c.check(checkCtx)
+
+			mu.Lock()
+			results[c.name] = err
+			mu.Unlock()
+		}(c)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// Ready runs the checks and summarizes them in a report. The service is ready when it is not
+// draining and every check passes.
+func (checker *Checker) Ready(ctx context.Context) (Report, bool) {
+	results := checker.Check(ctx)
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]string, len(results)),
+	}
+
+	for name, err := range results {
+		if err != nil {
+			log.Warn().Err(err).Str("check", name).Msg("health check failed")
+			// the report is served publicly, so the error is only logged
+			report.Checks[name] = StatusFailing
+			report.Status = StatusFailing
+			continue
+		}
+
+		report.Checks[name] = StatusOK
+	}
+
+	if checker.IsDraining() {
+		report.Status = StatusDraining
+	}
+
+	return report, report.Status == StatusOK
+}
+
+// LivenessHandler serves /healthz. It succeeds as long as the process can serve HTTP.
+func (checker *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		writeReport(res, Report{Status: StatusOK}, http.StatusOK)
+	})
+}
+
+// ReadinessHandler serves /readyz. It fails with 503 when a dependency is unusable or the
+// service is draining.
+func (checker *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		report, ready := checker.Ready(req.Context())
+
+		statusCode := http.StatusOK
+		if !ready {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		writeReport(res, report, statusCode)
+	})
+}
+
+func writeReport(res http.ResponseWriter, report Report, statusCode int) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(statusCode)
+
+	err := json.NewEncoder(res).Encode(report)
+	if err != nil {
+		log.Error().Err(err).Msg("could not write health report")
+	}
+}
+
+// WatchGrpc keeps the serving status of the grpc.health.v1 service in line with readiness until
+// ctx is done, after which every service is reported as not serving.
+func (checker *Checker) WatchGrpc(ctx context.Context, server *health.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checker.updateGrpcStatus(ctx, server)
+
+		select {
+		case <-ctx.Done():
+			server.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (checker *Checker) updateGrpcStatus(ctx context.Context, server *health.Server) {
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	if _, ready := checker.Ready(ctx); !ready {
+		servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	server.SetServingStatus("", servingStatus)
+}
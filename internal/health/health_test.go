@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func passingCheck(_ context.Context) error {
+	return nil
+}
+
+func failingCheck(_ context.Context) error {
+	return errors.New("some connection error")
+}
+
+func TestReadinessHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		checks         map[string]CheckFunc
+		drain          bool
+		expectedStatus int
+		expectedReport Report
+	}{
+		{
+			name: "all checks pass",
+			checks: map[string]CheckFunc{
+				"postgres": passingCheck,
+				"cache":    passingCheck,
+			},
+			expectedStatus: http.StatusOK,
+			expectedReport: Report{
+				Status: StatusOK,
+				Checks: map[string]string{"postgres": StatusOK, "cache": StatusOK},
+			},
+		},
+		{
+			name: "a check fails",
+			checks: map[string]CheckFunc{
+				"postgres": passingCheck,
+				"cache":    failingCheck,
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: Report{
+				Status: StatusFailing,
+				Checks: map[string]string{"postgres": StatusOK, "cache": StatusFailing},
+			},
+		},
+		{
+			name: "a check times out",
+			checks: map[string]CheckFunc{
+				"postgres": func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: Report{
+				Status: StatusFailing,
+				Checks: map[string]string{"postgres": StatusFailing},
+			},
+		},
+		{
+			name: "draining",
+			checks: map[string]CheckFunc{
+				"postgres": passingCheck,
+			},
+			drain:          true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: Report{
+				Status: StatusDraining,
+				Checks: map[string]string{"postgres": StatusOK},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker(10 * time.Millisecond)
+			for name, check := range tc.checks {
+				checker.AddCheck(name, check)
+			}
+
+			if tc.drain {
+				checker.Drain()
+			}
+
+			recorder := httptest.NewRecorder()
+			checker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tc.expectedStatus, recorder.Code)
+
+			var report Report
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			require.Equal(t, tc.expectedReport, report)
+		})
+	}
+}
+
+func TestLivenessHandler(t *testing.T) {
+	checker := NewChecker(0)
+	checker.AddCheck("postgres", failingCheck)
+	checker.Drain()
+
+	recorder := httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	require.Equal(t, Report{Status: StatusOK}, report)
+}
+
+func TestWatchGrpc(t *testing.T) {
+	checker := NewChecker(0)
+	server := health.NewServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		checker.WatchGrpc(ctx, server, time.Millisecond)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		res, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err == nil && res.GetStatus() == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+
+	checker.AddCheck("postgres", failingCheck)
+
+	require.Eventually(t, func() bool {
+		res, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err == nil && res.GetStatus() == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+
+	res, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
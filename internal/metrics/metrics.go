@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "users"
+
+const (
+	ProtocolGrpc = "grpc"
+	ProtocolHTTP = "http"
+
+	CacheResultHit   = "hit"
+	CacheResultMiss  = "miss"
+	CacheResultError = "error"
+
+	TaskResultEnqueued     = "enqueued"
+	TaskResultDeduplicated = "deduplicated"
+	TaskResultSucceeded    = "succeeded"
+	TaskResultFailed       = "failed"
+	TaskResultError        = "error"
+)
+
+var (
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "Latency of gRPC and HTTP gateway requests by method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"protocol", "method", "status"})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Cache lookups by cached entity and result.",
+	}, []string{"cache", "result"})
+
+	RateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected for exceeding their rate limit.",
+	}, []string{"protocol", "rate_limit"})
+
+	TasksEnqueued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_enqueued_total",
+		Help:      "Background tasks handed to the queue by type and result.",
+	}, []string{"type", "result"})
+
+	TasksProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_processed_total",
+		Help:      "Background tasks processed by type and result.",
+	}, []string{"type", "result"})
+
+	OutboxMessagesFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_messages_failed_total",
+		Help:      "Outbox messages given up on after exhausting their publish attempts by sink and topic.",
+	}, []string{"sink", "topic"})
+
+	AccessTokenRemoteFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "access_token_remote_fallbacks_total",
+		Help:      "Access tokens verified by the auth service while local verification is enabled by reason.",
+	}, []string{"reason"})
+
+	TaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Time spent processing background tasks by type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type"})
+)
+
+// Handler serves the metrics of the default registry, which also carries the Go runtime and
+// process collectors.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
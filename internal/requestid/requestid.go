@@ -0,0 +1,48 @@
+package requestid
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const logField = "request_id"
+
+// ids sent by callers are logged and carried along with tasks, so they are restricted to
+// characters that cannot break log lines or task ids
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+type contextKey struct{}
+
+// New generates a request id.
+func New() string {
+	return uuid.NewString()
+}
+
+// FromHeader returns the request id sent by the caller, or a generated one when it is missing or
+// malformed.
+func FromHeader(value string) string {
+	if validRequestID.MatchString(value) {
+		return value
+	}
+
+	return New()
+}
+
+// NewContext returns a context carrying the request id along with a logger that tags every line
+// with it. Handlers log through log.Ctx(ctx).
+func NewContext(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, contextKey{}, requestID)
+
+	logger := log.Ctx(ctx).With().Str(logField, requestID).Logger()
+
+	return logger.WithContext(ctx)
+}
+
+// FromContext returns the request id carried by ctx, or an empty string when there is none.
+func FromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(contextKey{}).(string)
+	return requestID
+}
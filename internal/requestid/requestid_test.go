@@ -0,0 +1,64 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromHeader(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedValue bool
+	}{
+		{
+			name:          "valid request id",
+			value:         "0b7e5f2a-3b8e-4b5e-9d39-6a9a3c1d2e4f",
+			expectedValue: true,
+		},
+		{
+			name:  "missing request id",
+			value: "",
+		},
+		{
+			name:  "request id with unsafe characters",
+			value: "some-id\n{\"level\":\"error\"}",
+		},
+		{
+			name:  "request id that is too long",
+			value: strings.Repeat("a", 65),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestID := FromHeader(tc.value)
+			require.NotEmpty(t, requestID)
+
+			if tc.expectedValue {
+				require.Equal(t, tc.value, requestID)
+			} else {
+				require.NotEqual(t, tc.value, requestID)
+			}
+		})
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	require.Empty(t, FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	ctx := NewContext(logger.WithContext(context.Background()), "some-request-id")
+	require.Equal(t, "some-request-id", FromContext(ctx))
+
+	log.Ctx(ctx).Info().Msg("some message")
+	require.Contains(t, buf.String(), `"request_id":"some-request-id"`)
+}
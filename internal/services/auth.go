@@ -2,7 +2,12 @@ package services
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/kyamalabs/proto/proto/auth/pb"
@@ -10,28 +15,70 @@ import (
 	"github.com/kyamalabs/auth/pkg/middleware"
 
 	"github.com/kyamalabs/users/internal/constants"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/rs/zerolog/log"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type AuthGrpcService interface {
-	VerifyAccessToken(*pb.VerifyAccessTokenRequest, string) (*pb.VerifyAccessTokenResponse, error)
+	VerifyAccessToken(context.Context, *pb.VerifyAccessTokenRequest, string) (*pb.VerifyAccessTokenResponse, error)
 }
 
+// AuthServiceGrpcClient calls the auth service within the deadline of the caller. Calls failing
+// with Unavailable are retried with exponential backoff, and once the auth service keeps failing
+// a circuit breaker fails calls fast with Unavailable until it has had time to recover.
 type AuthServiceGrpcClient struct {
 	conn                   *grpc.ClientConn
 	client                 pb.AuthClient
 	serviceAuthPrivateKeys []string
+	timeout                time.Duration
+	maxRetries             int
+	retryBackoff           time.Duration
+	breaker                *gobreaker.CircuitBreaker
+}
+
+// defaultAuthServiceTimeout bounds each attempt when no timeout is configured, so a hung auth
+// service cannot hold requests without a deadline of their own forever.
+const defaultAuthServiceTimeout = 10 * time.Second
+
+// callerContextError marks calls that failed because the context of the caller ended, which says
+// nothing about the health of the auth service.
+type callerContextError struct {
+	err error
+}
+
+func (e callerContextError) Error() string {
+	return e.err.Error()
+}
+
+func (e callerContextError) Unwrap() error {
+	return e.err
 }
 
 type requestMetadata struct {
 	accessToken string
 }
 
-func NewAuthServiceGrpcClient(serverAddress string, serviceAuthPrivateKeys []string) (AuthGrpcService, error) {
-	conn, err := grpc.Dial(serverAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewAuthServiceGrpcClient(config util.Config) (AuthGrpcService, error) {
+	transportCredentials, err := authServiceTransportCredentials(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not load auth service TLS credentials: %w", err)
+	}
+
+	conn, err := grpc.Dial(
+		config.AuthServiceGRPCServerAddress,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("could not create client connection to auth gRPC service: %w", err)
 	}
@@ -41,10 +88,106 @@ func NewAuthServiceGrpcClient(serverAddress string, serviceAuthPrivateKeys []str
 	return &AuthServiceGrpcClient{
 		conn:                   conn,
 		client:                 client,
-		serviceAuthPrivateKeys: serviceAuthPrivateKeys,
+		serviceAuthPrivateKeys: config.ServiceAuthPrivateKeys,
+		timeout:                config.AuthServiceTimeout,
+		maxRetries:             config.AuthServiceMaxRetries,
+		retryBackoff:           config.AuthServiceRetryBackoff,
+		breaker:                newAuthServiceBreaker(config.AuthServiceBreakerFailures, config.AuthServiceBreakerTimeout),
 	}, nil
 }
 
+// authServiceTransportCredentials connects over TLS when config.AuthServiceTLS is set, verifying
+// the auth service against config.AuthServiceTLSCAFile, or the system roots when it is not set,
+// and presenting a client certificate when one is configured.
+func authServiceTransportCredentials(config util.Config) (credentials.TransportCredentials, error) {
+	if !config.AuthServiceTLS {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.AuthServiceTLSServerName,
+	}
+
+	if config.AuthServiceTLSCAFile != "" {
+		caCert, err := os.ReadFile(config.AuthServiceTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA certificate: %w", err)
+		}
+
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("could not parse CA certificate")
+		}
+
+		tlsConfig.RootCAs = certPool
+	}
+
+	if config.AuthServiceTLSCertFile != "" || config.AuthServiceTLSKeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.AuthServiceTLSCertFile, config.AuthServiceTLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// newAuthServiceBreaker opens after consecutive calls fail because the auth service could not be
+// reached and lets a call through to probe it once timeout has passed. It is disabled when
+// failures is not positive.
+func newAuthServiceBreaker(failures int, timeout time.Duration) *gobreaker.CircuitBreaker {
+	if failures <= 0 {
+		return nil
+	}
+
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:    "auth",
+		Timeout: timeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= uint32(failures)
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			log.Warn().Str("breaker", name).Str("from", from.String()).Str("to", to.String()).Msg("auth service circuit breaker changed state")
+		},
+		// callers being refused by the auth service does not make it unhealthy
+		IsSuccessful: func(err error) bool {
+			return !isAuthServiceFailure(err)
+		},
+	})
+}
+
+// isAuthServiceFailure reports whether the call failed because of the auth service. Deadlines
+// count only when the timeout of an attempt fired while the caller was still waiting.
+func isAuthServiceFailure(err error) bool {
+	var callerErr callerContextError
+	if errors.As(err, &callerErr) {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+
+	return false
+}
+
+// Ping reports an error when the connection to the auth service is failing. An idle connection
+// is asked to reconnect and is not treated as failing.
+func (c *AuthServiceGrpcClient) Ping(_ context.Context) error {
+	switch state := c.conn.GetState(); state {
+	case connectivity.Idle:
+		c.conn.Connect()
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return fmt.Errorf("auth service connection is %s", strings.ToLower(state.String()))
+	}
+
+	return nil
+}
+
 func (c *AuthServiceGrpcClient) Close() error {
 	return c.conn.Close()
 }
@@ -70,14 +213,77 @@ func (c *AuthServiceGrpcClient) withMetadata(ctx context.Context, reqMetadata *r
 	return metadata.NewOutgoingContext(ctx, md), nil
 }
 
-func (c *AuthServiceGrpcClient) VerifyAccessToken(payload *pb.VerifyAccessTokenRequest, accessToken string) (*pb.VerifyAccessTokenResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
+func (c *AuthServiceGrpcClient) VerifyAccessToken(ctx context.Context, payload *pb.VerifyAccessTokenRequest, accessToken string) (*pb.VerifyAccessTokenResponse, error) {
 	ctx, err := c.withMetadata(ctx, &requestMetadata{accessToken: accessToken})
 	if err != nil {
 		return nil, fmt.Errorf("could not add metadata to verify access token request: %w", err)
 	}
 
-	return c.client.VerifyAccessToken(ctx, payload)
+	var response *pb.VerifyAccessTokenResponse
+	err = c.invoke(ctx, func(ctx context.Context) (err error) {
+		response, err = c.client.VerifyAccessToken(ctx, payload)
+		return err
+	})
+
+	return response, err
+}
+
+// invoke makes a call through the circuit breaker, retrying it while the auth service is
+// unavailable. Each attempt is bounded by timeout on top of the deadline of ctx.
+func (c *AuthServiceGrpcClient) invoke(ctx context.Context, call func(ctx context.Context) error) error {
+	if c.breaker == nil {
+		return c.callWithRetries(ctx, call)
+	}
+
+	_, err := c.breaker.Execute(func() (interface{}, error) {
+		err := c.callWithRetries(ctx, call)
+		if err != nil && ctx.Err() != nil {
+			return nil, callerContextError{err: err}
+		}
+
+		return nil, err
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return status.Errorf(codes.Unavailable, "auth service is unavailable: %s", err)
+	}
+
+	var callerErr callerContextError
+	if errors.As(err, &callerErr) {
+		return callerErr.err
+	}
+
+	return err
+}
+
+func (c *AuthServiceGrpcClient) callWithRetries(ctx context.Context, call func(ctx context.Context) error) error {
+	backoff := c.retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := c.callWithTimeout(ctx, call)
+		if status.Code(err) != codes.Unavailable || attempt >= c.maxRetries {
+			return err
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Int("attempt", attempt+1).Msg("auth service is unavailable, retrying")
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+func (c *AuthServiceGrpcClient) callWithTimeout(ctx context.Context, call func(ctx context.Context) error) error {
+	timeout := c.timeout
+	if timeout <= 0 {
+		timeout = defaultAuthServiceTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return call(ctx)
 }
@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/kyamalabs/proto/proto/auth/pb"
 
 	"github.com/kyamalabs/users/internal/constants"
+	"github.com/kyamalabs/users/internal/util"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func startMockGrpcServer(t *testing.T) string {
@@ -33,6 +37,8 @@ func TestNewAuthServiceGrpcClient(t *testing.T) {
 	testCases := []struct {
 		name                 string
 		getMockServerAddress func(t *testing.T) string
+		tls                  bool
+		tlsCAFile            string
 		checkResponse        func(t *testing.T, client AuthGrpcService, err error)
 	}{
 		{
@@ -44,6 +50,25 @@ func TestNewAuthServiceGrpcClient(t *testing.T) {
 				require.IsType(t, &AuthServiceGrpcClient{}, client)
 			},
 		},
+		{
+			name:                 "successfully creates auth service gRPC client with TLS",
+			getMockServerAddress: startMockGrpcServer,
+			tls:                  true,
+			checkResponse: func(t *testing.T, client AuthGrpcService, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, client)
+			},
+		},
+		{
+			name:                 "missing CA certificate",
+			getMockServerAddress: startMockGrpcServer,
+			tls:                  true,
+			tlsCAFile:            "testdata/missing.pem",
+			checkResponse: func(t *testing.T, client AuthGrpcService, err error) {
+				require.ErrorContains(t, err, "could not read CA certificate")
+				require.Nil(t, client)
+			},
+		},
 		{
 			name: "connection error",
 			getMockServerAddress: func(t *testing.T) string {
@@ -58,9 +83,14 @@ func TestNewAuthServiceGrpcClient(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			privateKeys := []string{"privateKey1", "privateKey2"}
+			config := util.Config{
+				AuthServiceGRPCServerAddress: tc.getMockServerAddress(t),
+				ServiceAuthPrivateKeys:       []string{"privateKey1", "privateKey2"},
+				AuthServiceTLS:               tc.tls,
+				AuthServiceTLSCAFile:         tc.tlsCAFile,
+			}
 
-			client, err := NewAuthServiceGrpcClient(tc.getMockServerAddress(t), privateKeys)
+			client, err := NewAuthServiceGrpcClient(config)
 			tc.checkResponse(t, client, err)
 
 			if client != nil {
@@ -144,9 +174,12 @@ func TestAuthServiceGrpcClient_withMetadata(t *testing.T) {
 				getServiceAuthenticationPayload = initialGetServiceAuthenticationPayload
 			}()
 
-			privateKeys := []string{"privateKey1", "privateKey2"}
+			config := util.Config{
+				AuthServiceGRPCServerAddress: startMockGrpcServer(t),
+				ServiceAuthPrivateKeys:       []string{"privateKey1", "privateKey2"},
+			}
 
-			client, err := NewAuthServiceGrpcClient(startMockGrpcServer(t), privateKeys)
+			client, err := NewAuthServiceGrpcClient(config)
 			require.NoError(t, err)
 			require.NotEmpty(t, client)
 
@@ -162,7 +195,9 @@ func TestAuthServiceGrpcClient_withMetadata(t *testing.T) {
 }
 
 type mockAuthClientHandler struct {
-	IsVerifyAccessTokenCalled bool
+	// verifyAccessTokenErrors are returned by consecutive calls, which succeed once they run out.
+	verifyAccessTokenErrors []error
+	verifyAccessTokenCalls  int
 }
 
 func (h *mockAuthClientHandler) GetChallenge(_ context.Context, _ *pb.GetChallengeRequest, _ ...grpc.CallOption) (*pb.GetChallengeResponse, error) {
@@ -174,8 +209,15 @@ func (h *mockAuthClientHandler) AuthenticateAccount(_ context.Context, _ *pb.Aut
 }
 
 func (h *mockAuthClientHandler) VerifyAccessToken(_ context.Context, _ *pb.VerifyAccessTokenRequest, _ ...grpc.CallOption) (*pb.VerifyAccessTokenResponse, error) {
-	h.IsVerifyAccessTokenCalled = true
-	return nil, nil
+	h.verifyAccessTokenCalls++
+
+	if len(h.verifyAccessTokenErrors) > 0 {
+		err := h.verifyAccessTokenErrors[0]
+		h.verifyAccessTokenErrors = h.verifyAccessTokenErrors[1:]
+		return nil, err
+	}
+
+	return &pb.VerifyAccessTokenResponse{}, nil
 }
 
 func (h *mockAuthClientHandler) RefreshAccessToken(_ context.Context, _ *pb.RefreshAccessTokenRequest, _ ...grpc.CallOption) (*pb.RefreshAccessTokenResponse, error) {
@@ -187,27 +229,126 @@ func (h *mockAuthClientHandler) RevokeRefreshTokens(_ context.Context, _ *pb.Rev
 }
 
 func TestAuthServiceGrpcClient_VerifyAccessToken(t *testing.T) {
-	dummyClient := &mockAuthClientHandler{}
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	deadlineExceeded := status.Error(codes.DeadlineExceeded, "context deadline exceeded")
 
-	mockAuthServiceGrpcClient := &AuthServiceGrpcClient{
-		client:                 dummyClient,
-		serviceAuthPrivateKeys: []string{"privateKey1", "privateKey2"},
+	testCases := []struct {
+		name            string
+		errors          []error
+		breakerFailures int
+		callerCancelled bool
+		previousCalls   int
+		expectedCalls   int
+		expectedCode    codes.Code
+	}{
+		{
+			name:          "verifies access token",
+			expectedCalls: 1,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "retries while unavailable",
+			errors:        []error{unavailable, unavailable},
+			expectedCalls: 3,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "gives up after max retries",
+			errors:        []error{unavailable, unavailable, unavailable},
+			expectedCalls: 3,
+			expectedCode:  codes.Unavailable,
+		},
+		{
+			name:          "does not retry rejected access tokens",
+			errors:        []error{status.Error(codes.Unauthenticated, "invalid token")},
+			expectedCalls: 1,
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:            "fails fast once the breaker is open",
+			errors:          []error{unavailable, unavailable, unavailable},
+			breakerFailures: 1,
+			previousCalls:   1,
+			expectedCalls:   3,
+			expectedCode:    codes.Unavailable,
+		},
+		{
+			name:            "rejected access tokens do not open the breaker",
+			errors:          []error{status.Error(codes.Unauthenticated, "invalid token")},
+			breakerFailures: 1,
+			previousCalls:   1,
+			expectedCalls:   2,
+			expectedCode:    codes.OK,
+		},
+		{
+			name:            "attempts timing out open the breaker",
+			errors:          []error{deadlineExceeded, deadlineExceeded},
+			breakerFailures: 1,
+			previousCalls:   1,
+			expectedCalls:   1,
+			expectedCode:    codes.Unavailable,
+		},
+		{
+			name:            "callers giving up do not open the breaker",
+			errors:          []error{deadlineExceeded},
+			breakerFailures: 1,
+			callerCancelled: true,
+			previousCalls:   1,
+			expectedCalls:   2,
+			expectedCode:    codes.OK,
+		},
 	}
 
-	initialGetServiceAuthenticationPayload := getServiceAuthenticationPayload
-	getServiceAuthenticationPayload = func(serviceAuthPrivateKeys []string) (string, error) {
-		return "some authentication payload", nil
-	}
-	defer func() {
-		getServiceAuthenticationPayload = initialGetServiceAuthenticationPayload
-	}()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dummyClient := &mockAuthClientHandler{verifyAccessTokenErrors: tc.errors}
+
+			mockAuthServiceGrpcClient := &AuthServiceGrpcClient{
+				client:                 dummyClient,
+				serviceAuthPrivateKeys: []string{"privateKey1", "privateKey2"},
+				timeout:                time.Second,
+				maxRetries:             2,
+				retryBackoff:           time.Millisecond,
+				breaker:                newAuthServiceBreaker(tc.breakerFailures, time.Minute),
+			}
 
-	payload := &pb.VerifyAccessTokenRequest{
-		WalletAddress: "some wallet address",
+			initialGetServiceAuthenticationPayload := getServiceAuthenticationPayload
+			getServiceAuthenticationPayload = func(serviceAuthPrivateKeys []string) (string, error) {
+				return "some authentication payload", nil
+			}
+			defer func() {
+				getServiceAuthenticationPayload = initialGetServiceAuthenticationPayload
+			}()
+
+			payload := &pb.VerifyAccessTokenRequest{
+				WalletAddress: "some wallet address",
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.callerCancelled {
+				cancel()
+			}
+
+			var err error
+			for i := 0; i <= tc.previousCalls; i++ {
+				_, err = mockAuthServiceGrpcClient.VerifyAccessToken(ctx, payload, "some access token")
+			}
+
+			require.Equal(t, tc.expectedCode, status.Code(err))
+			require.Equal(t, tc.expectedCalls, dummyClient.verifyAccessTokenCalls)
+		})
 	}
+}
 
-	_, err := mockAuthServiceGrpcClient.VerifyAccessToken(payload, "some access token")
-	require.NoError(t, err)
+func TestAuthServiceGrpcClient_callWithTimeout(t *testing.T) {
+	client := &AuthServiceGrpcClient{}
 
-	require.True(t, dummyClient.IsVerifyAccessTokenCalled)
+	err := client.callWithTimeout(context.Background(), func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(defaultAuthServiceTimeout), deadline, time.Second)
+		return nil
+	})
+	require.NoError(t, err)
 }
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/internal/cache"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	accessTokenCacheKeyPrefix = "access-token"
+	accessTokenCacheLabel     = "access_token"
+)
+
+// CachedAuthService caches the payloads of access tokens the auth service has verified until the
+// tokens expire, so only the first request made with a token waits on the auth service. Entries are
+// keyed by a hash of the token and the wallet address it was verified for, so tokens are never
+// stored in the cache.
+type CachedAuthService struct {
+	authService   AuthGrpcService
+	cache         cache.Cache
+	maxExpiration time.Duration
+	now           func() time.Time
+}
+
+// NewCachedAuthService caches the verifications of authService for at most maxExpiration, which
+// bounds how long a verification survives a revocation that is never reported through
+// RevokeAccessTokens.
+func NewCachedAuthService(authService AuthGrpcService, c cache.Cache, maxExpiration time.Duration) *CachedAuthService {
+	return &CachedAuthService{
+		authService:   authService,
+		cache:         c,
+		maxExpiration: maxExpiration,
+		now:           time.Now,
+	}
+}
+
+func accessTokenCacheKey(walletAddress string, accessToken string) string {
+	hash := sha256.Sum256([]byte(walletAddress + ":" + accessToken))
+	return fmt.Sprintf("%s:%s", accessTokenCacheKeyPrefix, hex.EncodeToString(hash[:]))
+}
+
+// VerifyAccessToken returns the cached payload of the access token when it has already been
+// verified for the wallet address and not revoked since. Otherwise the token is verified by the
+// auth service and cached if it is. Failing to reach the cache does not fail the verification.
+func (s *CachedAuthService) VerifyAccessToken(ctx context.Context, req *pb.VerifyAccessTokenRequest, accessToken string) (*pb.VerifyAccessTokenResponse, error) {
+	key := accessTokenCacheKey(req.GetWalletAddress(), accessToken)
+
+	payload, err := s.getCachedPayload(ctx, key, req.GetWalletAddress())
+	if err == nil {
+		return &pb.VerifyAccessTokenResponse{Payload: payload}, nil
+	}
+	if err != cache.Nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not read access token verification from cache")
+	}
+
+	response, err := s.authService.VerifyAccessToken(ctx, req, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cachePayload(ctx, key, response.GetPayload())
+
+	return response, nil
+}
+
+// getCachedPayload returns Nil when the access token has not been verified or has been revoked
+// since it was issued.
+func (s *CachedAuthService) getCachedPayload(ctx context.Context, key string, walletAddress string) (*pb.AccessTokenPayload, error) {
+	values, err := s.cache.MGet(ctx, key, accessTokenRevocationKey(walletAddress))
+	if err != nil {
+		metrics.CacheRequests.WithLabelValues(accessTokenCacheLabel, metrics.CacheResultError).Inc()
+		return nil, err
+	}
+
+	if values[0] == nil {
+		metrics.CacheRequests.WithLabelValues(accessTokenCacheLabel, metrics.CacheResultMiss).Inc()
+		return nil, cache.Nil
+	}
+
+	payload, err := cache.ProtoCodec[*pb.AccessTokenPayload]{}.Decode(values[0])
+	if err != nil {
+		metrics.CacheRequests.WithLabelValues(accessTokenCacheLabel, metrics.CacheResultError).Inc()
+		return nil, err
+	}
+
+	revoked, err := isAccessTokenRevoked(values[1], payload.GetIssuedAt().AsTime())
+	if err != nil {
+		metrics.CacheRequests.WithLabelValues(accessTokenCacheLabel, metrics.CacheResultError).Inc()
+		return nil, err
+	}
+	if revoked {
+		metrics.CacheRequests.WithLabelValues(accessTokenCacheLabel, metrics.CacheResultMiss).Inc()
+		return nil, cache.Nil
+	}
+
+	// the cache may hold the entry for a moment longer than the token is valid
+	if !payload.GetExpiresAt().AsTime().After(s.now()) {
+		metrics.CacheRequests.WithLabelValues(accessTokenCacheLabel, metrics.CacheResultMiss).Inc()
+		return nil, cache.Nil
+	}
+
+	metrics.CacheRequests.WithLabelValues(accessTokenCacheLabel, metrics.CacheResultHit).Inc()
+
+	return payload, nil
+}
+
+func (s *CachedAuthService) cachePayload(ctx context.Context, key string, payload *pb.AccessTokenPayload) {
+	if payload == nil || payload.GetExpiresAt() == nil {
+		return
+	}
+
+	expiration := payload.GetExpiresAt().AsTime().Sub(s.now())
+	if expiration > s.maxExpiration {
+		expiration = s.maxExpiration
+	}
+	if expiration <= 0 {
+		return
+	}
+
+	encodedPayload, err := cache.ProtoCodec[*pb.AccessTokenPayload]{}.Encode(payload)
+	if err == nil {
+		err = s.cache.Set(ctx, key, encodedPayload, expiration)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not store access token verification in cache")
+	}
+}
+
+// RevokeAccessTokens stops the cached verifications of every access token issued to the wallet
+// address so far from being used. Tokens issued afterwards are cached as usual. Revocations are
+// left to the wrapped verifier when it can revoke tokens itself, since it stores them for as long
+// as the tokens it verifies are valid.
+func (s *CachedAuthService) RevokeAccessTokens(ctx context.Context, walletAddress string) error {
+	if revoker, ok := s.authService.(AccessTokenRevoker); ok {
+		return revoker.RevokeAccessTokens(ctx, walletAddress)
+	}
+
+	// no cached verification outlives maxExpiration, so neither does the revocation
+	return storeAccessTokenRevocation(ctx, s.cache, walletAddress, s.now(), s.maxExpiration)
+}
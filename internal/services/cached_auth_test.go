@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/internal/cache"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newAccessTokenPayload(walletAddress string, issuedAt time.Time, expiresAt time.Time) *pb.AccessTokenPayload {
+	return &pb.AccessTokenPayload{
+		Id:            "some-token-id",
+		WalletAddress: walletAddress,
+		Role:          pb.AccessTokenPayload_GAMER,
+		IssuedAt:      timestamppb.New(issuedAt),
+		ExpiresAt:     timestamppb.New(expiresAt),
+	}
+}
+
+func TestCachedAuthService_VerifyAccessToken(t *testing.T) {
+	walletAddress := "0x0000000000000000000000000000000000000001"
+	checksummedWalletAddress := "0xc0ffee254729296a45a3885639AC7E10F9d54979"
+	accessToken := "some-access-token"
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		payload       *pb.AccessTokenPayload
+		verifyErr     error
+		prepare       func(t *testing.T, service *CachedAuthService)
+		request       *pb.VerifyAccessTokenRequest
+		accessToken   string
+		expectedCalls int
+		checkResponse func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error)
+	}{
+		{
+			name:          "serves repeated verifications from cache",
+			payload:       newAccessTokenPayload(walletAddress, now.Add(-time.Minute), now.Add(time.Hour)),
+			request:       &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress},
+			accessToken:   accessToken,
+			expectedCalls: 1,
+		},
+		{
+			name:    "verifies the token again for another wallet address",
+			payload: newAccessTokenPayload(walletAddress, now.Add(-time.Minute), now.Add(time.Hour)),
+			prepare: func(t *testing.T, service *CachedAuthService) {
+				_, err := service.VerifyAccessToken(context.Background(), &pb.VerifyAccessTokenRequest{WalletAddress: "0x0000000000000000000000000000000000000002"}, accessToken)
+				require.NoError(t, err)
+			},
+			request:       &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress},
+			accessToken:   accessToken,
+			expectedCalls: 2,
+		},
+		{
+			name:          "does not cache expired tokens",
+			payload:       newAccessTokenPayload(walletAddress, now.Add(-time.Hour), now.Add(-time.Minute)),
+			request:       &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress},
+			accessToken:   accessToken,
+			expectedCalls: 2,
+		},
+		{
+			name:          "does not cache failed verifications",
+			verifyErr:     errors.New("some error"),
+			request:       &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress},
+			accessToken:   accessToken,
+			expectedCalls: 2,
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, response)
+			},
+		},
+		{
+			name:    "verifies revoked tokens again",
+			payload: newAccessTokenPayload(walletAddress, now.Add(-time.Minute), now.Add(time.Hour)),
+			prepare: func(t *testing.T, service *CachedAuthService) {
+				_, err := service.VerifyAccessToken(context.Background(), &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress}, accessToken)
+				require.NoError(t, err)
+
+				err = service.RevokeAccessTokens(context.Background(), walletAddress)
+				require.NoError(t, err)
+			},
+			request:       &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress},
+			accessToken:   accessToken,
+			expectedCalls: 3,
+		},
+		{
+			name:    "verifies tokens revoked under another case of the wallet address again",
+			payload: newAccessTokenPayload(checksummedWalletAddress, now.Add(-time.Minute), now.Add(time.Hour)),
+			prepare: func(t *testing.T, service *CachedAuthService) {
+				_, err := service.VerifyAccessToken(context.Background(), &pb.VerifyAccessTokenRequest{WalletAddress: checksummedWalletAddress}, accessToken)
+				require.NoError(t, err)
+
+				err = service.RevokeAccessTokens(context.Background(), strings.ToLower(checksummedWalletAddress))
+				require.NoError(t, err)
+			},
+			request:       &pb.VerifyAccessTokenRequest{WalletAddress: checksummedWalletAddress},
+			accessToken:   accessToken,
+			expectedCalls: 3,
+		},
+		{
+			name:    "serves tokens issued after a revocation from cache",
+			payload: newAccessTokenPayload(walletAddress, now.Add(time.Second), now.Add(time.Hour)),
+			prepare: func(t *testing.T, service *CachedAuthService) {
+				err := service.RevokeAccessTokens(context.Background(), walletAddress)
+				require.NoError(t, err)
+			},
+			request:       &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress},
+			accessToken:   accessToken,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			authService.EXPECT().
+				VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(tc.expectedCalls).
+				DoAndReturn(func(_ context.Context, req *pb.VerifyAccessTokenRequest, _ string) (*pb.VerifyAccessTokenResponse, error) {
+					if tc.verifyErr != nil {
+						return nil, tc.verifyErr
+					}
+					payload := proto.Clone(tc.payload).(*pb.AccessTokenPayload)
+					payload.WalletAddress = req.GetWalletAddress()
+					return &pb.VerifyAccessTokenResponse{Payload: payload}, nil
+				})
+
+			service := NewCachedAuthService(authService, cache.NewMemoryCache(0), 5*time.Minute)
+
+			if tc.prepare != nil {
+				tc.prepare(t, service)
+			}
+
+			var response *pb.VerifyAccessTokenResponse
+			var err error
+			for i := 0; i < 2; i++ {
+				response, err = service.VerifyAccessToken(context.Background(), tc.request, tc.accessToken)
+			}
+
+			if tc.checkResponse != nil {
+				tc.checkResponse(t, response, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.request.GetWalletAddress(), response.GetPayload().GetWalletAddress())
+			require.Equal(t, tc.payload.GetId(), response.GetPayload().GetId())
+			require.Equal(t, tc.payload.GetExpiresAt().AsTime(), response.GetPayload().GetExpiresAt().AsTime())
+		})
+	}
+}
+
+func TestCachedAuthService_cachePayload(t *testing.T) {
+	now := time.Now()
+	maxExpiration := 5 * time.Minute
+
+	testCases := []struct {
+		name               string
+		payload            *pb.AccessTokenPayload
+		expectedExpiration time.Duration
+	}{
+		{
+			name:               "expires with the token",
+			payload:            newAccessTokenPayload("some-wallet", now, now.Add(time.Minute)),
+			expectedExpiration: time.Minute,
+		},
+		{
+			name:               "expires after max expiration",
+			payload:            newAccessTokenPayload("some-wallet", now, now.Add(time.Hour)),
+			expectedExpiration: maxExpiration,
+		},
+		{
+			name:    "without expiry",
+			payload: &pb.AccessTokenPayload{WalletAddress: "some-wallet"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := cache.NewMemoryCache(0)
+			service := NewCachedAuthService(nil, c, maxExpiration)
+			service.now = func() time.Time { return now }
+
+			service.cachePayload(context.Background(), "some-key", tc.payload)
+
+			ttl, err := c.TTL(context.Background(), "some-key")
+			if tc.expectedExpiration == 0 {
+				require.ErrorIs(t, err, cache.Nil)
+				return
+			}
+
+			require.NoError(t, err)
+			require.InDelta(t, tc.expectedExpiration, ttl, float64(time.Second))
+		})
+	}
+}
+
+func TestCachedAuthService_RevokeAccessTokens(t *testing.T) {
+	walletAddress := "0x0000000000000000000000000000000000000001"
+	maxExpiration := 5 * time.Minute
+
+	t.Run("stores the revocation for the cached verifications", func(t *testing.T) {
+		c := cache.NewMemoryCache(0)
+		service := NewCachedAuthService(nil, c, maxExpiration)
+
+		require.NoError(t, service.RevokeAccessTokens(context.Background(), walletAddress))
+
+		ttl, err := c.TTL(context.Background(), accessTokenRevocationKey(walletAddress))
+		require.NoError(t, err)
+		require.LessOrEqual(t, ttl, maxExpiration)
+	})
+
+	t.Run("leaves the revocation to the wrapped verifier", func(t *testing.T) {
+		c := cache.NewMemoryCache(0)
+		// locally verified tokens are valid for longer than verifications are cached
+		localAuthService := &LocalAuthService{cache: c, revocationTTL: time.Hour, now: time.Now}
+		service := NewCachedAuthService(localAuthService, c, maxExpiration)
+
+		require.NoError(t, service.RevokeAccessTokens(context.Background(), walletAddress))
+
+		ttl, err := c.TTL(context.Background(), accessTokenRevocationKey(walletAddress))
+		require.NoError(t, err)
+		require.Greater(t, ttl, maxExpiration)
+	})
+}
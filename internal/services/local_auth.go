@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/internal/cache"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/o1egl/paseto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	AccessTokenVerificationRemote = "remote"
+	AccessTokenVerificationLocal  = "local"
+)
+
+const (
+	accessTokenAccess = "Access Token"
+	accessTokenGamer  = "gamer"
+	accessTokenAdmin  = "admin"
+)
+
+// Reasons access tokens are verified by the auth service while local verification is enabled.
+const (
+	remoteFallbackUnsupportedToken = "unsupported_token"
+	remoteFallbackUnknownKey       = "unknown_key"
+	remoteFallbackAdmin            = "admin"
+	remoteFallbackRevocation       = "revocation_unchecked"
+)
+
+// defaultAccessTokenRevocationTTL keeps revocations for longer than the auth service issues access
+// tokens for.
+const defaultAccessTokenRevocationTTL = 24 * time.Hour
+
+// accessTokenClaims is the payload the auth service signs into access tokens.
+type accessTokenClaims struct {
+	ID            string    `json:"id"`
+	WalletAddress string    `json:"wallet_address"`
+	Role          string    `json:"role"`
+	TokenAccess   string    `json:"token_access"`
+	IssuedAt      time.Time `json:"issued_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// accessTokenFooter identifies the key an access token is signed with.
+type accessTokenFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// LocalAuthService verifies access tokens signed as v2.public PASETO tokens against the public
+// keys of the auth service, so users stay authorized while the auth service is unreachable.
+// Tokens it cannot verify locally, such as tokens signed with an unknown key, are verified by the
+// auth service. So are admin tokens, which must be checked for revocation by the auth service
+// before they are used. Every other token is checked against the revocations made through
+// RevokeAccessTokens, and is verified by the auth service when they cannot be read. Every fallback
+// is counted by reason, which shows when the auth service mints tokens that cannot be verified
+// locally, such as v2.local tokens.
+type LocalAuthService struct {
+	authService   AuthGrpcService
+	keys          *publicKeySet
+	cache         cache.Cache
+	revocationTTL time.Duration
+	now           func() time.Time
+}
+
+// NewLocalAuthService verifies access tokens with the configured public keys, formatted as
+// "<kid>=<base64 encoded PKIX public key>", and the keys published at publicKeysURL if it is set.
+// The published keys are fetched right away so the first verifications do not wait on them.
+// Revocations are stored in c for revocationTTL, which must be at least the lifetime of access
+// tokens.
+func NewLocalAuthService(authService AuthGrpcService, c cache.Cache, revocationTTL time.Duration, publicKeys []string, publicKeysURL string, refreshInterval time.Duration) (*LocalAuthService, error) {
+	keys, err := newPublicKeySet(publicKeys, publicKeysURL, refreshInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys.configured) == 0 && keys.fetch == nil {
+		return nil, errors.New("local access token verification requires public keys or a public keys url")
+	}
+
+	keys.prefetch(context.Background())
+
+	if revocationTTL <= 0 {
+		revocationTTL = defaultAccessTokenRevocationTTL
+	}
+
+	return &LocalAuthService{
+		authService:   authService,
+		keys:          keys,
+		cache:         c,
+		revocationTTL: revocationTTL,
+		now:           time.Now,
+	}, nil
+}
+
+func (s *LocalAuthService) VerifyAccessToken(ctx context.Context, req *pb.VerifyAccessTokenRequest, accessToken string) (*pb.VerifyAccessTokenResponse, error) {
+	key, fallbackReason := s.signingKey(ctx, accessToken)
+	if key == nil {
+		return s.verifyRemotely(ctx, req, accessToken, fallbackReason)
+	}
+
+	var claims accessTokenClaims
+	err := paseto.NewV2().Verify(accessToken, key, &claims, nil)
+	if err != nil {
+		return nil, fmt.Errorf("access token is invalid: %w", err)
+	}
+
+	payload, err := s.validateClaims(&claims, req.GetWalletAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.GetRole() == pb.AccessTokenPayload_ADMIN {
+		return s.verifyRemotely(ctx, req, accessToken, remoteFallbackAdmin)
+	}
+
+	revoked, err := s.isRevoked(ctx, payload.GetWalletAddress(), claims.IssuedAt)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not check access token revocation")
+		return s.verifyRemotely(ctx, req, accessToken, remoteFallbackRevocation)
+	}
+	if revoked {
+		return nil, errors.New("access token has been revoked")
+	}
+
+	return &pb.VerifyAccessTokenResponse{Payload: payload}, nil
+}
+
+// isRevoked reports whether RevokeAccessTokens has revoked an access token of the wallet address
+// issued at issuedAt.
+func (s *LocalAuthService) isRevoked(ctx context.Context, walletAddress string, issuedAt time.Time) (bool, error) {
+	revocation, err := s.cache.Get(ctx, accessTokenRevocationKey(walletAddress))
+	if err == cache.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return isAccessTokenRevoked(revocation, issuedAt)
+}
+
+// RevokeAccessTokens stops every access token issued to the wallet address so far from being
+// verified locally. Tokens issued afterwards are verified as usual.
+func (s *LocalAuthService) RevokeAccessTokens(ctx context.Context, walletAddress string) error {
+	return storeAccessTokenRevocation(ctx, s.cache, walletAddress, s.now(), s.revocationTTL)
+}
+
+func (s *LocalAuthService) verifyRemotely(ctx context.Context, req *pb.VerifyAccessTokenRequest, accessToken string, reason string) (*pb.VerifyAccessTokenResponse, error) {
+	metrics.AccessTokenRemoteFallbacks.WithLabelValues(reason).Inc()
+	log.Ctx(ctx).Debug().Str("reason", reason).Msg("verifying access token with the auth service")
+
+	return s.authService.VerifyAccessToken(ctx, req, accessToken)
+}
+
+// signingKey returns the public key the access token is signed with, if it is a v2.public token
+// signed with a known key, or the reason it has to be verified by the auth service otherwise.
+func (s *LocalAuthService) signingKey(ctx context.Context, accessToken string) (ed25519.PublicKey, string) {
+	version, purpose, err := paseto.GetTokenInfo(accessToken)
+	if err != nil || version != paseto.Version2 || purpose != paseto.PUBLIC {
+		return nil, remoteFallbackUnsupportedToken
+	}
+
+	var footer accessTokenFooter
+	err = paseto.ParseFooter(accessToken, &footer)
+	if err != nil || footer.KeyID == "" {
+		return nil, remoteFallbackUnknownKey
+	}
+
+	key, ok := s.keys.get(ctx, footer.KeyID)
+	if !ok {
+		return nil, remoteFallbackUnknownKey
+	}
+
+	return key, ""
+}
+
+func (s *LocalAuthService) validateClaims(claims *accessTokenClaims, walletAddress string) (*pb.AccessTokenPayload, error) {
+	if claims.TokenAccess != accessTokenAccess {
+		return nil, errors.New("token is not an access token")
+	}
+
+	if !s.now().Before(claims.ExpiresAt) {
+		return nil, errors.New("access token is expired")
+	}
+
+	if !strings.EqualFold(claims.WalletAddress, walletAddress) {
+		return nil, errors.New("access token was not issued for the wallet address")
+	}
+
+	var role pb.AccessTokenPayload_Role
+	switch claims.Role {
+	case accessTokenGamer:
+		role = pb.AccessTokenPayload_GAMER
+	case accessTokenAdmin:
+		role = pb.AccessTokenPayload_ADMIN
+	default:
+		return nil, fmt.Errorf("unsupported access token role: %s", claims.Role)
+	}
+
+	return &pb.AccessTokenPayload{
+		Id:            claims.ID,
+		WalletAddress: claims.WalletAddress,
+		Role:          role,
+		IssuedAt:      timestamppb.New(claims.IssuedAt),
+		ExpiresAt:     timestamppb.New(claims.ExpiresAt),
+	}, nil
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyamalabs/proto/proto/auth/pb"
+	"github.com/kyamalabs/users/internal/cache"
+	mockcache "github.com/kyamalabs/users/internal/cache/mock"
+	"github.com/kyamalabs/users/internal/metrics"
+	mockservices "github.com/kyamalabs/users/internal/services/mock"
+	"github.com/o1egl/paseto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newSigningKey(t *testing.T) (ed25519.PrivateKey, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	return privateKey, base64.StdEncoding.EncodeToString(der)
+}
+
+func signAccessToken(t *testing.T, privateKey ed25519.PrivateKey, keyID string, claims accessTokenClaims) string {
+	token, err := paseto.NewV2().Sign(privateKey, claims, accessTokenFooter{KeyID: keyID})
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestLocalAuthService_VerifyAccessToken(t *testing.T) {
+	walletAddress := "0x0000000000000000000000000000000000000001"
+	privateKey, publicKey := newSigningKey(t)
+	otherPrivateKey, _ := newSigningKey(t)
+
+	newClaims := func(role string) accessTokenClaims {
+		return accessTokenClaims{
+			ID:            "some-token-id",
+			WalletAddress: walletAddress,
+			Role:          role,
+			TokenAccess:   accessTokenAccess,
+			IssuedAt:      time.Now().Add(-time.Minute),
+			ExpiresAt:     time.Now().Add(time.Hour),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildToken     func(t *testing.T) string
+		prepare        func(t *testing.T, service *LocalAuthService)
+		cache          func(ctrl *gomock.Controller) cache.Cache
+		remoteCalls    int
+		fallbackReason string
+		checkResponse  func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error)
+	}{
+		{
+			name: "verifies gamer tokens locally",
+			buildToken: func(t *testing.T) string {
+				return signAccessToken(t, privateKey, "key-1", newClaims(accessTokenGamer))
+			},
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, walletAddress, response.GetPayload().GetWalletAddress())
+				require.Equal(t, pb.AccessTokenPayload_GAMER, response.GetPayload().GetRole())
+				require.Equal(t, "some-token-id", response.GetPayload().GetId())
+			},
+		},
+		{
+			name: "verifies admin tokens remotely",
+			buildToken: func(t *testing.T) string {
+				return signAccessToken(t, privateKey, "key-1", newClaims(accessTokenAdmin))
+			},
+			remoteCalls:    1,
+			fallbackReason: remoteFallbackAdmin,
+		},
+		{
+			name: "verifies tokens signed with unknown keys remotely",
+			buildToken: func(t *testing.T) string {
+				return signAccessToken(t, otherPrivateKey, "key-2", newClaims(accessTokenGamer))
+			},
+			remoteCalls:    1,
+			fallbackReason: remoteFallbackUnknownKey,
+		},
+		{
+			name: "verifies tokens without key id remotely",
+			buildToken: func(t *testing.T) string {
+				token, err := paseto.NewV2().Sign(privateKey, newClaims(accessTokenGamer), nil)
+				require.NoError(t, err)
+				return token
+			},
+			remoteCalls:    1,
+			fallbackReason: remoteFallbackUnknownKey,
+		},
+		{
+			name: "verifies local tokens remotely",
+			buildToken: func(t *testing.T) string {
+				token, err := paseto.NewV2().Encrypt([]byte("123456abcdefghijklmnopqrstuvwxyz"), newClaims(accessTokenGamer), nil)
+				require.NoError(t, err)
+				return token
+			},
+			remoteCalls:    1,
+			fallbackReason: remoteFallbackUnsupportedToken,
+		},
+		{
+			name: "revoked token",
+			buildToken: func(t *testing.T) string {
+				return signAccessToken(t, privateKey, "key-1", newClaims(accessTokenGamer))
+			},
+			prepare: func(t *testing.T, service *LocalAuthService) {
+				require.NoError(t, service.RevokeAccessTokens(context.Background(), strings.ToUpper(walletAddress)))
+			},
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.ErrorContains(t, err, "revoked")
+				require.Nil(t, response)
+			},
+		},
+		{
+			name: "token issued after a revocation",
+			buildToken: func(t *testing.T) string {
+				return signAccessToken(t, privateKey, "key-1", newClaims(accessTokenGamer))
+			},
+			prepare: func(t *testing.T, service *LocalAuthService) {
+				service.now = func() time.Time { return time.Now().Add(-time.Hour) }
+				require.NoError(t, service.RevokeAccessTokens(context.Background(), walletAddress))
+				service.now = time.Now
+			},
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, walletAddress, response.GetPayload().GetWalletAddress())
+			},
+		},
+		{
+			name: "verifies tokens remotely when revocations cannot be read",
+			buildToken: func(t *testing.T) string {
+				return signAccessToken(t, privateKey, "key-1", newClaims(accessTokenGamer))
+			},
+			cache: func(ctrl *gomock.Controller) cache.Cache {
+				c := mockcache.NewMockCache(ctrl)
+				c.EXPECT().
+					Get(gomock.Any(), accessTokenRevocationKey(walletAddress)).
+					Times(1).
+					Return(nil, errors.New("some cache error"))
+				return c
+			},
+			remoteCalls:    1,
+			fallbackReason: remoteFallbackRevocation,
+		},
+		{
+			name: "invalid signature",
+			buildToken: func(t *testing.T) string {
+				return signAccessToken(t, otherPrivateKey, "key-1", newClaims(accessTokenGamer))
+			},
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.ErrorContains(t, err, "access token is invalid")
+				require.Nil(t, response)
+			},
+		},
+		{
+			name: "expired token",
+			buildToken: func(t *testing.T) string {
+				claims := newClaims(accessTokenGamer)
+				claims.ExpiresAt = time.Now().Add(-time.Second)
+				return signAccessToken(t, privateKey, "key-1", claims)
+			},
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.ErrorContains(t, err, "expired")
+				require.Nil(t, response)
+			},
+		},
+		{
+			name: "token of another wallet address",
+			buildToken: func(t *testing.T) string {
+				claims := newClaims(accessTokenGamer)
+				claims.WalletAddress = "0x0000000000000000000000000000000000000002"
+				return signAccessToken(t, privateKey, "key-1", claims)
+			},
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.ErrorContains(t, err, "wallet address")
+				require.Nil(t, response)
+			},
+		},
+		{
+			name: "refresh token",
+			buildToken: func(t *testing.T) string {
+				claims := newClaims(accessTokenGamer)
+				claims.TokenAccess = "Refresh Token"
+				return signAccessToken(t, privateKey, "key-1", claims)
+			},
+			checkResponse: func(t *testing.T, response *pb.VerifyAccessTokenResponse, err error) {
+				require.ErrorContains(t, err, "not an access token")
+				require.Nil(t, response)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+			remoteResponse := &pb.VerifyAccessTokenResponse{Payload: &pb.AccessTokenPayload{WalletAddress: walletAddress}}
+			authService.EXPECT().
+				VerifyAccessToken(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(tc.remoteCalls).
+				Return(remoteResponse, nil)
+
+			var c cache.Cache = cache.NewMemoryCache(0)
+			if tc.cache != nil {
+				c = tc.cache(ctrl)
+			}
+
+			service, err := NewLocalAuthService(authService, c, time.Hour, []string{"key-1=" + publicKey}, "", time.Hour)
+			require.NoError(t, err)
+
+			if tc.prepare != nil {
+				tc.prepare(t, service)
+			}
+
+			fallbacks := metrics.AccessTokenRemoteFallbacks.WithLabelValues(tc.fallbackReason)
+			fallbacksBefore := testutil.ToFloat64(fallbacks)
+
+			response, err := service.VerifyAccessToken(context.Background(), &pb.VerifyAccessTokenRequest{WalletAddress: walletAddress}, tc.buildToken(t))
+
+			if tc.fallbackReason != "" {
+				require.Equal(t, fallbacksBefore+1, testutil.ToFloat64(fallbacks))
+			}
+
+			if tc.checkResponse != nil {
+				tc.checkResponse(t, response, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, remoteResponse, response)
+		})
+	}
+}
+
+func TestNewLocalAuthService_RequiresPublicKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthGrpcService(ctrl)
+
+	_, err := NewLocalAuthService(authService, cache.NewMemoryCache(0), time.Hour, nil, "", time.Hour)
+	require.Error(t, err)
+}
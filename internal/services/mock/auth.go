@@ -10,6 +10,7 @@
 package mockservices
 
 import (
+	context "context"
 	reflect "reflect"
 
 	pb "github.com/kyamalabs/proto/proto/auth/pb"
@@ -40,16 +41,16 @@ func (m *MockAuthGrpcService) EXPECT() *MockAuthGrpcServiceMockRecorder {
 }
 
 // VerifyAccessToken mocks base method.
-func (m *MockAuthGrpcService) VerifyAccessToken(arg0 *pb.VerifyAccessTokenRequest, arg1 string) (*pb.VerifyAccessTokenResponse, error) {
+func (m *MockAuthGrpcService) VerifyAccessToken(arg0 context.Context, arg1 *pb.VerifyAccessTokenRequest, arg2 string) (*pb.VerifyAccessTokenResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyAccessToken", arg0, arg1)
+	ret := m.ctrl.Call(m, "VerifyAccessToken", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*pb.VerifyAccessTokenResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // VerifyAccessToken indicates an expected call of VerifyAccessToken.
-func (mr *MockAuthGrpcServiceMockRecorder) VerifyAccessToken(arg0, arg1 any) *gomock.Call {
+func (mr *MockAuthGrpcServiceMockRecorder) VerifyAccessToken(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAccessToken", reflect.TypeOf((*MockAuthGrpcService)(nil).VerifyAccessToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAccessToken", reflect.TypeOf((*MockAuthGrpcService)(nil).VerifyAccessToken), arg0, arg1, arg2)
 }
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	publicKeysFetchTimeout = 10 * time.Second
+	// publicKeysRefetchDelay keeps tokens signed with unknown keys from fetching the published
+	// keys on every request.
+	publicKeysRefetchDelay = 30 * time.Second
+	publicKeysRefreshKey   = "refresh"
+)
+
+// publishedPublicKeys is the document the auth service publishes its access token public keys in.
+type publishedPublicKeys struct {
+	Keys []struct {
+		KeyID     string `json:"kid"`
+		PublicKey string `json:"public_key"`
+	} `json:"keys"`
+}
+
+// publicKeySet holds the public keys access tokens are signed with by key id. Configured keys are
+// always known, while keys published by the auth service are fetched when they are first needed
+// and refreshed once refreshInterval has passed. A failed refresh keeps the keys fetched last.
+// The keys are fetched without holding mu, so verifications with known keys never wait on the
+// auth service.
+type publicKeySet struct {
+	mu              sync.RWMutex
+	configured      map[string]ed25519.PublicKey
+	keys            map[string]ed25519.PublicKey
+	fetch           func(ctx context.Context) (map[string]ed25519.PublicKey, error)
+	refreshes       singleflight.Group
+	refreshInterval time.Duration
+	fetchedAt       time.Time
+	now             func() time.Time
+}
+
+// newPublicKeySet reads configured keys formatted as "<kid>=<base64 encoded PKIX public key>" and,
+// when url is set, fetches the keys published there as well.
+func newPublicKeySet(configuredKeys []string, url string, refreshInterval time.Duration) (*publicKeySet, error) {
+	configured := make(map[string]ed25519.PublicKey)
+
+	for _, entry := range configuredKeys {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		keyID, encodedKey, found := strings.Cut(entry, "=")
+		if !found || keyID == "" {
+			return nil, fmt.Errorf("invalid access token public key %q: expected <kid>=<public key>", entry)
+		}
+
+		key, err := parsePublicKey(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access token public key %s: %w", keyID, err)
+		}
+
+		configured[keyID] = key
+	}
+
+	keys := &publicKeySet{
+		configured:      configured,
+		keys:            configured,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+
+	if url != "" {
+		client := &http.Client{Timeout: publicKeysFetchTimeout}
+		keys.fetch = func(ctx context.Context) (map[string]ed25519.PublicKey, error) {
+			return fetchPublicKeys(ctx, client, url)
+		}
+	}
+
+	return keys, nil
+}
+
+func parsePublicKey(encodedKey string) (ed25519.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode public key: %w", err)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse public key: %w", err)
+	}
+
+	ed25519Key, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	return ed25519Key, nil
+}
+
+func fetchPublicKeys(ctx context.Context, client *http.Client, url string) (map[string]ed25519.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build public keys request: %w", err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch public keys: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch public keys: unexpected status %d", res.StatusCode)
+	}
+
+	var published publishedPublicKeys
+	err = json.NewDecoder(res.Body).Decode(&published)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode public keys: %w", err)
+	}
+
+	keys := make(map[string]ed25519.PublicKey, len(published.Keys))
+	for _, publishedKey := range published.Keys {
+		key, err := parsePublicKey(publishedKey.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %w", publishedKey.KeyID, err)
+		}
+
+		keys[publishedKey.KeyID] = key
+	}
+
+	return keys, nil
+}
+
+// get returns the public key with the key id, refreshing the published keys first when they are
+// stale or do not include it.
+func (k *publicKeySet) get(ctx context.Context, keyID string) (ed25519.PublicKey, bool) {
+	key, ok, stale := k.lookup(keyID)
+	if (ok && !stale) || k.fetch == nil {
+		return key, ok
+	}
+
+	// unknown keys also wait on a refresh in flight, which may publish them, while refresh
+	// itself only fetches once the keys are due
+	k.refresh(ctx, keyID)
+
+	key, ok, _ = k.lookup(keyID)
+	return key, ok
+}
+
+// prefetch fetches the published keys ahead of the first verification.
+func (k *publicKeySet) prefetch(ctx context.Context) {
+	if k.fetch != nil {
+		k.refresh(ctx, "")
+	}
+}
+
+// lookup returns the key with the key id and whether the published keys should be refreshed
+// before relying on the result.
+func (k *publicKeySet) lookup(keyID string) (ed25519.PublicKey, bool, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[keyID]
+
+	return key, ok, k.shouldRefresh(ok)
+}
+
+// shouldRefresh must be called with mu held.
+func (k *publicKeySet) shouldRefresh(known bool) bool {
+	if k.fetch == nil {
+		return false
+	}
+
+	sinceFetch := k.now().Sub(k.fetchedAt)
+	if !known {
+		return sinceFetch >= publicKeysRefetchDelay
+	}
+
+	return sinceFetch >= k.refreshInterval
+}
+
+// refresh fetches the published keys once for all callers that find them stale at the same time.
+// The fetch is detached from the cancellation of the caller that started it and bounded by its own
+// timeout instead, while each caller stops waiting as soon as its own context is done.
+func (k *publicKeySet) refresh(ctx context.Context, keyID string) {
+	fetchCtx := context.WithoutCancel(ctx)
+
+	results := k.refreshes.DoChan(publicKeysRefreshKey, func() (interface{}, error) {
+		k.mu.Lock()
+		// a refresh that finished in the meantime may have fetched the key already
+		_, known := k.keys[keyID]
+		if !k.shouldRefresh(known) {
+			k.mu.Unlock()
+			return nil, nil
+		}
+		// claimed before fetching, so failing fetches are not retried on every request either
+		k.fetchedAt = k.now()
+		k.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(fetchCtx, publicKeysFetchTimeout)
+		defer cancel()
+
+		fetched, err := k.fetch(ctx)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("could not refresh access token public keys")
+			return nil, nil
+		}
+
+		keys := make(map[string]ed25519.PublicKey, len(k.configured)+len(fetched))
+		for keyID, key := range fetched {
+			keys[keyID] = key
+		}
+		for keyID, key := range k.configured {
+			keys[keyID] = key
+		}
+
+		k.mu.Lock()
+		k.keys = keys
+		k.mu.Unlock()
+
+		return nil, nil
+	})
+
+	select {
+	case <-ctx.Done():
+	case <-results:
+	}
+}
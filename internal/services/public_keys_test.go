@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPublicKeySet(t *testing.T) {
+	_, publicKey := newSigningKey(t)
+
+	testCases := []struct {
+		name          string
+		keys          []string
+		expectedKeys  []string
+		expectedError string
+	}{
+		{
+			name:         "configured keys",
+			keys:         []string{"key-1=" + publicKey, " key-2=" + publicKey + " ", ""},
+			expectedKeys: []string{"key-1", "key-2"},
+		},
+		{
+			name:          "missing key id",
+			keys:          []string{"=" + publicKey},
+			expectedError: "expected <kid>=<public key>",
+		},
+		{
+			name:          "invalid public key",
+			keys:          []string{"key-1=bm90IGEga2V5"},
+			expectedError: "invalid access token public key key-1",
+		},
+		{
+			name:          "unsupported public key type",
+			keys:          []string{"key-1=MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEkcpsUaeko+BLe9sutR3FRCIQPBwlRU9UN2/69Q4RLb8upVzVcK+22dEJtvVzhu3bl1hgPk3HLIYPrtuLqKOQbw=="},
+			expectedError: "unsupported public key type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := newPublicKeySet(tc.keys, "", time.Hour)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			for _, keyID := range tc.expectedKeys {
+				_, ok := keys.get(context.Background(), keyID)
+				require.True(t, ok)
+			}
+		})
+	}
+}
+
+func TestPublicKeySet_get(t *testing.T) {
+	_, configuredKey := newSigningKey(t)
+	_, publishedKey := newSigningKey(t)
+
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		fetches++
+
+		published := map[string]interface{}{
+			"keys": []map[string]string{{"kid": "published", "public_key": publishedKey}},
+		}
+		err := json.NewEncoder(res).Encode(published)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	keys, err := newPublicKeySet([]string{"configured=" + configuredKey}, server.URL, time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	_, ok := keys.get(ctx, "published")
+	require.True(t, ok)
+	require.Equal(t, 1, fetches)
+
+	_, ok = keys.get(ctx, "configured")
+	require.True(t, ok)
+	require.Equal(t, 1, fetches)
+
+	// unknown keys are only fetched again once the refetch delay has passed
+	_, ok = keys.get(ctx, "unknown")
+	require.False(t, ok)
+	require.Equal(t, 1, fetches)
+
+	now = now.Add(publicKeysRefetchDelay)
+	_, ok = keys.get(ctx, "unknown")
+	require.False(t, ok)
+	require.Equal(t, 2, fetches)
+
+	// known keys are refreshed once the refresh interval has passed
+	now = now.Add(time.Hour)
+	_, ok = keys.get(ctx, "published")
+	require.True(t, ok)
+	require.Equal(t, 3, fetches)
+
+	// failed refreshes keep the keys fetched last
+	server.Config.Handler = http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		fetches++
+		res.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	now = now.Add(time.Hour)
+	_, ok = keys.get(ctx, "published")
+	require.True(t, ok)
+	require.Equal(t, 4, fetches)
+}
+
+func TestPublicKeySet_concurrentRefresh(t *testing.T) {
+	_, publishedKey := newSigningKey(t)
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		<-release
+
+		published := map[string]interface{}{
+			"keys": []map[string]string{{"kid": "published", "public_key": publishedKey}},
+		}
+		_ = json.NewEncoder(res).Encode(published)
+	}))
+	defer server.Close()
+
+	_, configuredKey := newSigningKey(t)
+	keys, err := newPublicKeySet([]string{"configured=" + configuredKey}, server.URL, time.Hour)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, ok := keys.get(context.Background(), "published")
+			require.True(t, ok)
+		}()
+	}
+
+	// known keys are served while the published keys are being fetched
+	require.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, 10*time.Millisecond)
+	_, ok := keys.get(context.Background(), "configured")
+	require.True(t, ok)
+
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), fetches.Load())
+}
+
+func TestPublicKeySet_prefetch(t *testing.T) {
+	_, publishedKey := newSigningKey(t)
+
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		fetches++
+
+		published := map[string]interface{}{
+			"keys": []map[string]string{{"kid": "published", "public_key": publishedKey}},
+		}
+		_ = json.NewEncoder(res).Encode(published)
+	}))
+	defer server.Close()
+
+	keys, err := newPublicKeySet(nil, server.URL, time.Hour)
+	require.NoError(t, err)
+
+	keys.prefetch(context.Background())
+	require.Equal(t, 1, fetches)
+
+	_, ok := keys.get(context.Background(), "published")
+	require.True(t, ok)
+	require.Equal(t, 1, fetches)
+}
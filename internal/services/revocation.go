@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyamalabs/users/internal/cache"
+)
+
+const accessTokenRevocationKeyPrefix = "access-token-revocation"
+
+// AccessTokenRevoker is the hook through which revocations reach the verifiers that accept access
+// tokens without asking the auth service, so revoked tokens stop being accepted right away.
+type AccessTokenRevoker interface {
+	RevokeAccessTokens(ctx context.Context, walletAddress string) error
+}
+
+// accessTokenRevocationKey ignores the case of the wallet address, which callers may or may not
+// checksum.
+func accessTokenRevocationKey(walletAddress string) string {
+	return fmt.Sprintf("%s:%s", accessTokenRevocationKeyPrefix, strings.ToLower(walletAddress))
+}
+
+// storeAccessTokenRevocation revokes the access tokens issued to the wallet address until
+// revokedAt. The revocation is kept for expiration, which must outlive the tokens it revokes.
+func storeAccessTokenRevocation(ctx context.Context, c cache.Cache, walletAddress string, revokedAt time.Time, expiration time.Duration) error {
+	err := c.Set(ctx, accessTokenRevocationKey(walletAddress), revokedAt, expiration)
+	if err != nil {
+		return fmt.Errorf("could not store access token revocation in cache: %w", err)
+	}
+
+	return nil
+}
+
+// isAccessTokenRevoked reports whether a token issued at issuedAt is revoked by the stored
+// revocation, which is nil when the tokens of the wallet address were never revoked.
+func isAccessTokenRevoked(revocation interface{}, issuedAt time.Time) (bool, error) {
+	if revocation == nil {
+		return false, nil
+	}
+
+	revokedAt, err := cache.JSONCodec[time.Time]{}.Decode(revocation)
+	if err != nil {
+		return false, err
+	}
+
+	return !issuedAt.After(revokedAt), nil
+}
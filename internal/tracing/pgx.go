@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxTracer starts a client span for every query run on a pgx connection. It is installed on the
+// pool config through pgx.ConnConfig.Tracer.
+type PgxTracer struct{}
+
+func NewPgxTracer() pgx.QueryTracer {
+	return &PgxTracer{}
+}
+
+func (tracer *PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := sqlOperation(data.SQL)
+
+	ctx, _ = Tracer().Start(ctx, "db "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBStatement(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (tracer *PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	EndSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+// sqlOperation returns the sqlc query name of a statement, which sqlc puts in a leading
+// "-- name: GetProfile :one" comment, or the SQL keyword the statement starts with.
+func sqlOperation(sql string) string {
+	sql = strings.TrimSpace(sql)
+
+	if name, ok := strings.CutPrefix(sql, "-- name: "); ok {
+		if fields := strings.Fields(name); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+
+	return "QUERY"
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+func TestPgxTracer(t *testing.T) {
+	recorder := recordSpans(t)
+	ctx, span := startSpan(t)
+
+	tracer := NewPgxTracer()
+
+	queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{
+		SQL: "-- name: GetProfile :one\nSELECT * FROM profiles WHERE wallet_address = $1",
+	})
+	tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{Err: errors.New("some db error")})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "db GetProfile", spans[0].Name())
+	require.Equal(t, span.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Contains(t, spans[0].Attributes(), semconv.DBSystemPostgreSQL)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestSQLOperation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sql       string
+		operation string
+	}{
+		{
+			name:      "sqlc query",
+			sql:       "-- name: CreateProfile :one\nINSERT INTO profiles (wallet_address) VALUES ($1)",
+			operation: "CreateProfile",
+		},
+		{
+			name:      "plain statement",
+			sql:       "  select 1",
+			operation: "SELECT",
+		},
+		{
+			name:      "empty statement",
+			sql:       "",
+			operation: "QUERY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.operation, sqlOperation(tc.sql))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const traceParentKey = "traceparent"
+
+// InjectContext returns the trace context of ctx in a form that can be stored and resumed later
+// with ExtractContext, such as in the headers of an outbox message.
+func InjectContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	return carrier
+}
+
+// ExtractContext resumes the trace context stored by InjectContext.
+func ExtractContext(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(traceContext))
+}
+
+// TraceParent returns the W3C traceparent of ctx, or an empty string when ctx is not part of a
+// sampled trace. It is used where there is no room for a carrier, such as asynq task ids.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	return carrier.Get(traceParentKey)
+}
+
+// ContextWithTraceParent resumes the trace of a traceparent returned by TraceParent. Malformed
+// traceparents leave ctx as it is.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+
+	carrier := propagation.MapCarrier{traceParentKey: traceParent}
+
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceParent(t *testing.T) {
+	recordSpans(t)
+
+	require.Empty(t, TraceParent(context.Background()))
+
+	ctx, span := startSpan(t)
+
+	traceParent := TraceParent(ctx)
+	require.True(t, strings.HasPrefix(traceParent, "00-"+span.SpanContext().TraceID().String()))
+
+	resumed := trace.SpanContextFromContext(ContextWithTraceParent(context.Background(), traceParent))
+	require.True(t, resumed.IsRemote())
+	require.Equal(t, span.SpanContext().TraceID(), resumed.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), resumed.SpanID())
+}
+
+func TestContextWithTraceParent_InvalidTraceParent(t *testing.T) {
+	testCases := []struct {
+		name        string
+		traceParent string
+	}{
+		{
+			name:        "empty trace parent",
+			traceParent: "",
+		},
+		{
+			name:        "malformed trace parent",
+			traceParent: "not-a-trace-parent",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := ContextWithTraceParent(context.Background(), tc.traceParent)
+			require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
+
+func TestInjectContext(t *testing.T) {
+	recordSpans(t)
+
+	require.Nil(t, InjectContext(context.Background()))
+
+	ctx, span := startSpan(t)
+
+	traceContext := InjectContext(ctx)
+	require.Contains(t, traceContext, traceParentKey)
+
+	resumed := trace.SpanContextFromContext(ExtractContext(context.Background(), traceContext))
+	require.Equal(t, span.SpanContext().TraceID(), resumed.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), resumed.SpanID())
+
+	require.Equal(t, context.Background(), ExtractContext(context.Background(), nil))
+}
@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"context"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook starts a client span for every command and pipeline sent by a go-redis client.
+// Command arguments are left out of the spans since they carry cached values.
+type RedisHook struct{}
+
+func NewRedisHook() redis.Hook {
+	return &RedisHook{}
+}
+
+func (hook *RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (hook *RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := Tracer().Start(ctx, "redis "+cmd.Name(),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemRedis,
+				semconv.DBOperation(cmd.Name()),
+			),
+		)
+
+		err := next(ctx, cmd)
+		EndSpan(span, ignoreNil(err))
+
+		return err
+	}
+}
+
+func (hook *RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := Tracer().Start(ctx, "redis pipeline",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemRedis,
+				semconv.DBOperation("pipeline"),
+			),
+		)
+
+		err := next(ctx, cmds)
+		EndSpan(span, ignoreNil(err))
+
+		return err
+	}
+}
+
+// ignoreNil keeps cache misses from being recorded as failed spans.
+func ignoreNil(err error) error {
+	if err == redis.Nil {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestRedisHook_ProcessHook(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		statusCode codes.Code
+	}{
+		{
+			name:       "success",
+			statusCode: codes.Unset,
+		},
+		{
+			name:       "cache miss",
+			err:        redis.Nil,
+			statusCode: codes.Unset,
+		},
+		{
+			name:       "failure",
+			err:        errors.New("some redis error"),
+			statusCode: codes.Error,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := recordSpans(t)
+			ctx, _ := startSpan(t)
+
+			process := NewRedisHook().ProcessHook(func(_ context.Context, _ redis.Cmder) error {
+				return tc.err
+			})
+
+			err := process(ctx, redis.NewStringCmd(ctx, "get", "some-key"))
+			require.Equal(t, tc.err, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			require.Equal(t, "redis get", spans[0].Name())
+			require.Equal(t, tc.statusCode, spans[0].Status().Code)
+		})
+	}
+}
+
+func TestRedisHook_ProcessPipelineHook(t *testing.T) {
+	recorder := recordSpans(t)
+	ctx, _ := startSpan(t)
+
+	processPipeline := NewRedisHook().ProcessPipelineHook(func(_ context.Context, _ []redis.Cmder) error {
+		return nil
+	})
+
+	err := processPipeline(ctx, []redis.Cmder{redis.NewStringCmd(ctx, "get", "some-key")})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "redis pipeline", spans[0].Name())
+}
@@ -1,21 +1,68 @@
 package util
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Environment                  string   `mapstructure:"ENVIRONMENT"`
-	DBDriver                     string   `mapstructure:"DB_DRIVER"`
-	DBSource                     string   `mapstructure:"DB_SOURCE"`
-	RedisConnURL                 string   `mapstructure:"REDIS_CONN_URL"`
-	DBMigrationURL               string   `mapstructure:"DB_MIGRATION_URL"`
-	EthereumRPCURL               string   `mapstructure:"ETHEREUM_RPC_URL"`
-	HTTPServerAddress            string   `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress            string   `mapstructure:"GRPC_SERVER_ADDRESS"`
-	AuthServiceGRPCServerAddress string   `mapstructure:"AUTH_SERVICE_GRPC_SERVER_ADDRESS"`
-	ServiceAuthPublicKeys        []string `mapstructure:"SERVICE_AUTH_PUBLIC_KEYS"`
-	ServiceAuthPrivateKeys       []string `mapstructure:"SERVICE_AUTH_PRIVATE_KEYS"`
+	Environment                    string        `mapstructure:"ENVIRONMENT"`
+	DBDriver                       string        `mapstructure:"DB_DRIVER"`
+	DBSource                       string        `mapstructure:"DB_SOURCE"`
+	RedisConnURL                   string        `mapstructure:"REDIS_CONN_URL"`
+	CacheBackend                   string        `mapstructure:"CACHE_BACKEND"`
+	CacheLocalCapacity             int           `mapstructure:"CACHE_LOCAL_CAPACITY"`
+	CacheLocalTTL                  time.Duration `mapstructure:"CACHE_LOCAL_TTL"`
+	DBMigrationURL                 string        `mapstructure:"DB_MIGRATION_URL"`
+	EthereumRPCURL                 string        `mapstructure:"ETHEREUM_RPC_URL"`
+	EthereumRPCFallbackURLs        []string      `mapstructure:"ETHEREUM_RPC_FALLBACK_URLS"`
+	EthereumRPCHealthCheckInterval time.Duration `mapstructure:"ETHEREUM_RPC_HEALTH_CHECK_INTERVAL"`
+	EnsRefreshInterval             time.Duration `mapstructure:"ENS_REFRESH_INTERVAL"`
+	EnsRefreshBatchSize            int32         `mapstructure:"ENS_REFRESH_BATCH_SIZE"`
+	EnsRefreshExpiryThreshold      time.Duration `mapstructure:"ENS_REFRESH_EXPIRY_THRESHOLD"`
+	EnsRefreshActiveWindow         time.Duration `mapstructure:"ENS_REFRESH_ACTIVE_WINDOW"`
+	OutboxRelayInterval            time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"`
+	OutboxRelayBatchSize           int32         `mapstructure:"OUTBOX_RELAY_BATCH_SIZE"`
+	OutboxRetention                time.Duration `mapstructure:"OUTBOX_RETENTION"`
+	OutboxMaxAttempts              int32         `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+	EventStream                    string        `mapstructure:"EVENT_STREAM"`
+	EventStreamMaxLen              int64         `mapstructure:"EVENT_STREAM_MAX_LEN"`
+	ProfileWatchersMax             int           `mapstructure:"PROFILE_WATCHERS_MAX"`
+	WebhookTimeout                 time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookMaxRetry                int           `mapstructure:"WEBHOOK_MAX_RETRY"`
+	HTTPServerAddress              string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress              string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	MetricsServerAddress           string        `mapstructure:"METRICS_SERVER_ADDRESS"`
+	ShutdownTimeout                time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	ShutdownDrainDelay             time.Duration `mapstructure:"SHUTDOWN_DRAIN_DELAY"`
+	HealthCheckTimeout             time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	HealthCheckInterval            time.Duration `mapstructure:"HEALTH_CHECK_INTERVAL"`
+	TracingExporter                string        `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint            string        `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure            bool          `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingSampleRatio             float64       `mapstructure:"TRACING_SAMPLE_RATIO"`
+	RateLimits                     string        `mapstructure:"RATE_LIMITS"`
+	TrustedProxies                 []string      `mapstructure:"TRUSTED_PROXIES"`
+	AuthServiceGRPCServerAddress   string        `mapstructure:"AUTH_SERVICE_GRPC_SERVER_ADDRESS"`
+	AuthServiceTimeout             time.Duration `mapstructure:"AUTH_SERVICE_TIMEOUT"`
+	AuthServiceMaxRetries          int           `mapstructure:"AUTH_SERVICE_MAX_RETRIES"`
+	AuthServiceRetryBackoff        time.Duration `mapstructure:"AUTH_SERVICE_RETRY_BACKOFF"`
+	AuthServiceBreakerFailures     int           `mapstructure:"AUTH_SERVICE_BREAKER_FAILURES"`
+	AuthServiceBreakerTimeout      time.Duration `mapstructure:"AUTH_SERVICE_BREAKER_TIMEOUT"`
+	AuthServiceTLS                 bool          `mapstructure:"AUTH_SERVICE_TLS"`
+	AuthServiceTLSCAFile           string        `mapstructure:"AUTH_SERVICE_TLS_CA_FILE"`
+	AuthServiceTLSServerName       string        `mapstructure:"AUTH_SERVICE_TLS_SERVER_NAME"`
+	AuthServiceTLSCertFile         string        `mapstructure:"AUTH_SERVICE_TLS_CERT_FILE"`
+	AuthServiceTLSKeyFile          string        `mapstructure:"AUTH_SERVICE_TLS_KEY_FILE"`
+	AccessTokenCacheMaxTTL         time.Duration `mapstructure:"ACCESS_TOKEN_CACHE_MAX_TTL"`
+	AccessTokenRevocationTTL       time.Duration `mapstructure:"ACCESS_TOKEN_REVOCATION_TTL"`
+	AccessTokenVerification        string        `mapstructure:"ACCESS_TOKEN_VERIFICATION"`
+	AccessTokenPublicKeys          []string      `mapstructure:"ACCESS_TOKEN_PUBLIC_KEYS"`
+	AccessTokenPublicKeysURL       string        `mapstructure:"ACCESS_TOKEN_PUBLIC_KEYS_URL"`
+	AccessTokenPublicKeysRefresh   time.Duration `mapstructure:"ACCESS_TOKEN_PUBLIC_KEYS_REFRESH_INTERVAL"`
+	ServiceAuthPublicKeys          []string      `mapstructure:"SERVICE_AUTH_PUBLIC_KEYS"`
+	ServiceAuthPrivateKeys         []string      `mapstructure:"SERVICE_AUTH_PRIVATE_KEYS"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -3,10 +3,13 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
 )
 
 const (
@@ -14,6 +17,12 @@ const (
 	gamerTagMaxLength          = 20
 	gamerTagRegexPattern       = "^[a-zA-Z0-9_]+$"
 	maxPageSize          int32 = 50
+	maxWatchedProfiles         = 100
+)
+
+const (
+	webhookSecretMinLength = 16
+	webhookURLMaxLength    = 2048
 )
 
 func ValidateWalletAddress(walletAddress string) error {
@@ -58,3 +67,126 @@ func ValidatePageSize(pageSize int32) error {
 
 	return nil
 }
+
+func ValidateWatchedProfilesCount(count int) error {
+	if count == 0 {
+		return errors.New("must not be empty")
+	}
+
+	if count > maxWatchedProfiles {
+		return fmt.Errorf("must not contain more than %d wallet addresses", maxWatchedProfiles)
+	}
+
+	return nil
+}
+
+func ValidateWebhookURL(webhookURL string) error {
+	if len(webhookURL) > webhookURLMaxLength {
+		return fmt.Errorf("must be at most %d characters long", webhookURLMaxLength)
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Host == "" {
+		return errors.New("must be an absolute url")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("must use the http or https scheme")
+	}
+
+	return nil
+}
+
+// ValidateWebhookAddresses checks that none of the addresses a webhook host resolves to is
+// reserved. Resolving the host is left to the caller, so that it happens with the request's
+// context.
+func ValidateWebhookAddresses(addrs []net.IPAddr) error {
+	if len(addrs) == 0 {
+		return errors.New("host could not be resolved")
+	}
+
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return errors.New("must not point to a loopback, private, link-local or otherwise reserved address")
+		}
+	}
+
+	return nil
+}
+
+// specialPurposeNetworks are the networks of the IANA IPv4 and IPv6 special-purpose address
+// registries that the checks of net.IP do not cover, such as shared address space, benchmarking,
+// documentation and translation prefixes.
+var specialPurposeNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"192.31.196.0/24",
+	"192.52.193.0/24",
+	"192.88.99.0/24",
+	"192.175.48.0/24",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"240.0.0.0/4",
+	"64:ff9b::/96",
+	"64:ff9b:1::/48",
+	"100::/64",
+	"2001::/23",
+	"2001:db8::/32",
+	"2002::/16",
+	"3fff::/20",
+	"5f00::/16",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("invalid special purpose network %s: %v", cidr, err))
+		}
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// IsPublicIP reports whether ip may be reached by outgoing requests that users control, such as
+// webhook deliveries.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() {
+		return false
+	}
+
+	for _, network := range specialPurposeNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func ValidateWebhookSecret(secret string) error {
+	if len(secret) < webhookSecretMinLength {
+		return fmt.Errorf("must be at least %d characters long", webhookSecretMinLength)
+	}
+
+	return nil
+}
+
+func ValidateUUID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("not a valid uuid")
+	}
+
+	return nil
+}
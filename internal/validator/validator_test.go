@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -118,3 +119,198 @@ func TestValidatePageSize(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateWatchedProfilesCount(t *testing.T) {
+	testCases := []struct {
+		name            string
+		count           int
+		expectedToError bool
+	}{
+		{
+			name:            "success",
+			count:           100,
+			expectedToError: false,
+		},
+		{
+			name:            "empty",
+			count:           0,
+			expectedToError: true,
+		},
+		{
+			name:            "too many",
+			count:           101,
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWatchedProfilesCount(tc.count)
+			if tc.expectedToError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	testCases := []struct {
+		name            string
+		url             string
+		expectedToError bool
+	}{
+		{
+			name:            "success",
+			url:             "https://93.184.216.34/hooks/users",
+			expectedToError: false,
+		},
+		{
+			name:            "http scheme",
+			url:             "http://93.184.216.34:8080/hooks",
+			expectedToError: false,
+		},
+		{
+			name:            "relative url",
+			url:             "/hooks/users",
+			expectedToError: true,
+		},
+		{
+			name:            "unsupported scheme",
+			url:             "ftp://example.com/hooks",
+			expectedToError: true,
+		},
+		{
+			name:            "empty",
+			url:             "",
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWebhookURL(tc.url)
+			if tc.expectedToError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateWebhookAddresses(t *testing.T) {
+	require.NoError(t, ValidateWebhookAddresses([]net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}))
+
+	// a single reserved address rejects the host
+	require.Error(t, ValidateWebhookAddresses([]net.IPAddr{
+		{IP: net.ParseIP("93.184.216.34")},
+		{IP: net.ParseIP("10.0.0.5")},
+	}))
+
+	require.Error(t, ValidateWebhookAddresses(nil))
+}
+
+func TestIsPublicIP(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ip       string
+		expected bool
+	}{
+		{name: "public ipv4", ip: "93.184.216.34", expected: true},
+		{name: "public ipv6", ip: "2606:2800:220:1:248:1893:25c8:1946", expected: true},
+		{name: "ipv4 mapped public ipv6", ip: "::ffff:93.184.216.34", expected: true},
+		{name: "loopback", ip: "127.0.0.1"},
+		{name: "ipv6 loopback", ip: "::1"},
+		{name: "private", ip: "10.0.0.5"},
+		{name: "ipv4 mapped private ipv6", ip: "::ffff:192.168.1.1"},
+		{name: "unique local ipv6", ip: "fd00::1"},
+		{name: "link-local", ip: "169.254.169.254"},
+		{name: "unspecified", ip: "0.0.0.0"},
+		{name: "this network", ip: "0.1.2.3"},
+		{name: "multicast", ip: "224.0.0.1"},
+		{name: "shared address space", ip: "100.64.0.1"},
+		{name: "ietf protocol assignments", ip: "192.0.0.8"},
+		{name: "documentation", ip: "198.51.100.7"},
+		{name: "benchmarking", ip: "198.18.0.1"},
+		{name: "reserved", ip: "240.0.0.1"},
+		{name: "limited broadcast", ip: "255.255.255.255"},
+		{name: "nat64", ip: "64:ff9b::a00:5"},
+		{name: "6to4", ip: "2002:a00:5::1"},
+		{name: "teredo", ip: "2001:0:4136:e378:8000:63bf:3fff:fdd2"},
+		{name: "ipv6 documentation", ip: "2001:db8::1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ip := net.ParseIP(tc.ip)
+			require.NotNil(t, ip)
+			require.Equal(t, tc.expected, IsPublicIP(ip))
+		})
+	}
+}
+
+func TestValidateWebhookSecret(t *testing.T) {
+	testCases := []struct {
+		name            string
+		secret          string
+		expectedToError bool
+	}{
+		{
+			name:            "success",
+			secret:          "0123456789abcdef",
+			expectedToError: false,
+		},
+		{
+			name:            "too short",
+			secret:          "0123456789abcde",
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWebhookSecret(tc.secret)
+			if tc.expectedToError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateUUID(t *testing.T) {
+	testCases := []struct {
+		name            string
+		id              string
+		expectedToError bool
+	}{
+		{
+			name:            "success",
+			id:              "0c5f4a8e-3b6f-4d3c-9a57-1f2a3b4c5d6e",
+			expectedToError: false,
+		},
+		{
+			name:            "invalid",
+			id:              "not-a-uuid",
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateUUID(tc.id)
+			if tc.expectedToError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+// ErrDeadLetterTaskNotFound is returned when a task does not exist or is not waiting on a retry
+// or archived.
+var ErrDeadLetterTaskNotFound = errors.New("dead letter task not found")
+
+// DeadLetterInspector exposes tasks that failed to process, either archived after exhausting their
+// retries or waiting to be retried, so they can be recovered without a separate asynq dashboard.
+type DeadLetterInspector interface {
+	ListDeadLetterTasks(queue string, taskType string, state asynq.TaskState, page int, pageSize int) ([]*asynq.TaskInfo, int, error)
+	GetDeadLetterTask(queue string, id string) (*asynq.TaskInfo, error)
+	ReplayDeadLetterTask(queue string, id string) error
+	DeleteDeadLetterTask(queue string, id string) error
+	Close() error
+}
+
+type RedisDeadLetterInspector struct {
+	inspector *asynq.Inspector
+}
+
+func NewRedisDeadLetterInspector(redisOpt asynq.RedisConnOpt) DeadLetterInspector {
+	return &RedisDeadLetterInspector{
+		inspector: asynq.NewInspector(redisOpt),
+	}
+}
+
+func (inspector *RedisDeadLetterInspector) Close() error {
+	return inspector.inspector.Close()
+}
+
+// Queues lists the queues tasks are processed from.
+func Queues() []string {
+	return []string{QueueCritical, QueueDefault}
+}
+
+// IsKnownQueue reports whether tasks are processed from the queue.
+func IsKnownQueue(queue string) bool {
+	for _, q := range Queues() {
+		if q == queue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRegisteredTaskType reports whether the task processor handles tasks of the type.
+func IsRegisteredTaskType(taskType string) bool {
+	_, ok := lookupTask(taskType)
+	return ok
+}
+
+// deadLetterScanPageSize is the number of tasks read from asynq at a time while filtering them by
+// type.
+const deadLetterScanPageSize = 500
+
+// ListDeadLetterTasks pages through the tasks in the state in the queue. Tasks are filtered by type
+// when one is given before they are paged, which scans every task in the state, and the total then
+// only counts the tasks of the type. Pages start at 1.
+func (inspector *RedisDeadLetterInspector) ListDeadLetterTasks(queue string, taskType string, state asynq.TaskState, page int, pageSize int) ([]*asynq.TaskInfo, int, error) {
+	if !isDeadLetterState(state) {
+		return nil, 0, fmt.Errorf("unsupported dead letter task state: %s", state)
+	}
+
+	list := inspector.inspector.ListArchivedTasks
+	if state == asynq.TaskStateRetry {
+		list = inspector.inspector.ListRetryTasks
+	}
+
+	if taskType != "" {
+		tasks, total, err := listTasksOfType(queue, list, taskType, page, pageSize)
+		if errors.Is(err, asynq.ErrQueueNotFound) {
+			// a queue only exists once a task has been enqueued on it
+			return nil, 0, nil
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not list %s tasks in queue %s: %w", state, queue, err)
+		}
+
+		return tasks, total, nil
+	}
+
+	queueInfo, err := inspector.inspector.GetQueueInfo(queue)
+	if errors.Is(err, asynq.ErrQueueNotFound) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get queue %s: %w", queue, err)
+	}
+
+	total := queueInfo.Archived
+	if state == asynq.TaskStateRetry {
+		total = queueInfo.Retry
+	}
+
+	tasks, err := list(queue, asynq.Page(page), asynq.PageSize(pageSize))
+	if errors.Is(err, asynq.ErrQueueNotFound) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not list %s tasks in queue %s: %w", state, queue, err)
+	}
+
+	return tasks, total, nil
+}
+
+type listTasksFunc func(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+
+// listTasksOfType reads every task listed in the queue, keeping those of the type that fall on the
+// page and counting all of them.
+func listTasksOfType(queue string, list listTasksFunc, taskType string, page int, pageSize int) ([]*asynq.TaskInfo, int, error) {
+	first := (page - 1) * pageSize
+
+	var tasks []*asynq.TaskInfo
+	total := 0
+
+	for scanPage := 1; ; scanPage++ {
+		scanned, err := list(queue, asynq.Page(scanPage), asynq.PageSize(deadLetterScanPageSize))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, task := range scanned {
+			if task.Type != taskType {
+				continue
+			}
+
+			if total >= first && total < first+pageSize {
+				tasks = append(tasks, task)
+			}
+			total++
+		}
+
+		if len(scanned) < deadLetterScanPageSize {
+			return tasks, total, nil
+		}
+	}
+}
+
+func (inspector *RedisDeadLetterInspector) GetDeadLetterTask(queue string, id string) (*asynq.TaskInfo, error) {
+	task, err := inspector.inspector.GetTaskInfo(queue, id)
+	if errors.Is(err, asynq.ErrQueueNotFound) || errors.Is(err, asynq.ErrTaskNotFound) {
+		return nil, ErrDeadLetterTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get task: %w", err)
+	}
+
+	// tasks that are pending or being processed are not for on-call to replay or delete
+	if !isDeadLetterState(task.State) {
+		return nil, ErrDeadLetterTaskNotFound
+	}
+
+	return task, nil
+}
+
+// ReplayDeadLetterTask moves the task back to its queue to be processed immediately.
+func (inspector *RedisDeadLetterInspector) ReplayDeadLetterTask(queue string, id string) error {
+	if _, err := inspector.GetDeadLetterTask(queue, id); err != nil {
+		return err
+	}
+
+	err := inspector.inspector.RunTask(queue, id)
+	if errors.Is(err, asynq.ErrQueueNotFound) || errors.Is(err, asynq.ErrTaskNotFound) {
+		return ErrDeadLetterTaskNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("could not run task: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDeadLetterTask discards the task for good.
+func (inspector *RedisDeadLetterInspector) DeleteDeadLetterTask(queue string, id string) error {
+	if _, err := inspector.GetDeadLetterTask(queue, id); err != nil {
+		return err
+	}
+
+	err := inspector.inspector.DeleteTask(queue, id)
+	if errors.Is(err, asynq.ErrQueueNotFound) || errors.Is(err, asynq.ErrTaskNotFound) {
+		return ErrDeadLetterTaskNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("could not delete task: %w", err)
+	}
+
+	return nil
+}
+
+func isDeadLetterState(state asynq.TaskState) bool {
+	return state == asynq.TaskStateArchived || state == asynq.TaskStateRetry
+}
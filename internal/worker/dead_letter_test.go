@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsKnownQueue(t *testing.T) {
+	require.True(t, IsKnownQueue(QueueDefault))
+	require.True(t, IsKnownQueue(QueueCritical))
+	require.False(t, IsKnownQueue(""))
+	require.False(t, IsKnownQueue("low"))
+}
+
+func TestIsRegisteredTaskType(t *testing.T) {
+	require.True(t, IsRegisteredTaskType(TaskCacheENSName))
+	require.True(t, IsRegisteredTaskType(TaskRefreshENSNames))
+	require.True(t, IsRegisteredTaskType(TaskDeliverWebhook))
+	require.False(t, IsRegisteredTaskType("task:unknown"))
+}
+
+func TestListTasksOfType(t *testing.T) {
+	// every third task is a webhook delivery, spread over several scan pages
+	var queued []*asynq.TaskInfo
+	var deliveries []*asynq.TaskInfo
+	for i := 0; i < 2*deadLetterScanPageSize+10; i++ {
+		task := &asynq.TaskInfo{ID: fmt.Sprintf("task-%d", i), Type: TaskCacheENSName}
+		if i%3 == 0 {
+			task.Type = TaskDeliverWebhook
+			deliveries = append(deliveries, task)
+		}
+		queued = append(queued, task)
+	}
+
+	// scan pages are read in order, so the fake hands them out one call at a time
+	newList := func() listTasksFunc {
+		calls := 0
+		return func(queue string, _ ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+			require.Equal(t, QueueDefault, queue)
+
+			first := calls * deadLetterScanPageSize
+			calls++
+			if first >= len(queued) {
+				return nil, nil
+			}
+			return queued[first:min(first+deadLetterScanPageSize, len(queued))], nil
+		}
+	}
+
+	pageSize := 50
+	lastPage := (len(deliveries) + pageSize - 1) / pageSize
+
+	testCases := []struct {
+		name     string
+		taskType string
+		page     int
+		expected []*asynq.TaskInfo
+	}{
+		{
+			name:     "first page",
+			taskType: TaskDeliverWebhook,
+			page:     1,
+			expected: deliveries[:pageSize],
+		},
+		{
+			name:     "page across scan pages",
+			taskType: TaskDeliverWebhook,
+			page:     4,
+			expected: deliveries[3*pageSize : 4*pageSize],
+		},
+		{
+			name:     "last page",
+			taskType: TaskDeliverWebhook,
+			page:     lastPage,
+			expected: deliveries[(lastPage-1)*pageSize:],
+		},
+		{
+			name:     "past the last page",
+			taskType: TaskDeliverWebhook,
+			page:     lastPage + 1,
+		},
+		{
+			name:     "type without tasks",
+			taskType: TaskRefreshENSNames,
+			page:     1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks, total, err := listTasksOfType(QueueDefault, newList(), tc.taskType, tc.page, pageSize)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tasks)
+
+			if tc.taskType == TaskDeliverWebhook {
+				require.Equal(t, len(deliveries), total)
+			} else {
+				require.Zero(t, total)
+			}
+		})
+	}
+}
@@ -2,12 +2,16 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/rs/zerolog/log"
 )
 
 type TaskDistributor interface {
-	DistributeTaskCacheEnsName(context.Context, *PayloadCacheEnsName, ...asynq.Option) error
+	Enqueue(ctx context.Context, task *asynq.Task, opts ...asynq.Option) error
 }
 
 type RedisTaskDistributor struct {
@@ -21,3 +25,37 @@ func NewRedisTaskDistributor(redisOpt asynq.RedisConnOpt) TaskDistributor {
 		client: client,
 	}
 }
+
+func (distributor *RedisTaskDistributor) Close() error {
+	return distributor.client.Close()
+}
+
+// Enqueue enqueues a task built by a registered Task. Tasks rejected as duplicates of a task
+// that is already pending are treated as enqueued.
+func (distributor *RedisTaskDistributor) Enqueue(ctx context.Context, task *asynq.Task, opts ...asynq.Option) error {
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if errors.Is(err, asynq.ErrDuplicateTask) || errors.Is(err, asynq.ErrTaskIDConflict) {
+		metrics.TasksEnqueued.WithLabelValues(task.Type(), metrics.TaskResultDeduplicated).Inc()
+		log.Ctx(ctx).Info().
+			Str("type", task.Type()).
+			Bytes("payload", task.Payload()).
+			Bool("deduplicated", true).
+			Msg("skipped enqueueing duplicate task")
+		return nil
+	}
+	if err != nil {
+		metrics.TasksEnqueued.WithLabelValues(task.Type(), metrics.TaskResultError).Inc()
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	metrics.TasksEnqueued.WithLabelValues(task.Type(), metrics.TaskResultEnqueued).Inc()
+
+	log.Ctx(ctx).Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
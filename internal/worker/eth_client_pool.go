@@ -0,0 +1,175 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultEthHealthCheckInterval = 30 * time.Second
+	ethHealthCheckTimeout         = 5 * time.Second
+	ethReconnectBaseBackoff       = time.Second
+	ethReconnectMaxBackoff        = time.Minute
+)
+
+var ErrNoHealthyEthClient = errors.New("no healthy ethereum rpc client available")
+
+type ethRPCEndpoint struct {
+	url       string
+	client    *ethclient.Client
+	failures  int
+	retryAt   time.Time
+	checkedAt time.Time
+}
+
+// EthClientPool keeps long-lived connections to a primary Ethereum RPC endpoint and
+// its fallbacks, handing out the first healthy client in priority order.
+type EthClientPool struct {
+	mu                  sync.Mutex
+	endpoints           []*ethRPCEndpoint
+	healthCheckInterval time.Duration
+	dial                func(ctx context.Context, url string) (*ethclient.Client, error)
+	now                 func() time.Time
+}
+
+func NewEthClientPool(urls []string, healthCheckInterval time.Duration) *EthClientPool {
+	if healthCheckInterval <= 0 {
+		healthCheckInterval = defaultEthHealthCheckInterval
+	}
+
+	var endpoints []*ethRPCEndpoint
+	for _, url := range urls {
+		if url == "" {
+			continue
+		}
+		endpoints = append(endpoints, &ethRPCEndpoint{url: url})
+	}
+
+	return &EthClientPool{
+		endpoints:           endpoints,
+		healthCheckInterval: healthCheckInterval,
+		dial:                ethclient.DialContext,
+		now:                 time.Now,
+	}
+}
+
+// Client returns the client of the first healthy endpoint. The lock is only held to read and
+// swap the clients, so dialing or checking a slow endpoint does not hold up other callers.
+func (pool *EthClientPool) Client(ctx context.Context) (*ethclient.Client, error) {
+	for _, endpoint := range pool.endpoints {
+		now := pool.now()
+
+		pool.mu.Lock()
+		backingOff := now.Before(endpoint.retryAt)
+		current := endpoint.client
+		checked := current != nil && now.Sub(endpoint.checkedAt) < pool.healthCheckInterval
+		pool.mu.Unlock()
+
+		if backingOff {
+			continue
+		}
+		if checked {
+			return current, nil
+		}
+
+		client, err := pool.checkHealth(ctx, endpoint.url, current)
+		if err != nil {
+			failures, retryAt := pool.markUnhealthy(endpoint, current, now)
+			log.Warn().Err(err).
+				Str("rpc_url", endpoint.url).
+				Int("consecutive_failures", failures).
+				Time("retry_at", retryAt).
+				Msg("ethereum rpc endpoint is unhealthy")
+			continue
+		}
+
+		return pool.markHealthy(endpoint, current, client, now), nil
+	}
+
+	return nil, ErrNoHealthyEthClient
+}
+
+// checkHealth checks the current client of an endpoint, dialing a new one when there is none.
+func (pool *EthClientPool) checkHealth(ctx context.Context, url string, current *ethclient.Client) (*ethclient.Client, error) {
+	checkCtx, cancel := context.WithTimeout(ctx, ethHealthCheckTimeout)
+	defer cancel()
+
+	client := current
+	if client == nil {
+		var err error
+		client, err = pool.dial(checkCtx, url)
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to ethereum rpc endpoint: %w", err)
+		}
+	}
+
+	_, err := client.ChainID(checkCtx)
+	if err != nil {
+		if client != current {
+			client.Close()
+		}
+		return nil, fmt.Errorf("ethereum rpc health check failed: %w", err)
+	}
+
+	return client, nil
+}
+
+// markHealthy stores the checked client of an endpoint. When another caller connected the
+// endpoint in the meantime, its client is kept and the one dialed here is closed.
+func (pool *EthClientPool) markHealthy(endpoint *ethRPCEndpoint, current *ethclient.Client, client *ethclient.Client, now time.Time) *ethclient.Client {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if endpoint.client != current && endpoint.client != nil {
+		if client != current {
+			client.Close()
+		}
+		return endpoint.client
+	}
+
+	endpoint.client = client
+	endpoint.failures = 0
+	endpoint.checkedAt = now
+
+	return client
+}
+
+// markUnhealthy backs the endpoint off and closes the client that failed its health check,
+// unless another caller has already replaced it.
+func (pool *EthClientPool) markUnhealthy(endpoint *ethRPCEndpoint, failed *ethclient.Client, now time.Time) (int, time.Time) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if failed != nil && endpoint.client == failed {
+		endpoint.client.Close()
+		endpoint.client = nil
+	}
+
+	backoff := ethReconnectBaseBackoff << endpoint.failures
+	if backoff <= 0 || backoff > ethReconnectMaxBackoff {
+		backoff = ethReconnectMaxBackoff
+	}
+
+	endpoint.failures++
+	endpoint.retryAt = now.Add(backoff)
+
+	return endpoint.failures, endpoint.retryAt
+}
+
+func (pool *EthClientPool) Close() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	for _, endpoint := range pool.endpoints {
+		if endpoint.client != nil {
+			endpoint.client.Close()
+			endpoint.client = nil
+		}
+	}
+}
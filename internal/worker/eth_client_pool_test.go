@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type testEthService struct {
+	healthy bool
+}
+
+func (s *testEthService) ChainId() (*hexutil.Big, error) {
+	if !s.healthy {
+		return nil, errors.New("node unavailable")
+	}
+	return (*hexutil.Big)(hexutil.MustDecodeBig("0xaa36a7")), nil
+}
+
+func newTestEthClient(t *testing.T, service *testEthService) *ethclient.Client {
+	server := rpc.NewServer()
+	err := server.RegisterName("eth", service)
+	require.NoError(t, err)
+
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
+
+func TestEthClientPool_Client(t *testing.T) {
+	primary := &testEthService{healthy: true}
+	fallback := &testEthService{healthy: true}
+
+	testCases := []struct {
+		name          string
+		urls          []string
+		setup         func()
+		checkResponse func(t *testing.T, pool *EthClientPool, client *ethclient.Client, err error, dials map[string]int)
+	}{
+		{
+			name: "reuses the primary client",
+			urls: []string{"primary", "fallback"},
+			setup: func() {
+				primary.healthy = true
+			},
+			checkResponse: func(t *testing.T, pool *EthClientPool, client *ethclient.Client, err error, dials map[string]int) {
+				require.NoError(t, err)
+				require.NotNil(t, client)
+
+				again, err := pool.Client(context.Background())
+				require.NoError(t, err)
+				require.Same(t, client, again)
+				require.Equal(t, 1, dials["primary"])
+				require.Zero(t, dials["fallback"])
+			},
+		},
+		{
+			name: "falls back when the primary is unhealthy",
+			urls: []string{"primary", "fallback"},
+			setup: func() {
+				primary.healthy = false
+			},
+			checkResponse: func(t *testing.T, pool *EthClientPool, client *ethclient.Client, err error, dials map[string]int) {
+				require.NoError(t, err)
+				require.NotNil(t, client)
+				require.Equal(t, 1, dials["fallback"])
+
+				_, err = pool.Client(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, 1, dials["primary"], "primary should be backed off")
+			},
+		},
+		{
+			name: "no healthy clients",
+			urls: []string{"primary"},
+			setup: func() {
+				primary.healthy = false
+			},
+			checkResponse: func(t *testing.T, pool *EthClientPool, client *ethclient.Client, err error, dials map[string]int) {
+				require.ErrorIs(t, err, ErrNoHealthyEthClient)
+				require.Nil(t, client)
+			},
+		},
+		{
+			name:  "ignores empty urls",
+			urls:  []string{"", ""},
+			setup: func() {},
+			checkResponse: func(t *testing.T, pool *EthClientPool, client *ethclient.Client, err error, dials map[string]int) {
+				require.ErrorIs(t, err, ErrNoHealthyEthClient)
+				require.Empty(t, dials)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			dials := make(map[string]int)
+			services := map[string]*testEthService{
+				"primary":  primary,
+				"fallback": fallback,
+			}
+
+			pool := NewEthClientPool(tc.urls, time.Minute)
+			pool.dial = func(_ context.Context, url string) (*ethclient.Client, error) {
+				dials[url]++
+				return newTestEthClient(t, services[url]), nil
+			}
+			defer pool.Close()
+
+			client, err := pool.Client(context.Background())
+			tc.checkResponse(t, pool, client, err, dials)
+		})
+	}
+}
+
+func TestEthClientPool_Backoff(t *testing.T) {
+	service := &testEthService{healthy: false}
+	now := time.Now()
+
+	pool := NewEthClientPool([]string{"primary"}, time.Minute)
+	pool.now = func() time.Time { return now }
+	pool.dial = func(_ context.Context, _ string) (*ethclient.Client, error) {
+		return newTestEthClient(t, service), nil
+	}
+	defer pool.Close()
+
+	_, err := pool.Client(context.Background())
+	require.ErrorIs(t, err, ErrNoHealthyEthClient)
+
+	service.healthy = true
+
+	_, err = pool.Client(context.Background())
+	require.ErrorIs(t, err, ErrNoHealthyEthClient, "endpoint should still be backing off")
+
+	now = now.Add(ethReconnectBaseBackoff)
+
+	client, err := pool.Client(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, client)
+}
+
+func TestEthClientPool_ConcurrentDials(t *testing.T) {
+	service := &testEthService{healthy: true}
+
+	var dials atomic.Int32
+	release := make(chan struct{})
+
+	pool := NewEthClientPool([]string{"primary"}, time.Minute)
+	pool.dial = func(_ context.Context, _ string) (*ethclient.Client, error) {
+		dials.Add(1)
+		<-release
+		return newTestEthClient(t, service), nil
+	}
+	defer pool.Close()
+
+	clients := make(chan *ethclient.Client, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			client, err := pool.Client(context.Background())
+			require.NoError(t, err)
+			clients <- client
+		}()
+	}
+
+	// a slow dial must not hold up the other callers
+	require.Eventually(t, func() bool { return dials.Load() == 2 }, time.Second, time.Millisecond)
+	close(release)
+
+	first, second := <-clients, <-clients
+	require.Same(t, first, second)
+}
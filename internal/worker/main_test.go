@@ -1,11 +1,15 @@
 package worker
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kyamalabs/users/internal/cache"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	"github.com/kyamalabs/users/internal/util"
 	"github.com/rs/zerolog/log"
 )
@@ -13,6 +17,7 @@ import (
 var (
 	testTaskDistributor TaskDistributor
 	testRedisCache      cache.Cache
+	testRedisOpt        asynq.RedisConnOpt
 )
 
 func TestMain(m *testing.M) {
@@ -31,15 +36,21 @@ func TestMain(m *testing.M) {
 		log.Fatal().Err(err).Msg("could not create new redis cache")
 	}
 
+	connPool, err := pgxpool.New(context.Background(), config.DBSource)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not connect to the db")
+	}
+
+	testRedisOpt = redisOpt
 	testTaskDistributor = NewRedisTaskDistributor(redisOpt)
 
-	go runTestTaskProcessor(config, redisOpt, testRedisCache)
+	go runTestTaskProcessor(config, redisOpt, testRedisCache, db.NewStore(connPool), testTaskDistributor, event.NewMemoryProfileWatcher())
 
 	os.Exit(m.Run())
 }
 
-func runTestTaskProcessor(config util.Config, redisOpt asynq.RedisConnOpt, redisCache cache.Cache) {
-	taskProcessor := NewRedisTaskProcessor(redisOpt, config, redisCache)
+func runTestTaskProcessor(config util.Config, redisOpt asynq.RedisConnOpt, redisCache cache.Cache, store db.Store, taskDistributor TaskDistributor, profileWatcher event.ProfileWatcher) {
+	taskProcessor := NewRedisTaskProcessor(redisOpt, config, redisCache, store, taskDistributor, profileWatcher)
 
 	err := taskProcessor.Start()
 	if err != nil {
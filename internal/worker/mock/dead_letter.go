@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kyamalabs/users/internal/worker (interfaces: DeadLetterInspector)
+//
+// Generated by this command:
+//
+//	mockgen -package mockwk -destination=internal/worker/mock/dead_letter.go github.com/kyamalabs/users/internal/worker DeadLetterInspector
+//
+
+// Package mockwk is a generated GoMock package.
+package mockwk
+
+import (
+	reflect "reflect"
+
+	asynq "github.com/hibiken/asynq"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeadLetterInspector is a mock of DeadLetterInspector interface.
+type MockDeadLetterInspector struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterInspectorMockRecorder
+}
+
+// MockDeadLetterInspectorMockRecorder is the mock recorder for MockDeadLetterInspector.
+type MockDeadLetterInspectorMockRecorder struct {
+	mock *MockDeadLetterInspector
+}
+
+// NewMockDeadLetterInspector creates a new mock instance.
+func NewMockDeadLetterInspector(ctrl *gomock.Controller) *MockDeadLetterInspector {
+	mock := &MockDeadLetterInspector{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterInspectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterInspector) EXPECT() *MockDeadLetterInspectorMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockDeadLetterInspector) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDeadLetterInspectorMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDeadLetterInspector)(nil).Close))
+}
+
+// DeleteDeadLetterTask mocks base method.
+func (m *MockDeadLetterInspector) DeleteDeadLetterTask(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadLetterTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeadLetterTask indicates an expected call of DeleteDeadLetterTask.
+func (mr *MockDeadLetterInspectorMockRecorder) DeleteDeadLetterTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadLetterTask", reflect.TypeOf((*MockDeadLetterInspector)(nil).DeleteDeadLetterTask), arg0, arg1)
+}
+
+// GetDeadLetterTask mocks base method.
+func (m *MockDeadLetterInspector) GetDeadLetterTask(arg0, arg1 string) (*asynq.TaskInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetterTask", arg0, arg1)
+	ret0, _ := ret[0].(*asynq.TaskInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetterTask indicates an expected call of GetDeadLetterTask.
+func (mr *MockDeadLetterInspectorMockRecorder) GetDeadLetterTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetterTask", reflect.TypeOf((*MockDeadLetterInspector)(nil).GetDeadLetterTask), arg0, arg1)
+}
+
+// ListDeadLetterTasks mocks base method.
+func (m *MockDeadLetterInspector) ListDeadLetterTasks(arg0, arg1 string, arg2 asynq.TaskState, arg3, arg4 int) ([]*asynq.TaskInfo, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadLetterTasks", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*asynq.TaskInfo)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeadLetterTasks indicates an expected call of ListDeadLetterTasks.
+func (mr *MockDeadLetterInspectorMockRecorder) ListDeadLetterTasks(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetterTasks", reflect.TypeOf((*MockDeadLetterInspector)(nil).ListDeadLetterTasks), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ReplayDeadLetterTask mocks base method.
+func (m *MockDeadLetterInspector) ReplayDeadLetterTask(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDeadLetterTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplayDeadLetterTask indicates an expected call of ReplayDeadLetterTask.
+func (mr *MockDeadLetterInspectorMockRecorder) ReplayDeadLetterTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetterTask", reflect.TypeOf((*MockDeadLetterInspector)(nil).ReplayDeadLetterTask), arg0, arg1)
+}
@@ -14,7 +14,6 @@ import (
 	reflect "reflect"
 
 	asynq "github.com/hibiken/asynq"
-	worker "github.com/kyamalabs/users/internal/worker"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,21 +40,21 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
-// DistributeTaskCacheEnsName mocks base method.
-func (m *MockTaskDistributor) DistributeTaskCacheEnsName(arg0 context.Context, arg1 *worker.PayloadCacheEnsName, arg2 ...asynq.Option) error {
+// Enqueue mocks base method.
+func (m *MockTaskDistributor) Enqueue(arg0 context.Context, arg1 *asynq.Task, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
 	varargs := []any{arg0, arg1}
 	for _, a := range arg2 {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "DistributeTaskCacheEnsName", varargs...)
+	ret := m.ctrl.Call(m, "Enqueue", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DistributeTaskCacheEnsName indicates an expected call of DistributeTaskCacheEnsName.
-func (mr *MockTaskDistributorMockRecorder) DistributeTaskCacheEnsName(arg0, arg1 any, arg2 ...any) *gomock.Call {
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockTaskDistributorMockRecorder) Enqueue(arg0, arg1 any, arg2 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]any{arg0, arg1}, arg2...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskCacheEnsName", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskCacheEnsName), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockTaskDistributor)(nil).Enqueue), varargs...)
 }
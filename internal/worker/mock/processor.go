@@ -10,10 +10,8 @@
 package mockwk
 
 import (
-	context "context"
 	reflect "reflect"
 
-	asynq "github.com/hibiken/asynq"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,18 +38,16 @@ func (m *MockTaskProcessor) EXPECT() *MockTaskProcessorMockRecorder {
 	return m.recorder
 }
 
-// ProcessTaskCacheEnsName mocks base method.
-func (m *MockTaskProcessor) ProcessTaskCacheEnsName(arg0 context.Context, arg1 *asynq.Task) error {
+// Shutdown mocks base method.
+func (m *MockTaskProcessor) Shutdown() {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessTaskCacheEnsName", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	m.ctrl.Call(m, "Shutdown")
 }
 
-// ProcessTaskCacheEnsName indicates an expected call of ProcessTaskCacheEnsName.
-func (mr *MockTaskProcessorMockRecorder) ProcessTaskCacheEnsName(arg0, arg1 any) *gomock.Call {
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockTaskProcessorMockRecorder) Shutdown() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTaskCacheEnsName", reflect.TypeOf((*MockTaskProcessor)(nil).ProcessTaskCacheEnsName), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTaskProcessor)(nil).Shutdown))
 }
 
 // Start mocks base method.
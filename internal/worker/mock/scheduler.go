@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kyamalabs/users/internal/worker (interfaces: TaskScheduler)
+//
+// Generated by this command:
+//
+//	mockgen -package mockwk -destination=internal/worker/mock/scheduler.go github.com/kyamalabs/users/internal/worker TaskScheduler
+//
+
+// Package mockwk is a generated GoMock package.
+package mockwk
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaskScheduler is a mock of TaskScheduler interface.
+type MockTaskScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskSchedulerMockRecorder
+}
+
+// MockTaskSchedulerMockRecorder is the mock recorder for MockTaskScheduler.
+type MockTaskSchedulerMockRecorder struct {
+	mock *MockTaskScheduler
+}
+
+// NewMockTaskScheduler creates a new mock instance.
+func NewMockTaskScheduler(ctrl *gomock.Controller) *MockTaskScheduler {
+	mock := &MockTaskScheduler{ctrl: ctrl}
+	mock.recorder = &MockTaskSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskScheduler) EXPECT() *MockTaskSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Shutdown mocks base method.
+func (m *MockTaskScheduler) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockTaskSchedulerMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTaskScheduler)(nil).Shutdown))
+}
+
+// Start mocks base method.
+func (m *MockTaskScheduler) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockTaskSchedulerMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockTaskScheduler)(nil).Start))
+}
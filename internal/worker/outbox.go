@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/requestid"
+	"github.com/kyamalabs/users/internal/tracing"
+)
+
+const OutboxSinkTasks = "tasks"
+
+// OutboxSink delivers outbox messages recorded for it to their final destination.
+type OutboxSink interface {
+	Publish(ctx context.Context, message db.Outbox) error
+}
+
+// OutboxTaskHeaders holds the enqueue options of a task relayed through the outbox, along with
+// the request id and trace context of the request that recorded it.
+type OutboxTaskHeaders struct {
+	Queue        string            `json:"queue,omitempty"`
+	MaxRetry     int               `json:"max_retry,omitempty"`
+	ProcessIn    time.Duration     `json:"process_in,omitempty"`
+	RequestID    string            `json:"request_id,omitempty"`
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+}
+
+func (headers OutboxTaskHeaders) options() []asynq.Option {
+	var opts []asynq.Option
+
+	if headers.Queue != "" {
+		opts = append(opts, asynq.Queue(headers.Queue))
+	}
+	if headers.MaxRetry > 0 {
+		opts = append(opts, asynq.MaxRetry(headers.MaxRetry))
+	}
+	if headers.ProcessIn > 0 {
+		opts = append(opts, asynq.ProcessIn(headers.ProcessIn))
+	}
+
+	return opts
+}
+
+func newTaskOutboxMessage(taskType string, payload interface{}, headers OutboxTaskHeaders) (db.CreateOutboxMessageParams, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return db.CreateOutboxMessageParams{}, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	jsonHeaders, err := json.Marshal(headers)
+	if err != nil {
+		return db.CreateOutboxMessageParams{}, fmt.Errorf("failed to marshal task headers: %w", err)
+	}
+
+	return db.CreateOutboxMessageParams{
+		Sink:    OutboxSinkTasks,
+		Topic:   taskType,
+		Payload: jsonPayload,
+		Headers: jsonHeaders,
+	}, nil
+}
+
+// taskOutboxSink enqueues relayed tasks through the task distributor so they get the same
+// deduplication and logging as tasks enqueued directly.
+type taskOutboxSink struct {
+	taskDistributor TaskDistributor
+}
+
+func (sink *taskOutboxSink) Publish(ctx context.Context, message db.Outbox) error {
+	var headers OutboxTaskHeaders
+	if err := json.Unmarshal(message.Headers, &headers); err != nil {
+		return fmt.Errorf("failed to unmarshal task headers: %w", err)
+	}
+
+	task, ok := lookupTask(message.Topic)
+	if !ok {
+		return fmt.Errorf("unsupported outbox task type: %s", message.Topic)
+	}
+
+	taskCtx := tracing.ExtractContext(ctx, headers.TraceContext)
+	if headers.RequestID != "" {
+		taskCtx = requestid.NewContext(taskCtx, headers.RequestID)
+	}
+
+	t, err := task.newRawTask(message.Payload, withContextTaskID(taskCtx, headers.options())...)
+	if err != nil {
+		return err
+	}
+
+	return sink.taskDistributor.Enqueue(taskCtx, t)
+}
@@ -0,0 +1,234 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultOutboxRelayInterval  = time.Second
+	defaultOutboxRelayBatchSize = 100
+	defaultOutboxRetention      = 24 * time.Hour
+	defaultOutboxMaxAttempts    = 20
+	outboxCleanupInterval       = time.Hour
+	outboxLeaseDuration         = time.Minute
+	outboxRetryBaseBackoff      = time.Second
+	outboxRetryMaxBackoff       = 5 * time.Minute
+)
+
+// OutboxRelay publishes outbox messages to their sinks with at-least-once delivery. Messages
+// are leased while being published so that several replicas can relay concurrently, and a
+// message whose publish fails is retried with exponential backoff until it runs out of attempts,
+// after which it is marked as failed and left in the outbox for inspection.
+type OutboxRelay struct {
+	store       db.Store
+	sinks       map[string]OutboxSink
+	interval    time.Duration
+	batchSize   int32
+	retention   time.Duration
+	maxAttempts int32
+	now         func() time.Time
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+func NewOutboxRelay(config util.Config, store db.Store, taskDistributor TaskDistributor) *OutboxRelay {
+	interval := config.OutboxRelayInterval
+	if interval <= 0 {
+		interval = defaultOutboxRelayInterval
+	}
+
+	batchSize := config.OutboxRelayBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultOutboxRelayBatchSize
+	}
+
+	retention := config.OutboxRetention
+	if retention <= 0 {
+		retention = defaultOutboxRetention
+	}
+
+	maxAttempts := config.OutboxMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultOutboxMaxAttempts
+	}
+
+	return &OutboxRelay{
+		store: store,
+		sinks: map[string]OutboxSink{
+			OutboxSinkTasks: &taskOutboxSink{taskDistributor: taskDistributor},
+		},
+		interval:    interval,
+		batchSize:   batchSize,
+		retention:   retention,
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+	}
+}
+
+// RegisterSink routes outbox messages recorded for name to sink. It must be called before Start.
+func (relay *OutboxRelay) RegisterSink(name string, sink OutboxSink) {
+	relay.sinks[name] = sink
+}
+
+func (relay *OutboxRelay) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	relay.cancel = cancel
+
+	relay.wg.Add(1)
+	go func() {
+		defer relay.wg.Done()
+
+		ticker := time.NewTicker(relay.interval)
+		defer ticker.Stop()
+
+		cleanupTicker := time.NewTicker(outboxCleanupInterval)
+		defer cleanupTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				relay.relayPending(ctx)
+			case <-cleanupTicker.C:
+				err := relay.cleanup(ctx)
+				if err != nil {
+					log.Error().Err(err).Msg("could not clean up published outbox messages")
+				}
+			}
+		}
+	}()
+}
+
+// Shutdown stops relaying and waits for the batch in flight to finish.
+func (relay *OutboxRelay) Shutdown() {
+	if relay.cancel != nil {
+		relay.cancel()
+	}
+	relay.wg.Wait()
+}
+
+func (relay *OutboxRelay) relayPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		relayed, err := relay.relayBatch(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("could not relay outbox messages")
+			return
+		}
+
+		if relayed < int(relay.batchSize) {
+			return
+		}
+	}
+}
+
+func (relay *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	messages, err := relay.store.ClaimOutboxMessages(ctx, db.ClaimOutboxMessagesParams{
+		LeaseExpiresAt: relay.now().Add(outboxLeaseDuration),
+		BatchSize:      relay.batchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not claim outbox messages: %w", err)
+	}
+
+	for _, message := range messages {
+		relay.publish(ctx, message)
+	}
+
+	return len(messages), nil
+}
+
+func (relay *OutboxRelay) publish(ctx context.Context, message db.Outbox) {
+	logger := log.With().
+		Int64("outbox_id", message.ID).
+		Str("sink", message.Sink).
+		Str("topic", message.Topic).
+		Int32("attempts", message.Attempts).
+		Logger()
+
+	var publishErr error
+	if sink, ok := relay.sinks[message.Sink]; ok {
+		publishErr = sink.Publish(ctx, message)
+	} else {
+		publishErr = fmt.Errorf("unknown outbox sink: %s", message.Sink)
+	}
+
+	if publishErr != nil && message.Attempts >= relay.maxAttempts {
+		logger.Error().Err(publishErr).Msg("could not publish outbox message, giving up")
+		metrics.OutboxMessagesFailed.WithLabelValues(message.Sink, message.Topic).Inc()
+
+		err := relay.store.MarkOutboxMessageFailed(ctx, db.MarkOutboxMessageFailedParams{
+			ID: message.ID,
+			LastError: pgtype.Text{
+				String: publishErr.Error(),
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("could not mark outbox message as failed")
+		}
+		return
+	}
+
+	if publishErr != nil {
+		retryAt := relay.now().Add(outboxRetryBackoff(message.Attempts))
+		logger.Error().Err(publishErr).Time("retry_at", retryAt).Msg("could not publish outbox message")
+
+		err := relay.store.RescheduleOutboxMessage(ctx, db.RescheduleOutboxMessageParams{
+			ID:          message.ID,
+			AvailableAt: retryAt,
+			LastError: pgtype.Text{
+				String: publishErr.Error(),
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("could not reschedule outbox message")
+		}
+		return
+	}
+
+	// a failure here means the message is published again once its lease expires
+	err := relay.store.MarkOutboxMessagePublished(ctx, message.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not mark outbox message as published")
+		return
+	}
+
+	logger.Info().Msg("published outbox message")
+}
+
+func (relay *OutboxRelay) cleanup(ctx context.Context) error {
+	deleted, err := relay.store.DeletePublishedOutboxMessages(ctx, relay.now().Add(-relay.retention))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		log.Info().Int64("deleted", deleted).Msg("cleaned up published outbox messages")
+	}
+
+	return nil
+}
+
+func outboxRetryBackoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := outboxRetryBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > outboxRetryMaxBackoff {
+		backoff = outboxRetryMaxBackoff
+	}
+
+	return backoff
+}
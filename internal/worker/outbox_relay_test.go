@@ -0,0 +1,224 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestOutboxMessage(t *testing.T, id int64, walletAddress string) db.Outbox {
+	params, err := CacheEnsNameTask.NewOutboxMessage(&PayloadCacheEnsName{WalletAddress: walletAddress}, OutboxTaskHeaders{Queue: QueueDefault})
+	require.NoError(t, err)
+
+	return db.Outbox{
+		ID:       id,
+		Sink:     params.Sink,
+		Topic:    params.Topic,
+		Payload:  params.Payload,
+		Headers:  params.Headers,
+		Attempts: 1,
+	}
+}
+
+func TestOutboxRelay_RelayBatch(t *testing.T) {
+	now := time.Now()
+	message := newTestOutboxMessage(t, 1, "0x0000000000000000000000000000000000000001")
+
+	unknownSinkMessage := newTestOutboxMessage(t, 2, "0x0000000000000000000000000000000000000002")
+	unknownSinkMessage.Sink = "unknown"
+
+	unknownTopicMessage := newTestOutboxMessage(t, 3, "0x0000000000000000000000000000000000000003")
+	unknownTopicMessage.Topic = "unknown"
+	unknownTopicMessage.Attempts = 3
+
+	exhaustedMessage := newTestOutboxMessage(t, 4, "0x0000000000000000000000000000000000000004")
+	exhaustedMessage.Attempts = 5
+
+	testCases := []struct {
+		name               string
+		distributorErr     error
+		buildStubs         func(store *mockdb.MockStore)
+		expectedToError    bool
+		expectedRelayed    int
+		expectedEnqueuedTo []string
+	}{
+		{
+			name: "publishes claimed messages",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimOutboxMessages(gomock.Any(), db.ClaimOutboxMessagesParams{
+						LeaseExpiresAt: now.Add(outboxLeaseDuration),
+						BatchSize:      10,
+					}).
+					Times(1).
+					Return([]db.Outbox{message}, nil)
+
+				store.EXPECT().
+					MarkOutboxMessagePublished(gomock.Any(), message.ID).
+					Times(1).
+					Return(nil)
+			},
+			expectedRelayed:    1,
+			expectedEnqueuedTo: []string{"0x0000000000000000000000000000000000000001"},
+		},
+		{
+			name:           "reschedules messages that fail to publish",
+			distributorErr: errors.New("some queue error"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimOutboxMessages(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Outbox{message}, nil)
+
+				store.EXPECT().
+					RescheduleOutboxMessage(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, params db.RescheduleOutboxMessageParams) error {
+						require.Equal(t, message.ID, params.ID)
+						require.Equal(t, now.Add(outboxRetryBaseBackoff), params.AvailableAt)
+						require.Equal(t, "some queue error", params.LastError.String)
+						return nil
+					})
+
+				store.EXPECT().
+					MarkOutboxMessagePublished(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedRelayed: 1,
+		},
+		{
+			name: "reschedules messages with unknown sinks and topics",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimOutboxMessages(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Outbox{unknownSinkMessage, unknownTopicMessage}, nil)
+
+				store.EXPECT().
+					RescheduleOutboxMessage(gomock.Any(), db.RescheduleOutboxMessageParams{
+						ID:          unknownSinkMessage.ID,
+						AvailableAt: now.Add(outboxRetryBaseBackoff),
+						LastError:   pgtype.Text{String: "unknown outbox sink: unknown", Valid: true},
+					}).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					RescheduleOutboxMessage(gomock.Any(), db.RescheduleOutboxMessageParams{
+						ID:          unknownTopicMessage.ID,
+						AvailableAt: now.Add(4 * outboxRetryBaseBackoff),
+						LastError:   pgtype.Text{String: "unsupported outbox task type: unknown", Valid: true},
+					}).
+					Times(1).
+					Return(nil)
+			},
+			expectedRelayed: 2,
+		},
+		{
+			name:           "marks messages out of attempts as failed",
+			distributorErr: errors.New("some queue error"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimOutboxMessages(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Outbox{exhaustedMessage}, nil)
+
+				store.EXPECT().
+					MarkOutboxMessageFailed(gomock.Any(), db.MarkOutboxMessageFailedParams{
+						ID:        exhaustedMessage.ID,
+						LastError: pgtype.Text{String: "some queue error", Valid: true},
+					}).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					RescheduleOutboxMessage(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedRelayed: 1,
+		},
+		{
+			name: "db error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimOutboxMessages(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some db error"))
+			},
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			distributor := &recordingTaskDistributor{err: tc.distributorErr}
+
+			tc.buildStubs(store)
+
+			relay := &OutboxRelay{
+				store: store,
+				sinks: map[string]OutboxSink{
+					OutboxSinkTasks: &taskOutboxSink{taskDistributor: distributor},
+				},
+				batchSize:   10,
+				maxAttempts: 5,
+				now:         func() time.Time { return now },
+			}
+
+			relayed, err := relay.relayBatch(context.Background())
+			if tc.expectedToError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRelayed, relayed)
+
+			var enqueuedTo []string
+			for _, payload := range recordedPayloads(t, distributor, CacheEnsNameTask) {
+				enqueuedTo = append(enqueuedTo, payload.WalletAddress)
+			}
+			require.Equal(t, tc.expectedEnqueuedTo, enqueuedTo)
+		})
+	}
+}
+
+func TestOutboxRelay_Cleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		DeletePublishedOutboxMessages(gomock.Any(), now.Add(-time.Hour)).
+		Times(1).
+		Return(int64(5), nil)
+
+	relay := &OutboxRelay{
+		store:     store,
+		retention: time.Hour,
+		now:       func() time.Time { return now },
+	}
+
+	err := relay.cleanup(context.Background())
+	require.NoError(t, err)
+}
+
+func TestOutboxRetryBackoff(t *testing.T) {
+	require.Equal(t, time.Second, outboxRetryBackoff(0))
+	require.Equal(t, time.Second, outboxRetryBackoff(1))
+	require.Equal(t, 8*time.Second, outboxRetryBackoff(4))
+	require.Equal(t, outboxRetryMaxBackoff, outboxRetryBackoff(10))
+	require.Equal(t, outboxRetryMaxBackoff, outboxRetryBackoff(100))
+}
@@ -2,9 +2,12 @@ package worker
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/hibiken/asynq"
 	"github.com/kyamalabs/users/internal/cache"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
 	"github.com/kyamalabs/users/internal/util"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
@@ -17,24 +20,38 @@ const (
 
 type TaskProcessor interface {
 	Start() error
-	ProcessTaskCacheEnsName(context.Context, *asynq.Task) error
+	Shutdown()
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	config util.Config
-	cache  cache.Cache
+	server          *asynq.Server
+	config          util.Config
+	cache           cache.Cache
+	store           db.Store
+	taskDistributor TaskDistributor
+	profileWatcher  event.ProfileWatcher
+	ethClients      *EthClientPool
+	webhookClient   *http.Client
 }
 
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
-	mux.HandleFunc(TaskCacheENSName, processor.ProcessTaskCacheEnsName)
+	for _, task := range registeredTasks() {
+		mux.Handle(task.taskType(), task.handler(processor))
+	}
 
 	return processor.server.Start(mux)
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisConnOpt, config util.Config, cache cache.Cache) TaskProcessor {
+// Shutdown stops pulling new tasks and waits up to the configured shutdown timeout for the tasks
+// in flight. Tasks that do not finish in time are handed back to their queue to be retried.
+func (processor *RedisTaskProcessor) Shutdown() {
+	processor.server.Shutdown()
+	processor.ethClients.Close()
+}
+
+func NewRedisTaskProcessor(redisOpt asynq.RedisConnOpt, config util.Config, cache cache.Cache, store db.Store, taskDistributor TaskDistributor, profileWatcher event.ProfileWatcher) TaskProcessor {
 	logger := NewLogger()
 	redis.SetLogger(logger)
 
@@ -44,17 +61,27 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisConnOpt, config util.Config, cach
 			QueueCritical: 7,
 		},
 		ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
-			log.Error().Err(err).
+			taskID, _ := asynq.GetTaskID(ctx)
+			log.Ctx(taskContext(ctx, taskID)).Error().Err(err).
 				Str("type", task.Type()).
 				Bytes("payload", task.Payload()).
 				Msg("process task failed")
 		}),
-		Logger: logger,
+		RetryDelayFunc:  retryDelay,
+		ShutdownTimeout: config.ShutdownTimeout,
+		Logger:          logger,
 	})
 
+	rpcURLs := append([]string{config.EthereumRPCURL}, config.EthereumRPCFallbackURLs...)
+
 	return &RedisTaskProcessor{
-		server: server,
-		config: config,
-		cache:  cache,
+		server:          server,
+		config:          config,
+		cache:           cache,
+		store:           store,
+		taskDistributor: taskDistributor,
+		profileWatcher:  profileWatcher,
+		ethClients:      NewEthClientPool(rpcURLs, config.EthereumRPCHealthCheckInterval),
+		webhookClient:   newWebhookClient(config.WebhookTimeout),
 	}
 }
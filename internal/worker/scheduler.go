@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+type TaskScheduler interface {
+	Start() error
+	Shutdown()
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+	config    util.Config
+}
+
+func (s *RedisTaskScheduler) Start() error {
+	if s.config.EnsRefreshInterval > 0 {
+		task, err := RefreshEnsNamesTask.NewTask(
+			&PayloadRefreshEnsNames{},
+			asynq.MaxRetry(0),
+			asynq.Unique(s.config.EnsRefreshInterval),
+		)
+		if err != nil {
+			return fmt.Errorf("could not build periodic ens name refresh task: %w", err)
+		}
+
+		_, err = s.scheduler.Register(fmt.Sprintf("@every %s", s.config.EnsRefreshInterval), task)
+		if err != nil {
+			return fmt.Errorf("could not register periodic ens name refresh task: %w", err)
+		}
+	}
+
+	return s.scheduler.Start()
+}
+
+func (s *RedisTaskScheduler) Shutdown() {
+	s.scheduler.Shutdown()
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisConnOpt, config util.Config) TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+		EnqueueErrorHandler: func(task *asynq.Task, _ []asynq.Option, err error) {
+			if errors.Is(err, asynq.ErrDuplicateTask) {
+				log.Debug().Str("type", task.Type()).Msg("periodic task already enqueued by another scheduler")
+				return
+			}
+
+			log.Error().Err(err).
+				Str("type", task.Type()).
+				Msg("could not enqueue periodic task")
+		},
+	})
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+		config:    config,
+	}
+}
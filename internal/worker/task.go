@@ -0,0 +1,217 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/kyamalabs/users/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TaskDefinition declares everything about a task type in one place: the payload it carries,
+// the options it is enqueued with by default and the handler that processes it.
+type TaskDefinition[P any] struct {
+	Type     string
+	Queue    string
+	MaxRetry int
+	// Unique deduplicates tasks with identical payloads enqueued within the duration.
+	Unique time.Duration
+	// RetryDelay overrides asynq's default backoff between retries.
+	RetryDelay func(n int) time.Duration
+	Handle     func(processor *RedisTaskProcessor, ctx context.Context, payload *P) error
+}
+
+// Task is a registered task type. Tasks are built and enqueued through it so payloads are
+// always of the type its handler expects.
+type Task[P any] struct {
+	definition TaskDefinition[P]
+}
+
+// registeredTask is the untyped view of a Task the processor, scheduler and outbox work with.
+type registeredTask interface {
+	taskType() string
+	handler(processor *RedisTaskProcessor) asynq.HandlerFunc
+	retryDelay() func(n int) time.Duration
+	newRawTask(payload []byte, opts ...asynq.Option) (*asynq.Task, error)
+}
+
+var taskRegistry = map[string]registeredTask{}
+
+// RegisterTask adds a task type to the registry the task processor serves. It is meant to be
+// called from package level variable declarations and panics if the type is registered twice.
+func RegisterTask[P any](definition TaskDefinition[P]) *Task[P] {
+	if _, exists := taskRegistry[definition.Type]; exists {
+		panic(fmt.Sprintf("task type %s is already registered", definition.Type))
+	}
+
+	task := &Task[P]{definition: definition}
+	taskRegistry[definition.Type] = task
+
+	return task
+}
+
+func lookupTask(taskType string) (registeredTask, bool) {
+	task, ok := taskRegistry[taskType]
+	return task, ok
+}
+
+func registeredTasks() []registeredTask {
+	tasks := make([]registeredTask, 0, len(taskRegistry))
+	for _, task := range taskRegistry {
+		tasks = append(tasks, task)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].taskType() < tasks[j].taskType()
+	})
+
+	return tasks
+}
+
+func (task *Task[P]) Type() string {
+	return task.definition.Type
+}
+
+// NewTask builds an asynq task carrying the payload. Options passed in take precedence over the
+// defaults of the task definition.
+func (task *Task[P]) NewTask(payload *P, opts ...asynq.Option) (*asynq.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	return task.newRawTask(jsonPayload, opts...)
+}
+
+// Enqueue builds a task carrying the payload and hands it to the task distributor. The task is
+// processed with the request id and trace of ctx unless a task id is passed in.
+func (task *Task[P]) Enqueue(ctx context.Context, taskDistributor TaskDistributor, payload *P, opts ...asynq.Option) error {
+	t, err := task.NewTask(payload, withContextTaskID(ctx, opts)...)
+	if err != nil {
+		return err
+	}
+
+	return taskDistributor.Enqueue(ctx, t)
+}
+
+// NewOutboxMessage builds an outbox message that enqueues the task once the transaction it is
+// recorded in commits.
+func (task *Task[P]) NewOutboxMessage(payload *P, headers OutboxTaskHeaders) (db.CreateOutboxMessageParams, error) {
+	return newTaskOutboxMessage(task.definition.Type, payload, headers)
+}
+
+// Payload decodes the payload of a task of this type.
+func (task *Task[P]) Payload(t *asynq.Task) (*P, error) {
+	if t.Type() != task.definition.Type {
+		return nil, fmt.Errorf("unexpected task type: %s", t.Type())
+	}
+
+	var payload P
+
+	// tasks without a payload, such as periodic tasks enqueued by older schedulers, decode to
+	// the zero value
+	if len(t.Payload()) == 0 {
+		return &payload, nil
+	}
+
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+func (task *Task[P]) taskType() string {
+	return task.definition.Type
+}
+
+func (task *Task[P]) retryDelay() func(n int) time.Duration {
+	return task.definition.RetryDelay
+}
+
+func (task *Task[P]) newRawTask(payload []byte, opts ...asynq.Option) (*asynq.Task, error) {
+	var p P
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task payload: %w", err)
+	}
+
+	return asynq.NewTask(task.definition.Type, payload, append(task.defaultOptions(), opts...)...), nil
+}
+
+func (task *Task[P]) defaultOptions() []asynq.Option {
+	var opts []asynq.Option
+
+	if task.definition.Queue != "" {
+		opts = append(opts, asynq.Queue(task.definition.Queue))
+	}
+	if task.definition.MaxRetry > 0 {
+		opts = append(opts, asynq.MaxRetry(task.definition.MaxRetry))
+	}
+	if task.definition.Unique > 0 {
+		opts = append(opts, asynq.Unique(task.definition.Unique))
+	}
+
+	return opts
+}
+
+func (task *Task[P]) handler(processor *RedisTaskProcessor) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) (err error) {
+		startTime := time.Now()
+		defer func() {
+			metrics.TaskDuration.WithLabelValues(t.Type()).Observe(time.Since(startTime).Seconds())
+		}()
+
+		taskID, _ := asynq.GetTaskID(ctx)
+		ctx, span := tracing.Tracer().Start(
+			taskContext(ctx, taskID),
+			"process "+t.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("task.type", t.Type()),
+				attribute.String("task.id", taskID),
+			),
+		)
+		defer func() {
+			tracing.EndSpan(span, err)
+		}()
+
+		payload, err := task.Payload(t)
+		if err != nil {
+			metrics.TasksProcessed.WithLabelValues(t.Type(), metrics.TaskResultFailed).Inc()
+			return fmt.Errorf("%s: %w", err, asynq.SkipRetry)
+		}
+
+		err = task.definition.Handle(processor, ctx, payload)
+		if err != nil {
+			metrics.TasksProcessed.WithLabelValues(t.Type(), metrics.TaskResultFailed).Inc()
+			return err
+		}
+
+		metrics.TasksProcessed.WithLabelValues(t.Type(), metrics.TaskResultSucceeded).Inc()
+
+		log.Ctx(ctx).Info().
+			Str("type", t.Type()).
+			Bytes("payload", t.Payload()).
+			Msg("processed task")
+
+		return nil
+	}
+}
+
+// retryDelay applies the backoff declared by a task definition and leaves the other tasks on
+// asynq's default schedule.
+func retryDelay(n int, err error, t *asynq.Task) time.Duration {
+	if task, ok := lookupTask(t.Type()); ok && task.retryDelay() != nil {
+		return task.retryDelay()(n)
+	}
+
+	return asynq.DefaultRetryDelayFunc(n, err, t)
+}
@@ -2,97 +2,257 @@ package worker
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/api/pb"
 	"github.com/kyamalabs/users/internal/cache"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/kyamalabs/users/internal/tracing"
 	"github.com/rs/zerolog/log"
 	"github.com/wealdtech/go-ens/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	TaskCacheENSName       = "task:cache_ens_name"
 	ensNameCacheKeyPrefix  = "ens-name"
 	ensNameCacheExpiration = 7 * 24 * time.Hour
+	ensNameErrorExpiration = 5 * time.Minute
+	ensNameTaskUniqueTTL   = time.Hour
+	ensNameCacheLabel      = "ens_name"
 )
 
+type EnsNameStatus string
+
+const (
+	EnsNameStatusResolved EnsNameStatus = "resolved"
+	EnsNameStatusNone     EnsNameStatus = "none"
+	EnsNameStatusError    EnsNameStatus = "error"
+)
+
+type CachedENSName struct {
+	Status     EnsNameStatus `json:"status"`
+	Name       string        `json:"name"`
+	ResolvedAt time.Time     `json:"resolved_at"`
+}
+
 type PayloadCacheEnsName struct {
 	WalletAddress string `json:"wallet_address"`
 }
 
-func (distributor *RedisTaskDistributor) DistributeTaskCacheEnsName(ctx context.Context, payload *PayloadCacheEnsName, opts ...asynq.Option) error {
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal task payload: %w", err)
+var CacheEnsNameTask = RegisterTask(TaskDefinition[PayloadCacheEnsName]{
+	Type:     TaskCacheENSName,
+	Queue:    QueueDefault,
+	MaxRetry: 10,
+	// only one resolution per wallet address may be pending in a queue at a time
+	Unique: ensNameTaskUniqueTTL,
+	Handle: (*RedisTaskProcessor).processTaskCacheEnsName,
+})
+
+func getCacheKey(walletAddress string) string {
+	return fmt.Sprintf("%s:%s", ensNameCacheKeyPrefix, walletAddress)
+}
+
+func (processor *RedisTaskProcessor) processTaskCacheEnsName(ctx context.Context, payload *PayloadCacheEnsName) error {
+	entry, resolveErr := processor.resolveENSName(ctx, payload.WalletAddress)
+	if resolveErr != nil {
+		// keep serving a previously resolved entry rather than replacing it with a transient failure
+		cached, err := GetCachedENSName(ctx, processor.cache, payload.WalletAddress)
+		if err == nil && cached.Status != EnsNameStatusError {
+			return fmt.Errorf("could not resolve address into an ENS name: %w", resolveErr)
+		}
+
+		err = processor.cacheENSName(ctx, payload.WalletAddress, entry, ensNameErrorExpiration)
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not resolve address into an ENS name: %w", resolveErr)
 	}
 
-	task := asynq.NewTask(TaskCacheENSName, jsonPayload, opts...)
-	info, err := distributor.client.EnqueueContext(ctx, task)
+	err := processor.cacheENSName(ctx, payload.WalletAddress, entry, ensNameCacheExpiration)
 	if err != nil {
-		return fmt.Errorf("failed to enqueue task: %w", err)
+		return err
 	}
 
-	log.Info().
-		Str("type", task.Type()).
-		Bytes("payload", task.Payload()).
-		Str("queue", info.Queue).
-		Int("max_retry", info.MaxRetry).
-		Msg("enqueued task")
+	processor.notifyENSNameResolved(ctx, payload.WalletAddress, entry)
 
 	return nil
 }
 
-func getCacheKey(walletAddress string) string {
-	return fmt.Sprintf("%s:%s", ensNameCacheKeyPrefix, walletAddress)
+func (processor *RedisTaskProcessor) resolveENSName(ctx context.Context, walletAddress string) (entry CachedENSName, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "ens.ReverseResolve",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("wallet_address", walletAddress)),
+	)
+	defer func() {
+		span.SetAttributes(attribute.String("ens.status", string(entry.Status)))
+		tracing.EndSpan(span, err)
+	}()
+
+	entry = CachedENSName{
+		ResolvedAt: time.Now().UTC(),
+	}
+
+	client, err := processor.ethClients.Client(ctx)
+	if err != nil {
+		entry.Status = EnsNameStatusError
+		return entry, fmt.Errorf("failed to connect to the Ethereum blockchain: %w", err)
+	}
+
+	ensName, err := ens.ReverseResolve(client, common.HexToAddress(walletAddress))
+	switch {
+	case err == nil:
+		entry.Status = EnsNameStatusResolved
+		entry.Name = ensName
+	case isENSNameNotFoundError(err):
+		log.Ctx(ctx).Info().Err(err).Str("wallet_address", walletAddress).Msg("wallet address has no ENS name")
+		entry.Status = EnsNameStatusNone
+	default:
+		entry.Status = EnsNameStatusError
+		return entry, err
+	}
+
+	return entry, nil
+}
+
+// isENSNameNotFoundError reports whether a reverse resolution failed because the
+// address has no reverse record, as opposed to a transient RPC failure.
+func isENSNameNotFoundError(err error) bool {
+	switch err.Error() {
+	case "no resolution", "not a resolver":
+		return true
+	}
+
+	return false
+}
+
+// ensNameCodec decodes structured entries as JSON and also accepts entries cached
+// before resolution statuses were introduced, which only hold the name.
+type ensNameCodec struct {
+	cache.JSONCodec[CachedENSName]
 }
 
-func (processor *RedisTaskProcessor) ProcessTaskCacheEnsName(ctx context.Context, task *asynq.Task) error {
-	var payload PayloadCacheEnsName
-	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
-		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+func (codec ensNameCodec) Decode(value interface{}) (CachedENSName, error) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return CachedENSName{Status: EnsNameStatusNone}, nil
+		}
+		return CachedENSName{Status: EnsNameStatusResolved, Name: v}, nil
+	case CachedENSName, map[string]interface{}:
+		return codec.JSONCodec.Decode(v)
 	}
 
-	client, err := ethclient.Dial(processor.config.EthereumRPCURL)
+	return CachedENSName{}, errors.New("could not decode cached ens name")
+}
+
+func ensNameCache(c cache.Cache) *cache.Typed[CachedENSName] {
+	return cache.NewTyped[CachedENSName](c, ensNameCodec{})
+}
+
+// cacheENSName caches the entry and records when it expires, which is what the periodic refresh
+// selects the names to resolve again by. A failure to record the expiry is only logged since the
+// name is then refreshed early rather than late.
+func (processor *RedisTaskProcessor) cacheENSName(ctx context.Context, walletAddress string, entry CachedENSName, expiration time.Duration) error {
+	err := setCachedENSName(ctx, processor.cache, walletAddress, entry, expiration)
 	if err != nil {
-		return fmt.Errorf("failed to connect to the Ethereum blockchain at %s: %w", processor.config.EthereumRPCURL, err)
+		return err
 	}
 
-	ensName, err := ens.ReverseResolve(client, common.HexToAddress(payload.WalletAddress))
+	err = processor.store.UpsertEnsNameExpiry(ctx, db.UpsertEnsNameExpiryParams{
+		WalletAddress: walletAddress,
+		ExpiresAt:     time.Now().Add(expiration),
+	})
 	if err != nil {
-		log.Info().Err(err).Str("wallet_address", payload.WalletAddress).Msg("could not resolve address into an ENS name")
+		log.Ctx(ctx).Error().Err(err).Str("wallet_address", walletAddress).Msg("could not record ens name expiry")
 	}
 
-	err = processor.cache.Set(ctx, getCacheKey(payload.WalletAddress), ensName, ensNameCacheExpiration)
+	return nil
+}
+
+func setCachedENSName(ctx context.Context, c cache.Cache, walletAddress string, entry CachedENSName, expiration time.Duration) error {
+	err := ensNameCache(c).Set(ctx, getCacheKey(walletAddress), entry, expiration)
 	if err != nil {
 		return fmt.Errorf("could not store ens name in cache: %w", err)
 	}
 
-	log.Info().
-		Str("type", task.Type()).
-		Bytes("payload", task.Payload()).
-		Msg("processed task")
-
 	return nil
 }
 
-func GetCachedENSName(ctx context.Context, c cache.Cache, walletAddress string) (string, error) {
-	res, err := c.Get(ctx, getCacheKey(walletAddress))
+func GetCachedENSName(ctx context.Context, c cache.Cache, walletAddress string) (CachedENSName, error) {
+	cachedENSName, err := ensNameCache(c).Get(ctx, getCacheKey(walletAddress))
+	if err == cache.Nil {
+		metrics.CacheRequests.WithLabelValues(ensNameCacheLabel, metrics.CacheResultMiss).Inc()
+		return CachedENSName{}, cache.Nil
+	}
+	if err != nil {
+		metrics.CacheRequests.WithLabelValues(ensNameCacheLabel, metrics.CacheResultError).Inc()
+		return CachedENSName{}, fmt.Errorf("could not fetch ens name from cache: %w", err)
+	}
+
+	metrics.CacheRequests.WithLabelValues(ensNameCacheLabel, metrics.CacheResultHit).Inc()
+
+	return cachedENSName, nil
+}
+
+// GetCachedENSNames returns the cached ens names of the wallet addresses that have one.
+func GetCachedENSNames(ctx context.Context, c cache.Cache, walletAddresses []string) (map[string]CachedENSName, error) {
+	keys := make([]string, len(walletAddresses))
+	for i, walletAddress := range walletAddresses {
+		keys[i] = getCacheKey(walletAddress)
+	}
+
+	res, err := ensNameCache(c).MGet(ctx, keys...)
 	if err != nil {
-		return "", fmt.Errorf("could not fetch ens name from cache: %w", err)
+		metrics.CacheRequests.WithLabelValues(ensNameCacheLabel, metrics.CacheResultError).Add(float64(len(keys)))
+		return nil, fmt.Errorf("could not fetch ens names from cache: %w", err)
 	}
-	if res == nil {
-		return "", cache.Nil
+
+	metrics.CacheRequests.WithLabelValues(ensNameCacheLabel, metrics.CacheResultHit).Add(float64(len(res)))
+	metrics.CacheRequests.WithLabelValues(ensNameCacheLabel, metrics.CacheResultMiss).Add(float64(len(keys) - len(res)))
+
+	cachedENSNames := make(map[string]CachedENSName, len(res))
+	for i, walletAddress := range walletAddresses {
+		if cachedENSName, ok := res[keys[i]]; ok {
+			cachedENSNames[walletAddress] = cachedENSName
+		}
 	}
 
-	cachedENSName, ok := res.(string)
-	if !ok {
-		return "", errors.New("could not cast ens name to string")
+	return cachedENSNames, nil
+}
+
+func DeleteCachedENSName(ctx context.Context, c cache.Cache, walletAddress string) error {
+	err := c.Del(ctx, getCacheKey(walletAddress))
+	if err != nil && err != cache.Nil {
+		return fmt.Errorf("could not evict ens name from cache: %w", err)
 	}
 
-	return cachedENSName, nil
+	return nil
+}
+
+// notifyENSNameResolved pushes a resolved ens name to the streams watching the profile. A failed
+// notification is only logged since watchers still see the name on their next read.
+func (processor *RedisTaskProcessor) notifyENSNameResolved(ctx context.Context, walletAddress string, entry CachedENSName) {
+	ensStatus := pb.EnsStatus_ENS_STATUS_NONE
+	if entry.Status == EnsNameStatusResolved {
+		ensStatus = pb.EnsStatus_ENS_STATUS_RESOLVED
+	}
+
+	err := processor.profileWatcher.Notify(ctx, &pb.ProfileChange{
+		Type:          pb.ProfileChangeType_PROFILE_CHANGE_TYPE_ENS_NAME_RESOLVED,
+		WalletAddress: walletAddress,
+		EnsName:       entry.Name,
+		EnsStatus:     ensStatus,
+		ChangedAt:     timestamppb.Now(),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("wallet_address", walletAddress).Msg("could not notify resolved ens name")
+	}
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hibiken/asynq"
 	mockcache "github.com/kyamalabs/users/internal/cache/mock"
 	"go.uber.org/mock/gomock"
 
@@ -45,7 +46,7 @@ func TestTaskCacheENSName(t *testing.T) {
 			payload := &PayloadCacheEnsName{
 				WalletAddress: tc.walletAddress,
 			}
-			err = testTaskDistributor.DistributeTaskCacheEnsName(context.Background(), payload)
+			err = CacheEnsNameTask.Enqueue(context.Background(), testTaskDistributor, payload)
 			require.NoError(t, err)
 
 			time.Sleep(3 * time.Second) // wait for the task to be processed
@@ -53,7 +54,7 @@ func TestTaskCacheENSName(t *testing.T) {
 			cachedENSName, err := GetCachedENSName(context.Background(), testRedisCache, tc.walletAddress)
 			require.NoError(t, err)
 
-			require.Equal(t, tc.expectedENSName, cachedENSName)
+			require.Equal(t, tc.expectedENSName, cachedENSName.Name)
 		})
 	}
 }
@@ -64,12 +65,47 @@ func TestGetCachedENSName(t *testing.T) {
 		walletAddress   string
 		buildStubs      func(cache *mockcache.MockCache)
 		expectedENSName string
+		expectedStatus  EnsNameStatus
 		expectedError   error
 		expectedToError bool
 	}{
 		{
 			name:          "success",
 			walletAddress: "0xc0ffee254729296a45a3885639AC7E10F9d54979",
+			buildStubs: func(cache *mockcache.MockCache) {
+				cache.EXPECT().
+					Get(gomock.Any(), getCacheKey("0xc0ffee254729296a45a3885639AC7E10F9d54979")).
+					Times(1).
+					Return(map[string]interface{}{
+						"status":      "resolved",
+						"name":        "bulba",
+						"resolved_at": "2024-02-12T06:51:34Z",
+					}, nil)
+			},
+			expectedENSName: "bulba",
+			expectedStatus:  EnsNameStatusResolved,
+			expectedToError: false,
+		},
+		{
+			name:          "negatively cached",
+			walletAddress: "0xc0ffee254729296a45a3885639AC7E10F9d54979",
+			buildStubs: func(cache *mockcache.MockCache) {
+				cache.EXPECT().
+					Get(gomock.Any(), getCacheKey("0xc0ffee254729296a45a3885639AC7E10F9d54979")).
+					Times(1).
+					Return(map[string]interface{}{
+						"status":      "none",
+						"name":        "",
+						"resolved_at": "2024-02-12T06:51:34Z",
+					}, nil)
+			},
+			expectedENSName: "",
+			expectedStatus:  EnsNameStatusNone,
+			expectedToError: false,
+		},
+		{
+			name:          "legacy string entry",
+			walletAddress: "0xc0ffee254729296a45a3885639AC7E10F9d54979",
 			buildStubs: func(cache *mockcache.MockCache) {
 				cache.EXPECT().
 					Get(gomock.Any(), getCacheKey("0xc0ffee254729296a45a3885639AC7E10F9d54979")).
@@ -77,6 +113,7 @@ func TestGetCachedENSName(t *testing.T) {
 					Return("bulba", nil)
 			},
 			expectedENSName: "bulba",
+			expectedStatus:  EnsNameStatusResolved,
 			expectedToError: false,
 		},
 		{
@@ -105,7 +142,7 @@ func TestGetCachedENSName(t *testing.T) {
 			expectedToError: true,
 		},
 		{
-			name:          "could not cast cached ens name to string",
+			name:          "could not decode cached ens name",
 			walletAddress: "0xc0ffee254729296a45a3885639AC7E10F9d54979",
 			buildStubs: func(cache *mockcache.MockCache) {
 				cache.EXPECT().
@@ -134,7 +171,110 @@ func TestGetCachedENSName(t *testing.T) {
 			if tc.expectedToError {
 				require.Error(t, err)
 			}
-			require.Equal(t, tc.expectedENSName, cachedENSName)
+			require.Equal(t, tc.expectedENSName, cachedENSName.Name)
+			require.Equal(t, tc.expectedStatus, cachedENSName.Status)
+		})
+	}
+}
+
+func TestDeleteCachedENSName(t *testing.T) {
+	walletAddress := "0xc0ffee254729296a45a3885639AC7E10F9d54979"
+
+	testCases := []struct {
+		name            string
+		delError        error
+		expectedToError bool
+	}{
+		{
+			name:            "success",
+			delError:        nil,
+			expectedToError: false,
+		},
+		{
+			name:            "ens name not cached",
+			delError:        cache.Nil,
+			expectedToError: false,
+		},
+		{
+			name:            "error deleting from cache",
+			delError:        errors.New("some cache error"),
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := mockcache.NewMockCache(ctrl)
+			c.EXPECT().
+				Del(gomock.Any(), getCacheKey(walletAddress)).
+				Times(1).
+				Return(tc.delError)
+
+			err := DeleteCachedENSName(context.Background(), c, walletAddress)
+			if tc.expectedToError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
 		})
 	}
 }
+
+func TestEnqueueTaskCacheEnsNameDeduplicates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test to maintain redis queue state")
+	}
+
+	payload := &PayloadCacheEnsName{
+		WalletAddress: "0x0000000000000000000000000000000000000029",
+	}
+	opts := []asynq.Option{
+		asynq.ProcessIn(time.Hour),
+		asynq.Queue(QueueDefault),
+	}
+
+	inspector := asynq.NewInspector(testRedisOpt)
+	defer inspector.Close()
+
+	listScheduled := func() []*asynq.TaskInfo {
+		tasks, err := inspector.ListScheduledTasks(QueueDefault, asynq.PageSize(1000))
+		require.NoError(t, err)
+
+		var scheduled []*asynq.TaskInfo
+		for _, task := range tasks {
+			if task.Type == TaskCacheENSName && string(task.Payload) == `{"wallet_address":"`+payload.WalletAddress+`"}` {
+				scheduled = append(scheduled, task)
+			}
+		}
+		return scheduled
+	}
+
+	// deleting a task also releases its uniqueness lock, so leftovers of earlier runs do not
+	// absorb the first enqueue
+	deleteScheduled := func() {
+		for _, task := range listScheduled() {
+			require.NoError(t, inspector.DeleteTask(QueueDefault, task.ID))
+		}
+	}
+	deleteScheduled()
+	defer deleteScheduled()
+
+	err := CacheEnsNameTask.Enqueue(context.Background(), testTaskDistributor, payload, opts...)
+	require.NoError(t, err)
+	require.Len(t, listScheduled(), 1)
+
+	for i := 0; i < 2; i++ {
+		err = CacheEnsNameTask.Enqueue(context.Background(), testTaskDistributor, payload, opts...)
+		require.NoError(t, err)
+	}
+	require.Len(t, listScheduled(), 1)
+}
+
+func TestIsENSNameNotFoundError(t *testing.T) {
+	require.True(t, isENSNameNotFoundError(errors.New("no resolution")))
+	require.True(t, isENSNameNotFoundError(errors.New("not a resolver")))
+	require.False(t, isENSNameNotFoundError(errors.New("dial tcp: i/o timeout")))
+}
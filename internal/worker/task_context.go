@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/internal/requestid"
+	"github.com/kyamalabs/users/internal/tracing"
+)
+
+// Tasks carry the request id and trace of the request that enqueued them in their task id, as
+// "<request id>.<traceparent>.<uuid>". asynq tasks have no headers and their payload is part of
+// the key unique tasks are deduplicated by, which leaves the task id as the only place for them.
+const taskIDSeparator = "."
+
+// newTaskID returns a task id carrying the request id and trace of ctx, or an empty string when
+// ctx carries neither so asynq generates the id.
+func newTaskID(ctx context.Context) string {
+	requestID := requestid.FromContext(ctx)
+	traceParent := tracing.TraceParent(ctx)
+
+	if requestID == "" && traceParent == "" {
+		return ""
+	}
+
+	return strings.Join([]string{requestID, traceParent, uuid.NewString()}, taskIDSeparator)
+}
+
+// taskContext restores the request id and trace carried by a task id built by newTaskID. Other
+// task ids leave ctx as it is.
+func taskContext(ctx context.Context, taskID string) context.Context {
+	parts := strings.Split(taskID, taskIDSeparator)
+	if len(parts) != 3 {
+		return ctx
+	}
+
+	requestID, traceParent := parts[0], parts[1]
+
+	ctx = tracing.ContextWithTraceParent(ctx, traceParent)
+	if requestID != "" {
+		ctx = requestid.NewContext(ctx, requestID)
+	}
+
+	return ctx
+}
+
+// withContextTaskID adds a task id carrying the request id and trace of ctx to opts, leaving
+// task ids callers rely on for idempotency untouched.
+func withContextTaskID(ctx context.Context, opts []asynq.Option) []asynq.Option {
+	for _, opt := range opts {
+		if opt.Type() == asynq.TaskIDOpt {
+			return opts
+		}
+	}
+
+	taskID := newTaskID(ctx)
+	if taskID == "" {
+		return opts
+	}
+
+	return append(opts, asynq.TaskID(taskID))
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/internal/requestid"
+	"github.com/kyamalabs/users/internal/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTaskContext(t *testing.T) {
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+	})
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "some-request")
+	defer span.End()
+
+	testCases := []struct {
+		name            string
+		ctx             context.Context
+		expectTaskID    bool
+		expectRequestID string
+		expectTrace     bool
+	}{
+		{
+			name: "no request id or trace",
+			ctx:  context.Background(),
+		},
+		{
+			name:            "request id",
+			ctx:             requestid.NewContext(context.Background(), "some-request-id"),
+			expectTaskID:    true,
+			expectRequestID: "some-request-id",
+		},
+		{
+			name:         "trace",
+			ctx:          ctx,
+			expectTaskID: true,
+			expectTrace:  true,
+		},
+		{
+			name:            "request id and trace",
+			ctx:             requestid.NewContext(ctx, "some-request-id"),
+			expectTaskID:    true,
+			expectRequestID: "some-request-id",
+			expectTrace:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskID := newTaskID(tc.ctx)
+			if !tc.expectTaskID {
+				require.Empty(t, taskID)
+				return
+			}
+
+			require.NotEqual(t, taskID, newTaskID(tc.ctx))
+
+			restored := taskContext(context.Background(), taskID)
+			require.Equal(t, tc.expectRequestID, requestid.FromContext(restored))
+
+			spanContext := trace.SpanContextFromContext(restored)
+			require.Equal(t, tc.expectTrace, spanContext.IsValid())
+			if tc.expectTrace {
+				require.Equal(t, span.SpanContext().TraceID(), spanContext.TraceID())
+			}
+		})
+	}
+}
+
+func TestTaskContext_UntaggedTaskID(t *testing.T) {
+	ctx := taskContext(context.Background(), "0b7e5f2a-3b8e-4b5e-9d39-6a9a3c1d2e4f")
+
+	require.Empty(t, requestid.FromContext(ctx))
+	require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func TestWithContextTaskID(t *testing.T) {
+	ctx := requestid.NewContext(context.Background(), "some-request-id")
+
+	opts := withContextTaskID(context.Background(), []asynq.Option{asynq.MaxRetry(2)})
+	require.Len(t, opts, 1)
+
+	opts = withContextTaskID(ctx, []asynq.Option{asynq.MaxRetry(2)})
+	require.Len(t, opts, 2)
+	require.Equal(t, asynq.TaskIDOpt, opts[1].Type())
+
+	// task ids set by callers for idempotency are kept
+	opts = withContextTaskID(ctx, []asynq.Option{asynq.TaskID("some-delivery-id")})
+	require.Len(t, opts, 1)
+	require.Equal(t, "some-delivery-id", opts[0].Value())
+}
@@ -0,0 +1,238 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/validator"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskDeliverWebhook = "task:deliver_webhook"
+
+	WebhookEventHeader     = "X-Kyama-Event"
+	WebhookDeliveryHeader  = "X-Kyama-Delivery"
+	WebhookTimestampHeader = "X-Kyama-Timestamp"
+	WebhookSignatureHeader = "X-Kyama-Signature"
+
+	webhookSignaturePrefix   = "sha256="
+	webhookRetryBaseDelay    = 10 * time.Second
+	webhookRetryMaxDelay     = time.Hour
+	webhookResponseReadLimit = 4096
+	defaultWebhookTimeout    = 10 * time.Second
+)
+
+var errWebhookAddressNotAllowed = errors.New("webhook endpoint resolves to an address that is not public")
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+type PayloadDeliverWebhook struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+var DeliverWebhookTask = RegisterTask(TaskDefinition[PayloadDeliverWebhook]{
+	Type:       TaskDeliverWebhook,
+	Queue:      QueueDefault,
+	RetryDelay: webhookRetryDelay,
+	Handle:     (*RedisTaskProcessor).processTaskDeliverWebhook,
+})
+
+// SignWebhookPayload computes the signature receivers use to check that a delivery came from this
+// service. The timestamp is signed along with the body so captured deliveries cannot be replayed later.
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (processor *RedisTaskProcessor) processTaskDeliverWebhook(ctx context.Context, payload *PayloadDeliverWebhook) error {
+	delivery, err := processor.store.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if errors.Is(err, db.RecordNotFoundError) {
+		// the webhook was deleted along with its deliveries
+		return fmt.Errorf("webhook delivery no longer exists: %w", asynq.SkipRetry)
+	}
+	if err != nil {
+		return fmt.Errorf("could not get webhook delivery: %w", err)
+	}
+
+	if delivery.Status == WebhookDeliveryStatusSucceeded {
+		log.Ctx(ctx).Info().
+			Str("delivery_id", delivery.ID.String()).
+			Msg("skipped webhook delivery that already succeeded")
+		return nil
+	}
+
+	webhook, err := processor.store.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, db.RecordNotFoundError) {
+		return fmt.Errorf("webhook no longer exists: %w", asynq.SkipRetry)
+	}
+	if err != nil {
+		return fmt.Errorf("could not get webhook: %w", err)
+	}
+
+	responseStatus, deliveryErr := processor.postWebhook(ctx, webhook, delivery)
+
+	params := db.UpdateWebhookDeliveryAttemptParams{
+		ID:     delivery.ID,
+		Status: WebhookDeliveryStatusSucceeded,
+		ResponseStatus: pgtype.Int4{
+			Int32: int32(responseStatus),
+			Valid: responseStatus != 0,
+		},
+	}
+
+	if deliveryErr == nil {
+		params.DeliveredAt = pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		}
+	} else {
+		params.Status = WebhookDeliveryStatusPending
+		if isLastRetry(ctx) {
+			params.Status = WebhookDeliveryStatusFailed
+		}
+		params.LastError = pgtype.Text{
+			String: deliveryErr.Error(),
+			Valid:  true,
+		}
+	}
+
+	_, err = processor.store.UpdateWebhookDeliveryAttempt(ctx, params)
+	if err != nil {
+		return fmt.Errorf("could not record webhook delivery attempt: %w", err)
+	}
+
+	if deliveryErr != nil {
+		return fmt.Errorf("could not deliver webhook: %w", deliveryErr)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("delivery_id", delivery.ID.String()).
+		Int("response_status", responseStatus).
+		Msg("delivered webhook")
+
+	return nil
+}
+
+// newWebhookClient returns the client webhooks are delivered with. The endpoint was checked when the
+// webhook was created, but its host may resolve to another address by now, so the address is checked
+// again when connecting. Redirects are not followed for the same reason.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !validator.IsPublicIP(ip) {
+				return errWebhookAddressNotAllowed
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the endpoint and defeat the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// postWebhook sends a delivery to the webhook endpoint and returns the response status, which is
+// zero when the endpoint could not be reached.
+func (processor *RedisTaskProcessor) postWebhook(ctx context.Context, webhook db.Webhook, delivery db.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("could not create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := processor.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// drain part of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, webhookResponseReadLimit))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook endpoint responded with status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+func isLastRetry(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return false
+	}
+
+	maxRetry, ok := asynq.GetMaxRetry(ctx)
+	if !ok {
+		return false
+	}
+
+	return retried >= maxRetry
+}
+
+// webhookRetryDelay backs webhook deliveries off exponentially so an endpoint that is down is
+// not hammered.
+func webhookRetryDelay(n int) time.Duration {
+	if n >= 10 {
+		return webhookRetryMaxDelay
+	}
+
+	delay := webhookRetryBaseDelay << n
+	if delay > webhookRetryMaxDelay {
+		return webhookRetryMaxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,196 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newTestWebhookReceiver(t *testing.T, statusCode int) (*httptest.Server, chan receivedWebhook) {
+	received := make(chan receivedWebhook, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		received <- receivedWebhook{header: r.Header, body: body}
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, received
+}
+
+func TestProcessTaskDeliverWebhook(t *testing.T) {
+	secret := "some-webhook-secret"
+
+	testCases := []struct {
+		name            string
+		statusCode      int
+		previousStatus  string
+		expectedToError bool
+		expectedStatus  string
+	}{
+		{
+			name:           "delivers signed payload",
+			statusCode:     http.StatusNoContent,
+			previousStatus: WebhookDeliveryStatusPending,
+			expectedStatus: WebhookDeliveryStatusSucceeded,
+		},
+		{
+			name:            "records failed attempt for retry",
+			statusCode:      http.StatusServiceUnavailable,
+			previousStatus:  WebhookDeliveryStatusPending,
+			expectedToError: true,
+			expectedStatus:  WebhookDeliveryStatusPending,
+		},
+		{
+			name:           "skips deliveries that already succeeded",
+			previousStatus: WebhookDeliveryStatusSucceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			receiver, received := newTestWebhookReceiver(t, tc.statusCode)
+
+			webhook := db.Webhook{
+				ID:         uuid.New(),
+				Url:        receiver.URL,
+				Secret:     secret,
+				EventTypes: []string{event.TypeReferralRecorded},
+			}
+
+			delivery := db.WebhookDelivery{
+				ID:        uuid.New(),
+				WebhookID: webhook.ID,
+				EventID:   uuid.NewString(),
+				EventType: event.TypeReferralRecorded,
+				Payload:   []byte(`{"id":"some-event-id"}`),
+				Status:    tc.previousStatus,
+			}
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetWebhookDelivery(gomock.Any(), delivery.ID).
+				Times(1).
+				Return(delivery, nil)
+
+			if tc.expectedStatus != "" {
+				store.EXPECT().
+					GetWebhook(gomock.Any(), webhook.ID).
+					Times(1).
+					Return(webhook, nil)
+
+				store.EXPECT().
+					UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, params db.UpdateWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+						require.Equal(t, delivery.ID, params.ID)
+						require.Equal(t, tc.expectedStatus, params.Status)
+						require.Equal(t, int32(tc.statusCode), params.ResponseStatus.Int32)
+						require.Equal(t, tc.expectedToError, params.LastError.Valid)
+						require.Equal(t, !tc.expectedToError, params.DeliveredAt.Valid)
+						return delivery, nil
+					})
+			}
+
+			processor := &RedisTaskProcessor{
+				store:         store,
+				webhookClient: &http.Client{Timeout: time.Second},
+			}
+
+			payload, err := json.Marshal(PayloadDeliverWebhook{DeliveryID: delivery.ID})
+			require.NoError(t, err)
+
+			err = DeliverWebhookTask.handler(processor)(context.Background(), asynq.NewTask(TaskDeliverWebhook, payload))
+			if tc.expectedToError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tc.expectedStatus == "" {
+				require.Empty(t, received)
+				return
+			}
+
+			req := <-received
+			require.Equal(t, delivery.Payload, req.body)
+			require.Equal(t, delivery.EventType, req.header.Get(WebhookEventHeader))
+			require.Equal(t, delivery.ID.String(), req.header.Get(WebhookDeliveryHeader))
+
+			timestamp := req.header.Get(WebhookTimestampHeader)
+			require.NotEmpty(t, timestamp)
+			require.Equal(t, SignWebhookPayload(secret, timestamp, req.body), req.header.Get(WebhookSignatureHeader))
+		})
+	}
+}
+
+func TestProcessTaskDeliverWebhook_DeletedWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveryID := uuid.New()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetWebhookDelivery(gomock.Any(), deliveryID).
+		Times(1).
+		Return(db.WebhookDelivery{}, db.RecordNotFoundError)
+
+	processor := &RedisTaskProcessor{store: store}
+
+	payload, err := json.Marshal(PayloadDeliverWebhook{DeliveryID: deliveryID})
+	require.NoError(t, err)
+
+	err = DeliverWebhookTask.handler(processor)(context.Background(), asynq.NewTask(TaskDeliverWebhook, payload))
+	require.ErrorIs(t, err, asynq.SkipRetry)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := SignWebhookPayload("some-webhook-secret", "1700000000", []byte("{}"))
+	require.Equal(t, "sha256=d1578b0d5cfa4e04e93e9056c732f3432065ec0e53cee2f6d0a157650466998f", signature)
+
+	require.NotEqual(t, signature, SignWebhookPayload("another-webhook-secret", "1700000000", []byte("{}")))
+	require.NotEqual(t, signature, SignWebhookPayload("some-webhook-secret", "1700000001", []byte("{}")))
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	require.Equal(t, webhookRetryBaseDelay, webhookRetryDelay(0))
+	require.Equal(t, 8*webhookRetryBaseDelay, webhookRetryDelay(3))
+	require.Equal(t, webhookRetryMaxDelay, webhookRetryDelay(9))
+	require.Equal(t, webhookRetryMaxDelay, webhookRetryDelay(100))
+}
+
+func TestNewWebhookClient(t *testing.T) {
+	client := newWebhookClient(0)
+	require.Equal(t, defaultWebhookTimeout, client.Timeout)
+
+	server, received := newTestWebhookReceiver(t, http.StatusNoContent)
+
+	// the test receiver listens on a loopback address
+	_, err := client.Post(server.URL, "application/json", nil)
+	require.ErrorIs(t, err, errWebhookAddressNotAllowed)
+	require.Empty(t, received)
+}
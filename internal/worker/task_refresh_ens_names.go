@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskRefreshENSNames = "task:refresh_ens_names"
+
+	defaultEnsRefreshBatchSize       int32 = 100
+	defaultEnsRefreshExpiryThreshold       = 24 * time.Hour
+	defaultEnsRefreshActiveWindow          = 24 * time.Hour
+	ensRefreshBatchSpacing                 = time.Minute
+)
+
+type PayloadRefreshEnsNames struct{}
+
+var RefreshEnsNamesTask = RegisterTask(TaskDefinition[PayloadRefreshEnsNames]{
+	Type:   TaskRefreshENSNames,
+	Queue:  QueueDefault,
+	Handle: (*RedisTaskProcessor).processTaskRefreshEnsNames,
+})
+
+func (processor *RedisTaskProcessor) processTaskRefreshEnsNames(ctx context.Context, _ *PayloadRefreshEnsNames) error {
+	batchSize := processor.config.EnsRefreshBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultEnsRefreshBatchSize
+	}
+
+	threshold := processor.config.EnsRefreshExpiryThreshold
+	if threshold <= 0 {
+		threshold = defaultEnsRefreshExpiryThreshold
+	}
+
+	activeWindow := processor.config.EnsRefreshActiveWindow
+	if activeWindow <= 0 {
+		activeWindow = defaultEnsRefreshActiveWindow
+	}
+
+	// only profiles whose ens name is missing, expires within the threshold or was resolved
+	// before the profile was last updated within the active window are listed, a batch at a
+	// time in wallet address order, so the cache is never scanned
+	now := time.Now()
+	expiresBefore := now.Add(threshold)
+	activeSince := now.Add(-activeWindow)
+
+	var afterWalletAddress string
+	var batch, enqueued int
+
+	for {
+		walletAddresses, err := processor.store.ListProfilesDueForEnsRefresh(ctx, db.ListProfilesDueForEnsRefreshParams{
+			AfterWalletAddress: afterWalletAddress,
+			ExpiresBefore:      expiresBefore,
+			ActiveSince:        activeSince,
+			BatchSize:          batchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("could not list profiles due for ens name refresh: %w", err)
+		}
+
+		for _, walletAddress := range walletAddresses {
+			payload := &PayloadCacheEnsName{
+				WalletAddress: walletAddress,
+			}
+			err = CacheEnsNameTask.Enqueue(
+				ctx,
+				processor.taskDistributor,
+				payload,
+				asynq.MaxRetry(3),
+				asynq.ProcessIn(time.Duration(batch)*ensRefreshBatchSpacing),
+				asynq.Queue(QueueDefault),
+			)
+			if err != nil {
+				return fmt.Errorf("could not enqueue ens name refresh: %w", err)
+			}
+
+			enqueued++
+		}
+
+		if int32(len(walletAddresses)) < batchSize {
+			break
+		}
+
+		afterWalletAddress = walletAddresses[len(walletAddresses)-1]
+		batch++
+	}
+
+	log.Ctx(ctx).Info().
+		Int("refreshes_enqueued", enqueued).
+		Msg("enqueued refreshes of expiring and recently active ens names")
+
+	return nil
+}
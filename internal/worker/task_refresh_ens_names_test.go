@@ -0,0 +1,154 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingTaskDistributor struct {
+	tasks []*asynq.Task
+	err   error
+}
+
+func (d *recordingTaskDistributor) Enqueue(_ context.Context, task *asynq.Task, _ ...asynq.Option) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.tasks = append(d.tasks, task)
+	return nil
+}
+
+// recordedPayloads returns the payloads of the recorded tasks of the given type.
+func recordedPayloads[P any](t *testing.T, d *recordingTaskDistributor, task *Task[P]) []*P {
+	var payloads []*P
+	for _, recorded := range d.tasks {
+		if recorded.Type() != task.Type() {
+			continue
+		}
+
+		payload, err := task.Payload(recorded)
+		require.NoError(t, err)
+		payloads = append(payloads, payload)
+	}
+
+	return payloads
+}
+
+func TestProcessTaskRefreshEnsNames(t *testing.T) {
+	walletAddresses := []string{
+		"0x0000000000000000000000000000000000000001",
+		"0x0000000000000000000000000000000000000002",
+		"0x0000000000000000000000000000000000000003",
+	}
+
+	testCases := []struct {
+		name                 string
+		distributorErr       error
+		buildStubs           func(store *mockdb.MockStore)
+		expectedToError      bool
+		expectedRefreshedFor []string
+	}{
+		{
+			name: "enqueues profiles due for refresh a batch at a time",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListProfilesDueForEnsRefresh(gomock.Any(), listProfilesDueForEnsRefreshParams("", 2)).
+					Times(1).
+					Return(walletAddresses[:2], nil)
+				store.EXPECT().
+					ListProfilesDueForEnsRefresh(gomock.Any(), listProfilesDueForEnsRefreshParams(walletAddresses[1], 2)).
+					Times(1).
+					Return(walletAddresses[2:], nil)
+			},
+			expectedRefreshedFor: walletAddresses,
+		},
+		{
+			name: "no profiles due for refresh",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListProfilesDueForEnsRefresh(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]string{}, nil)
+			},
+		},
+		{
+			name: "db error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListProfilesDueForEnsRefresh(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some db error"))
+			},
+			expectedToError: true,
+		},
+		{
+			name:           "task distributor error",
+			distributorErr: errors.New("some task distributor error"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListProfilesDueForEnsRefresh(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(walletAddresses[:1], nil)
+			},
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			distributor := &recordingTaskDistributor{err: tc.distributorErr}
+
+			tc.buildStubs(store)
+
+			processor := &RedisTaskProcessor{
+				config: util.Config{
+					EnsRefreshBatchSize:       2,
+					EnsRefreshExpiryThreshold: 24 * time.Hour,
+					EnsRefreshActiveWindow:    time.Hour,
+				},
+				store:           store,
+				taskDistributor: distributor,
+			}
+
+			err := RefreshEnsNamesTask.handler(processor)(context.Background(), asynq.NewTask(TaskRefreshENSNames, nil))
+			if tc.expectedToError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var refreshedFor []string
+			for _, payload := range recordedPayloads(t, distributor, CacheEnsNameTask) {
+				refreshedFor = append(refreshedFor, payload.WalletAddress)
+			}
+			require.Equal(t, tc.expectedRefreshedFor, refreshedFor)
+		})
+	}
+}
+
+// listProfilesDueForEnsRefreshParams matches the params of a batch listed after the wallet
+// address, whose expiry and activity cutoffs depend on when the task ran.
+func listProfilesDueForEnsRefreshParams(afterWalletAddress string, batchSize int32) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		params, ok := x.(db.ListProfilesDueForEnsRefreshParams)
+		return ok &&
+			params.AfterWalletAddress == afterWalletAddress &&
+			params.BatchSize == batchSize &&
+			time.Until(params.ExpiresBefore) > 23*time.Hour &&
+			time.Since(params.ActiveSince) >= time.Hour &&
+			time.Since(params.ActiveSince) < 2*time.Hour
+	})
+}
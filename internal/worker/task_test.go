@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type payloadTestTask struct {
+	Value string `json:"value"`
+}
+
+func registerTestTask(t *testing.T, handle func(processor *RedisTaskProcessor, ctx context.Context, payload *payloadTestTask) error) *Task[payloadTestTask] {
+	task := RegisterTask(TaskDefinition[payloadTestTask]{
+		Type:       "task:test",
+		Queue:      QueueCritical,
+		MaxRetry:   2,
+		RetryDelay: func(n int) time.Duration { return time.Duration(n) * time.Minute },
+		Handle:     handle,
+	})
+	t.Cleanup(func() {
+		delete(taskRegistry, task.Type())
+	})
+
+	return task
+}
+
+func TestRegisterTask(t *testing.T) {
+	task := registerTestTask(t, nil)
+
+	registered, ok := lookupTask(task.Type())
+	require.True(t, ok)
+	require.Equal(t, task, registered)
+
+	require.Panics(t, func() {
+		RegisterTask(TaskDefinition[payloadTestTask]{Type: task.Type()})
+	})
+
+	var types []string
+	for _, registered := range registeredTasks() {
+		types = append(types, registered.taskType())
+	}
+	require.Equal(t, []string{TaskCacheENSName, TaskDeliverWebhook, TaskRefreshENSNames, task.Type()}, types)
+}
+
+func TestTask_NewTask(t *testing.T) {
+	task := registerTestTask(t, nil)
+
+	asynqTask, err := task.NewTask(&payloadTestTask{Value: "some-value"})
+	require.NoError(t, err)
+	require.Equal(t, task.Type(), asynqTask.Type())
+	require.JSONEq(t, `{"value": "some-value"}`, string(asynqTask.Payload()))
+
+	payload, err := task.Payload(asynqTask)
+	require.NoError(t, err)
+	require.Equal(t, "some-value", payload.Value)
+
+	_, err = task.Payload(asynq.NewTask(TaskCacheENSName, asynqTask.Payload()))
+	require.Error(t, err)
+
+	_, err = task.newRawTask([]byte(`not json`))
+	require.Error(t, err)
+}
+
+func TestTask_Handler(t *testing.T) {
+	handleErr := errors.New("some handler error")
+
+	var handled []*payloadTestTask
+	task := registerTestTask(t, func(_ *RedisTaskProcessor, _ context.Context, payload *payloadTestTask) error {
+		handled = append(handled, payload)
+		if payload.Value == "fail" {
+			return handleErr
+		}
+		return nil
+	})
+
+	handler := task.handler(&RedisTaskProcessor{})
+
+	err := handler(context.Background(), asynq.NewTask(task.Type(), []byte(`{"value": "some-value"}`)))
+	require.NoError(t, err)
+
+	err = handler(context.Background(), asynq.NewTask(task.Type(), []byte(`{"value": "fail"}`)))
+	require.ErrorIs(t, err, handleErr)
+
+	// undecodable payloads are not retried
+	err = handler(context.Background(), asynq.NewTask(task.Type(), []byte(`not json`)))
+	require.ErrorIs(t, err, asynq.SkipRetry)
+
+	require.Len(t, handled, 2)
+	require.Equal(t, "some-value", handled[0].Value)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.TasksProcessed.WithLabelValues(task.Type(), metrics.TaskResultSucceeded)))
+	require.Equal(t, float64(2), testutil.ToFloat64(metrics.TasksProcessed.WithLabelValues(task.Type(), metrics.TaskResultFailed)))
+}
+
+func TestRetryDelay(t *testing.T) {
+	task := registerTestTask(t, nil)
+
+	require.Equal(t, 3*time.Minute, retryDelay(3, nil, asynq.NewTask(task.Type(), nil)))
+	require.Equal(t, webhookRetryDelay(2), retryDelay(2, nil, asynq.NewTask(TaskDeliverWebhook, nil)))
+	require.Positive(t, retryDelay(1, nil, asynq.NewTask(TaskCacheENSName, nil)))
+}
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/api/pb"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	"github.com/kyamalabs/users/internal/util"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// WebhookEventPublisher records a delivery for every webhook subscribed to an event and enqueues
+// a task to send it. Deliveries are keyed by webhook and event id, so an event relayed more than
+// once is still only delivered once per webhook.
+type WebhookEventPublisher struct {
+	store           db.Store
+	taskDistributor TaskDistributor
+	maxRetry        int
+}
+
+func NewWebhookEventPublisher(config util.Config, store db.Store, taskDistributor TaskDistributor) event.EventPublisher {
+	return &WebhookEventPublisher{
+		store:           store,
+		taskDistributor: taskDistributor,
+		maxRetry:        config.WebhookMaxRetry,
+	}
+}
+
+func (publisher *WebhookEventPublisher) Publish(ctx context.Context, e *pb.Event) error {
+	eventType := event.Type(e)
+
+	webhooks, err := publisher.store.ListWebhooksForEventType(ctx, eventType)
+	if err != nil {
+		return fmt.Errorf("could not list webhooks for event type: %w", err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := protojson.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("could not serialize event: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		delivery, err := publisher.store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			WebhookID: webhook.ID,
+			EventID:   e.GetId(),
+			EventType: eventType,
+			Payload:   payload,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create webhook delivery: %w", err)
+		}
+
+		err = DeliverWebhookTask.Enqueue(
+			ctx,
+			publisher.taskDistributor,
+			&PayloadDeliverWebhook{DeliveryID: delivery.ID},
+			asynq.MaxRetry(publisher.maxRetry),
+			asynq.TaskID(delivery.ID.String()),
+		)
+		if err != nil {
+			return fmt.Errorf("could not enqueue webhook delivery: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	mockdb "github.com/kyamalabs/users/internal/db/mock"
+	db "github.com/kyamalabs/users/internal/db/sqlc"
+	"github.com/kyamalabs/users/internal/event"
+	"github.com/kyamalabs/users/internal/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestWebhookEventPublisher_Publish(t *testing.T) {
+	e := event.NewReferralRecorded("0x0000000000000000000000000000000000000002", "0x0000000000000000000000000000000000000001")
+
+	webhooks := []db.Webhook{
+		{ID: uuid.New(), EventTypes: []string{event.TypeReferralRecorded}},
+		{ID: uuid.New(), EventTypes: []string{event.TypeReferralRecorded, event.TypeProfileCreated}},
+	}
+
+	payload, err := protojson.Marshal(e)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		distributorErr  error
+		buildStubs      func(store *mockdb.MockStore) []uuid.UUID
+		expectedToError bool
+	}{
+		{
+			name: "records and enqueues a delivery per webhook",
+			buildStubs: func(store *mockdb.MockStore) []uuid.UUID {
+				store.EXPECT().
+					ListWebhooksForEventType(gomock.Any(), event.TypeReferralRecorded).
+					Times(1).
+					Return(webhooks, nil)
+
+				var deliveryIDs []uuid.UUID
+				for _, webhook := range webhooks {
+					deliveryID := uuid.New()
+					deliveryIDs = append(deliveryIDs, deliveryID)
+
+					store.EXPECT().
+						CreateWebhookDelivery(gomock.Any(), db.CreateWebhookDeliveryParams{
+							WebhookID: webhook.ID,
+							EventID:   e.GetId(),
+							EventType: event.TypeReferralRecorded,
+							Payload:   payload,
+						}).
+						Times(1).
+						Return(db.WebhookDelivery{ID: deliveryID, WebhookID: webhook.ID}, nil)
+				}
+
+				return deliveryIDs
+			},
+		},
+		{
+			name: "no subscribed webhooks",
+			buildStubs: func(store *mockdb.MockStore) []uuid.UUID {
+				store.EXPECT().
+					ListWebhooksForEventType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, nil)
+
+				store.EXPECT().
+					CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return nil
+			},
+		},
+		{
+			name:           "task distributor error",
+			distributorErr: errors.New("some queue error"),
+			buildStubs: func(store *mockdb.MockStore) []uuid.UUID {
+				store.EXPECT().
+					ListWebhooksForEventType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(webhooks[:1], nil)
+
+				store.EXPECT().
+					CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WebhookDelivery{ID: uuid.New()}, nil)
+
+				return nil
+			},
+			expectedToError: true,
+		},
+		{
+			name: "db error",
+			buildStubs: func(store *mockdb.MockStore) []uuid.UUID {
+				store.EXPECT().
+					ListWebhooksForEventType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("some db error"))
+
+				return nil
+			},
+			expectedToError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			distributor := &recordingTaskDistributor{err: tc.distributorErr}
+
+			expectedDeliveryIDs := tc.buildStubs(store)
+
+			publisher := NewWebhookEventPublisher(util.Config{WebhookMaxRetry: 8}, store, distributor)
+
+			err := publisher.Publish(context.Background(), e)
+			if tc.expectedToError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var deliveryIDs []uuid.UUID
+			for _, payload := range recordedPayloads(t, distributor, DeliverWebhookTask) {
+				deliveryIDs = append(deliveryIDs, payload.DeliveryID)
+			}
+			require.Equal(t, expectedDeliveryIDs, deliveryIDs)
+		})
+	}
+}
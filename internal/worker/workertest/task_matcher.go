@@ -0,0 +1,39 @@
+// Package workertest provides helpers for testing code that enqueues worker tasks.
+package workertest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hibiken/asynq"
+	"github.com/kyamalabs/users/internal/worker"
+	"go.uber.org/mock/gomock"
+)
+
+type enqueuedTaskMatcher[P any] struct {
+	task    *worker.Task[P]
+	payload *P
+}
+
+// EnqueuedTask matches tasks of the registered type that carry the payload.
+func EnqueuedTask[P any](task *worker.Task[P], payload *P) gomock.Matcher {
+	return enqueuedTaskMatcher[P]{task: task, payload: payload}
+}
+
+func (m enqueuedTaskMatcher[P]) Matches(x interface{}) bool {
+	t, ok := x.(*asynq.Task)
+	if !ok {
+		return false
+	}
+
+	payload, err := m.task.Payload(t)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(m.payload, payload)
+}
+
+func (m enqueuedTaskMatcher[P]) String() string {
+	return fmt.Sprintf("is a %s task with payload %+v", m.task.Type(), *m.payload)
+}